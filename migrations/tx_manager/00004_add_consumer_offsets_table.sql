@@ -0,0 +1,13 @@
+-- +goose Up
+
+create table consumer_offsets(
+    consumer_group text not null,
+    topic text not null,
+    partition_id int not null,
+    next_offset bigint not null,
+    primary key (consumer_group, topic, partition_id)
+);
+
+-- +goose Down
+
+drop table consumer_offsets;
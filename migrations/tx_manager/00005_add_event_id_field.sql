@@ -0,0 +1,7 @@
+-- +goose Up
+
+alter table transactions add column event_id text unique;
+
+-- +goose Down
+
+alter table transactions drop column event_id;
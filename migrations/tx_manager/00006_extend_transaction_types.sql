@@ -0,0 +1,23 @@
+-- +goose Up
+
+alter table transactions alter column transaction_type type varchar(32);
+
+alter table transactions add constraint chk_transaction_type check (
+    transaction_type in ('bet', 'win', 'deposit', 'withdrawal', 'refund', 'rollback', 'bonus_credit', 'bonus_conversion', 'jackpot_win')
+);
+
+alter table transactions add column direction varchar(6);
+
+update transactions
+set direction = case when transaction_type = 'bet' then 'debit' else 'credit' end
+where direction is null;
+
+alter table transactions alter column direction set not null;
+alter table transactions add constraint chk_direction check (direction in ('credit', 'debit'));
+
+-- +goose Down
+
+alter table transactions drop constraint chk_direction;
+alter table transactions drop column direction;
+alter table transactions drop constraint chk_transaction_type;
+alter table transactions alter column transaction_type type varchar(10);
@@ -0,0 +1,15 @@
+-- +goose Up
+
+alter table transactions add column reference_id text;
+alter table transactions add column original_id uuid references transactions(id);
+
+create index idx_original_id on transactions(original_id);
+create index idx_unresolved_reference_id on transactions(reference_id) where original_id is null;
+
+-- +goose Down
+
+drop index if exists idx_unresolved_reference_id;
+drop index if exists idx_original_id;
+
+alter table transactions drop column original_id;
+alter table transactions drop column reference_id;
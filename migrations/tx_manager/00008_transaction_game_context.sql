@@ -0,0 +1,23 @@
+-- +goose Up
+
+alter table transactions add column round_id text;
+alter table transactions add column game_id text;
+alter table transactions add column provider text;
+alter table transactions add column session_id text;
+alter table transactions add column channel text;
+
+create index idx_round_id on transactions(round_id);
+create index idx_game_id on transactions(game_id);
+create index idx_session_id on transactions(session_id);
+
+-- +goose Down
+
+drop index if exists idx_session_id;
+drop index if exists idx_game_id;
+drop index if exists idx_round_id;
+
+alter table transactions drop column channel;
+alter table transactions drop column session_id;
+alter table transactions drop column provider;
+alter table transactions drop column game_id;
+alter table transactions drop column round_id;
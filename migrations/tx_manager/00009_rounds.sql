@@ -0,0 +1,39 @@
+-- +goose Up
+
+create table rounds(
+    provider text not null default '',
+    round_id text not null,
+    user_id uuid not null,
+    game_id text,
+    bet_total bigint not null default 0,
+    win_total bigint not null default 0,
+    net bigint not null default 0,
+    bet_count int not null default 0,
+    win_count int not null default 0,
+    opened_at timestamp with time zone not null,
+    settled_at timestamp with time zone,
+    updated_at timestamp with time zone not null default now(),
+    primary key (provider, round_id)
+);
+
+create index idx_rounds_open on rounds(opened_at) where settled_at is null or bet_count = 0;
+
+insert into rounds (provider, round_id, user_id, game_id, bet_total, win_total, net, bet_count, win_count, opened_at, settled_at)
+select coalesce(provider, ''),
+       round_id,
+       (array_agg(user_id order by transaction_time))[1],
+       max(game_id),
+       coalesce(sum(amount) filter (where transaction_type = 'bet'), 0),
+       coalesce(sum(amount) filter (where transaction_type in ('win', 'jackpot_win')), 0),
+       sum(case when direction = 'credit' then amount else -amount end),
+       count(*) filter (where transaction_type = 'bet'),
+       count(*) filter (where transaction_type in ('win', 'jackpot_win')),
+       min(transaction_time),
+       min(transaction_time) filter (where transaction_type in ('win', 'jackpot_win', 'refund', 'rollback'))
+from transactions
+where round_id is not null
+group by coalesce(provider, ''), round_id;
+
+-- +goose Down
+
+drop table rounds;
@@ -0,0 +1,39 @@
+-- +goose Up
+
+create table balances(
+    user_id uuid primary key,
+    balance bigint not null default 0,
+    credit_total bigint not null default 0,
+    debit_total bigint not null default 0,
+    transaction_count bigint not null default 0,
+    last_transaction_time timestamp with time zone not null,
+    updated_at timestamp with time zone not null default now()
+);
+
+insert into balances (user_id, balance, credit_total, debit_total, transaction_count, last_transaction_time)
+select user_id,
+       sum(case when direction = 'credit' then amount else -amount end),
+       coalesce(sum(amount) filter (where direction = 'credit'), 0),
+       coalesce(sum(amount) filter (where direction = 'debit'), 0),
+       count(*),
+       max(transaction_time)
+from transactions
+group by user_id;
+
+-- A rollback whose original hasn't arrived yet is stored without a direction and booked once it does.
+alter table transactions alter column direction drop not null;
+alter table transactions add constraint chk_unresolved_direction check (
+    direction is not null or (transaction_type = 'rollback' and original_id is null)
+);
+
+-- A transaction is rolled back at most once.
+create unique index idx_rollback_original_id on transactions(original_id) where transaction_type = 'rollback';
+
+-- +goose Down
+
+drop index idx_rollback_original_id;
+alter table transactions drop constraint chk_unresolved_direction;
+delete from transactions where direction is null;
+alter table transactions alter column direction set not null;
+
+drop table balances;
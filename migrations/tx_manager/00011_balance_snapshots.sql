@@ -0,0 +1,39 @@
+-- +goose Up
+
+create table balance_snapshots(
+    user_id uuid not null,
+    taken_at timestamp with time zone not null,
+    balance bigint not null,
+    transaction_count bigint not null,
+    primary key (user_id, taken_at)
+);
+
+create index idx_user_id_transaction_time_id on transactions(user_id, transaction_time, id);
+
+-- Snapshots taken after the transaction time already miss it when it arrives late.
+-- The trigger runs at the end of the insert statement, after the balance row of the user is locked,
+-- so it also sees snapshots committed by the snapshot job while the insert waited for that lock.
+-- +goose StatementBegin
+create function shift_balance_snapshots() returns trigger as $$
+begin
+    update balance_snapshots
+    set balance = balance + case when new.direction = 'credit' then new.amount else -new.amount end,
+        transaction_count = transaction_count + 1
+    where user_id = new.user_id and taken_at >= new.transaction_time;
+
+    return null;
+end;
+$$ language plpgsql;
+-- +goose StatementEnd
+
+-- Rollbacks waiting for their original aren't booked yet and shift the snapshots once they are.
+create trigger trg_shift_balance_snapshots
+    after insert on transactions
+    for each row when (new.direction is not null) execute function shift_balance_snapshots();
+
+-- +goose Down
+
+drop trigger trg_shift_balance_snapshots on transactions;
+drop function shift_balance_snapshots();
+drop index idx_user_id_transaction_time_id;
+drop table balance_snapshots;
@@ -0,0 +1,56 @@
+-- +goose Up
+
+create table postings(
+    id uuid primary key default gen_random_uuid(),
+    transaction_id uuid not null references transactions(id),
+    account text not null,
+    amount bigint not null,
+    transaction_time timestamp with time zone not null,
+    created_at timestamp with time zone not null default now()
+);
+
+-- Covers the balance of named accounts, e.g. house, so it is summed from the index alone.
+create index idx_postings_account on postings(account, transaction_time, id) include (amount);
+create index idx_postings_transaction_id on postings(transaction_id);
+
+insert into postings (transaction_id, account, amount, transaction_time)
+select id, 'player:' || user_id, case when direction = 'credit' then amount else -amount end, transaction_time
+from transactions
+union all
+select t.id,
+       case coalesce(o.transaction_type, t.transaction_type)
+           when 'deposit' then 'bank'
+           when 'withdrawal' then 'bank'
+           when 'bonus_credit' then 'bonus_pool'
+           when 'bonus_conversion' then 'bonus_pool'
+           when 'jackpot_win' then 'jackpot_pool'
+           else 'house'
+       end,
+       case when t.direction = 'credit' then -t.amount else t.amount end,
+       t.transaction_time
+from transactions t
+left join transactions o on o.id = t.original_id and t.transaction_type = 'rollback';
+
+-- +goose StatementBegin
+create function check_postings_balanced() returns trigger as $$
+begin
+    if (select sum(amount) from postings where transaction_id = new.transaction_id) <> 0 then
+        raise exception 'postings of transaction % are unbalanced', new.transaction_id
+            using errcode = 'check_violation';
+    end if;
+
+    return null;
+end;
+$$ language plpgsql;
+-- +goose StatementEnd
+
+create constraint trigger trg_postings_balanced
+    after insert or update on postings
+    deferrable initially deferred
+    for each row execute function check_postings_balanced();
+
+-- +goose Down
+
+drop trigger trg_postings_balanced on postings;
+drop function check_postings_balanced();
+drop table postings;
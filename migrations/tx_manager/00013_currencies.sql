@@ -0,0 +1,67 @@
+-- +goose Up
+
+alter table transactions alter column amount type bigint;
+alter table transactions add column currency char(3) not null default 'EUR';
+
+alter table postings add column currency char(3) not null default 'EUR';
+alter table rounds add column currency char(3) not null default 'EUR';
+
+alter table balances add column currency char(3) not null default 'EUR';
+alter table balances drop constraint balances_pkey;
+alter table balances add primary key (user_id, currency);
+
+alter table balance_snapshots add column currency char(3) not null default 'EUR';
+alter table balance_snapshots drop constraint balance_snapshots_pkey;
+alter table balance_snapshots add primary key (user_id, currency, taken_at);
+
+-- Late transactions only shift the snapshots of their own currency.
+-- +goose StatementBegin
+create or replace function shift_balance_snapshots() returns trigger as $$
+begin
+    update balance_snapshots
+    set balance = balance + case when new.direction = 'credit' then new.amount else -new.amount end,
+        transaction_count = transaction_count + 1
+    where user_id = new.user_id and currency = new.currency and taken_at >= new.transaction_time;
+
+    return null;
+end;
+$$ language plpgsql;
+-- +goose StatementEnd
+
+-- Keeps covering the balance of named accounts, now per currency.
+drop index idx_postings_account;
+create index idx_postings_account on postings(account, currency, transaction_time, id) include (amount);
+
+-- +goose Down
+
+drop index idx_postings_account;
+create index idx_postings_account on postings(account, transaction_time, id) include (amount);
+
+-- +goose StatementBegin
+create or replace function shift_balance_snapshots() returns trigger as $$
+begin
+    update balance_snapshots
+    set balance = balance + case when new.direction = 'credit' then new.amount else -new.amount end,
+        transaction_count = transaction_count + 1
+    where user_id = new.user_id and taken_at >= new.transaction_time;
+
+    return null;
+end;
+$$ language plpgsql;
+-- +goose StatementEnd
+
+delete from balance_snapshots where currency <> 'EUR';
+alter table balance_snapshots drop constraint balance_snapshots_pkey;
+alter table balance_snapshots add primary key (user_id, taken_at);
+alter table balance_snapshots drop column currency;
+
+delete from balances where currency <> 'EUR';
+alter table balances drop constraint balances_pkey;
+alter table balances add primary key (user_id);
+alter table balances drop column currency;
+
+alter table rounds drop column currency;
+alter table postings drop column currency;
+
+alter table transactions drop column currency;
+alter table transactions alter column amount type int;
@@ -0,0 +1,16 @@
+-- +goose Up
+
+-- Time ranges and keyset pages are ordered by (transaction_time, id), the user's are covered by idx_user_id_transaction_time_id.
+create index idx_transaction_time_id on transactions(transaction_time, id);
+create index idx_transaction_type_time_id on transactions(transaction_type, transaction_time, id);
+create index idx_transaction_amount on transactions(amount);
+
+drop index if exists idx_transaction_type;
+
+-- +goose Down
+
+create index idx_transaction_type on transactions(transaction_type);
+
+drop index if exists idx_transaction_amount;
+drop index if exists idx_transaction_type_time_id;
+drop index if exists idx_transaction_time_id;
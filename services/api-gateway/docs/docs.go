@@ -18,9 +18,199 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/ledger/accounts/{account}/postings": {
+            "get": {
+                "description": "Returns postings of a ledger account, newest first, with the account balance.\nEvery transaction is posted twice: to the player wallet and to house, bank, bonus_pool or jackpot_pool, and its postings sum to zero.\nPlayer wallets are named player:\u003cuser id\u003e.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ledger"
+                ],
+                "summary": "Get the ledger postings of an account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Account name",
+                        "name": "account",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "EUR",
+                        "description": "ISO 4217 code",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 code to also convert amounts to, with the rate valid at their time",
+                        "name": "reporting_currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of postings to return",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Pagination offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Postings and account balance",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.postings"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request parameters",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rounds/open": {
+            "get": {
+                "description": "Returns rounds that are not settled yet, oldest first, with their transactions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rounds"
+                ],
+                "summary": "Get a list of open rounds",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only rounds of this user",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only rounds open for longer than the orphan timeout",
+                        "name": "orphaned",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of rounds to return",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Pagination offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Open rounds",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.rounds"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request parameters",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rounds/{id}": {
+            "get": {
+                "description": "Returns a round with its transactions, totals and net result.\nA round is settled once it has a bet and a win, refund or rollback.\nOrphaned rounds have been open for longer than the configured timeout, unmatched_win marks wins without a bet.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rounds"
+                ],
+                "summary": "Get a game round",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Round ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Provider of the round, round ids are only unique per provider",
+                        "name": "provider",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Round object",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.round"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Round not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
         "/transactions": {
             "get": {
-                "description": "Returns transactions with optional filtering, pagination, and ordering",
+                "description": "Returns transactions matching filters, newest first and paged by cursor unless orderBy or offset is given. Amounts are in minor units.",
                 "consumes": [
                     "application/json"
                 ],
@@ -35,28 +225,51 @@ const docTemplate = `{
                     {
                         "type": "integer",
                         "default": 10,
-                        "description": "Number of transactions to return",
+                        "description": "Number of transactions to return, at most 1000",
                         "name": "limit",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "default": 0,
-                        "description": "Pagination offset",
+                        "description": "Legacy pagination offset, can't be combined with cursor",
                         "name": "offset",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Field to order by, e.g., amount desc",
+                        "description": "Comma separated fields to order by, - for descending, e.g. -amount,timestamp. One of user_id, type, amount, timestamp. Selects the legacy offset paging",
                         "name": "orderBy",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "JSON-encoded filters, e.g., {\\",
+                        "description": "RSQL filter expression, e.g. type==bet;amount\u003e1000, or legacy JSON-encoded filters, e.g., {\\",
                         "name": "filters",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 code to also convert amounts to, with the rate valid at their time",
+                        "name": "reporting_currency",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "exact",
+                            "estimated"
+                        ],
+                        "type": "string",
+                        "default": "estimated",
+                        "description": "How to count total, estimated is the planner's cheaper but rougher estimate, exact counts every matching row",
+                        "name": "count",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -83,7 +296,7 @@ const docTemplate = `{
         },
         "/transactions/{id}": {
             "get": {
-                "description": "Returns a transaction by its UUID",
+                "description": "Returns a transaction by its UUID together with its chain: the original transaction and everything referencing it, e.g. rollbacks, oldest first.\nA transaction referenced by a rollback has status voided.",
                 "consumes": [
                     "application/json"
                 ],
@@ -107,7 +320,7 @@ const docTemplate = `{
                     "200": {
                         "description": "Transaction object",
                         "schema": {
-                            "$ref": "#/definitions/handlers.transaction"
+                            "$ref": "#/definitions/handlers.transactionWithChain"
                         }
                     },
                     "400": {
@@ -130,34 +343,616 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "handlers.transaction": {
-            "type": "object",
-            "properties": {
-                "amount": {
-                    "type": "integer"
-                },
-                "date": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "type": {
-                    "type": "string"
-                },
-                "user_id": {
-                    "type": "string"
+        },
+        "/transactions:batchGet": {
+            "post": {
+                "description": "Returns the transactions with the given UUIDs in request order, repeated ids once, and the ids that weren't found.\nThe number of ids per request is capped by TRANSACTIONS_MAX_BATCH_SIZE of the transaction manager, default 100.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Get several transactions by ID",
+                "parameters": [
+                    {
+                        "description": "Transaction IDs",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.batchGetRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency to also report amounts in, e.g. USD",
+                        "name": "reporting_currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Found transactions and missing IDs",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.transactionBatch"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid body, no ids or too many ids",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
                 }
             }
         },
-        "handlers.transactions": {
-            "type": "object",
-            "properties": {
-                "total": {
-                    "type": "integer"
+        "/users/{id}/balance": {
+            "get": {
+                "description": "Returns the wallet balance of a user: the sum of credits minus debits of all their transactions.\nBet and withdrawal are debits, a rollback takes the reverse of the transaction it voids, all other types are credits.\nA rollback counts once the transaction it voids arrives. Balances are kept per currency, in minor units.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get the balance of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "EUR",
+                        "description": "ISO 4217 code",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 code to also convert amounts to, with the rate valid at their time",
+                        "name": "reporting_currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Balance object",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.balance"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or missing ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "User has no transactions",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/balance/at": {
+            "get": {
+                "description": "Returns the balance of a user including every transaction up to the given time.\nIt is replayed from the nearest balance snapshot, a user without transactions by then has balance 0.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get the balance of a user at a point in time",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 timestamp, e.g., 2025-01-01T14:03:00Z",
+                        "name": "time",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "EUR",
+                        "description": "ISO 4217 code",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 code to also convert amounts to, with the rate valid at their time",
+                        "name": "reporting_currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Balance at the given time",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.balancePoint"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request parameters",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/balance/history": {
+            "get": {
+                "description": "Returns the balance of a user at from, after every step and at to, the last step may be shorter.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get the balance history of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 start of the range",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 end of the range",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1h",
+                        "description": "Duration between points, e.g., 15m or 24h",
+                        "name": "step",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "EUR",
+                        "description": "ISO 4217 code",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 code to also convert amounts to, with the rate valid at their time",
+                        "name": "reporting_currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Balance time series",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.balanceHistory"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request parameters",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handlers.balance": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "integer"
+                },
+                "credit_total": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "debit_total": {
+                    "type": "integer"
+                },
+                "last_transaction_time": {
+                    "type": "string"
+                },
+                "reporting": {
+                    "$ref": "#/definitions/handlers.conversion"
+                },
+                "transaction_count": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.balanceHistory": {
+            "type": "object",
+            "properties": {
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.balancePoint"
+                    }
+                }
+            }
+        },
+        "handlers.balancePoint": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "reporting": {
+                    "$ref": "#/definitions/handlers.conversion"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "transaction_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.batchGetRequest": {
+            "type": "object",
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handlers.conversion": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "amount_decimal": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "string"
+                },
+                "rate_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.posting": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string"
+                },
+                "amount": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reporting": {
+                    "$ref": "#/definitions/handlers.conversion"
+                },
+                "transaction_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.postings": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "integer"
+                },
+                "postings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.posting"
+                    }
+                },
+                "reporting_balance": {
+                    "$ref": "#/definitions/handlers.conversion"
+                }
+            }
+        },
+        "handlers.round": {
+            "type": "object",
+            "properties": {
+                "bet_count": {
+                    "type": "integer"
+                },
+                "bet_total": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "game_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "net": {
+                    "type": "integer"
+                },
+                "opened_at": {
+                    "type": "string"
+                },
+                "orphaned": {
+                    "type": "boolean"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "settled_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.transaction"
+                    }
+                },
+                "unmatched_win": {
+                    "type": "boolean"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "win_count": {
+                    "type": "integer"
+                },
+                "win_total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.rounds": {
+            "type": "object",
+            "properties": {
+                "rounds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.round"
+                    }
+                }
+            }
+        },
+        "handlers.transaction": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "amount_decimal": {
+                    "type": "string"
+                },
+                "channel": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "direction": {
+                    "type": "string"
+                },
+                "game_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "original_id": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "reference_id": {
+                    "type": "string"
+                },
+                "reporting": {
+                    "$ref": "#/definitions/handlers.conversion"
+                },
+                "round_id": {
+                    "type": "string"
+                },
+                "session_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.transactionBatch": {
+            "type": "object",
+            "properties": {
+                "missing_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.transaction"
+                    }
+                }
+            }
+        },
+        "handlers.transactionWithChain": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "amount_decimal": {
+                    "type": "string"
+                },
+                "chain": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.transaction"
+                    }
+                },
+                "channel": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "direction": {
+                    "type": "string"
+                },
+                "game_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "original_id": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "reference_id": {
+                    "type": "string"
+                },
+                "reporting": {
+                    "$ref": "#/definitions/handlers.conversion"
+                },
+                "round_id": {
+                    "type": "string"
+                },
+                "session_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.transactions": {
+            "type": "object",
+            "properties": {
+                "next_cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_exact": {
+                    "type": "boolean"
                 },
                 "transactions": {
                     "type": "array",
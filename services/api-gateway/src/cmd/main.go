@@ -57,6 +57,13 @@ func createHttpHandler(managerClient *client.TxManagerClient) http.Handler {
 
 	mx.HandleFunc("GET /api/v1/transactions/{id}", h.GetTransactionByID)
 	mx.HandleFunc("GET /api/v1/transactions", h.GetTransactions)
+	mx.HandleFunc("POST /api/v1/transactions:batchGet", h.BatchGetTransactions)
+	mx.HandleFunc("GET /api/v1/rounds/open", h.ListOpenRounds)
+	mx.HandleFunc("GET /api/v1/rounds/{id}", h.GetRound)
+	mx.HandleFunc("GET /api/v1/users/{id}/balance", h.GetUserBalance)
+	mx.HandleFunc("GET /api/v1/users/{id}/balance/at", h.GetBalanceAt)
+	mx.HandleFunc("GET /api/v1/users/{id}/balance/history", h.GetBalanceHistory)
+	mx.HandleFunc("GET /api/v1/ledger/accounts/{account}/postings", h.ListPostings)
 	mx.HandleFunc("GET /ping", h.Healthcheck)
 
 	mx.Handle("/swagger/", httpSwagger.Handler(
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/e1esm/casino-transaction-system/api-gateway/src/internal/config"
@@ -18,6 +19,13 @@ import (
 type ProtoClient interface {
 	GetTransactionByID(ctx context.Context, in *txProto.GetTransactionByIDRequest, opts ...grpc.CallOption) (*txProto.GetTransactionByIDResponse, error)
 	GetTransactionByFilters(ctx context.Context, in *txProto.GetTransactionByFiltersRequest, opts ...grpc.CallOption) (*txProto.GetTransactionByFiltersResponse, error)
+	GetRound(ctx context.Context, in *txProto.GetRoundRequest, opts ...grpc.CallOption) (*txProto.GetRoundResponse, error)
+	ListOpenRounds(ctx context.Context, in *txProto.ListOpenRoundsRequest, opts ...grpc.CallOption) (*txProto.ListOpenRoundsResponse, error)
+	GetUserBalance(ctx context.Context, in *txProto.GetUserBalanceRequest, opts ...grpc.CallOption) (*txProto.GetUserBalanceResponse, error)
+	GetBalanceAt(ctx context.Context, in *txProto.GetBalanceAtRequest, opts ...grpc.CallOption) (*txProto.GetBalanceAtResponse, error)
+	GetBalanceHistory(ctx context.Context, in *txProto.GetBalanceHistoryRequest, opts ...grpc.CallOption) (*txProto.GetBalanceHistoryResponse, error)
+	ListPostings(ctx context.Context, in *txProto.ListPostingsRequest, opts ...grpc.CallOption) (*txProto.ListPostingsResponse, error)
+	BatchGetTransactions(ctx context.Context, in *txProto.BatchGetTransactionsRequest, opts ...grpc.CallOption) (*txProto.BatchGetTransactionsResponse, error)
 }
 
 type TxManagerClient struct {
@@ -59,33 +67,209 @@ func (c *TxManagerClient) GetTransactionByID(ctx context.Context, id uuid.UUID)
 		return entities.Transaction{}, err
 	}
 
+	tx.Chain, err = convertProtoTransactionsToEntities(resp.Chain)
+	if err != nil {
+		return entities.Transaction{}, err
+	}
+
 	return tx, nil
 }
 
-func (c *TxManagerClient) GetTransactions(
-	ctx context.Context,
-	filter entities.TransactionFilter,
-	orderBy string,
-	limit,
-	offset int64) ([]entities.Transaction, int, error) {
+func (c *TxManagerClient) BatchGetTransactions(ctx context.Context, ids []uuid.UUID, reportingCurrency string) (entities.TransactionBatch, error) {
+	rawIDs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		rawIDs = append(rawIDs, id.String())
+	}
+
+	resp, err := c.cli.BatchGetTransactions(ctx, &txProto.BatchGetTransactionsRequest{
+		Ids:               rawIDs,
+		ReportingCurrency: reportingCurrency,
+	})
+	if err != nil {
+		return entities.TransactionBatch{}, mapReturnedCodeToSvcError(err)
+	}
+
+	transactions, err := convertProtoTransactionsToEntities(resp.Transactions)
+	if err != nil {
+		return entities.TransactionBatch{}, err
+	}
+
+	missing := make([]uuid.UUID, 0, len(resp.MissingIds))
+	for _, raw := range resp.MissingIds {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return entities.TransactionBatch{}, err
+		}
+
+		missing = append(missing, id)
+	}
+
+	return entities.TransactionBatch{
+		Transactions: transactions,
+		MissingIDs:   missing,
+	}, nil
+}
+
+func (c *TxManagerClient) GetTransactions(ctx context.Context, query entities.TransactionQuery) (entities.TransactionPage, error) {
+	filter := query.Filter
+
+	countMode := txProto.CountMode_CountEstimated
+	if query.ExactTotal {
+		countMode = txProto.CountMode_CountExact
+	}
 
 	resp, err := c.cli.GetTransactionByFilters(ctx, &txProto.GetTransactionByFiltersRequest{
 		Filters: &txProto.Filters{
-			Type:   transactionTypeEntityToProto[filter.Type],
-			UserId: filter.UserID,
+			Type:      transactionTypeEntityToProto[filter.Type],
+			UserId:    filter.UserID,
+			RoundId:   filter.RoundID,
+			GameId:    filter.GameID,
+			Provider:  filter.Provider,
+			SessionId: filter.SessionID,
+			Channel:   filter.Channel,
+			Currency:  filter.Currency,
+			From:      unixOrNil(filter.From),
+			To:        unixOrNil(filter.To),
+			MinAmount: filter.MinAmount,
+			MaxAmount: filter.MaxAmount,
 		},
-		OrderBy: orderBy,
-		Limit:   limit,
-		Offset:  offset,
+		OrderBy:           convertSortToProto(query.OrderBy),
+		Limit:             query.Limit,
+		Offset:            query.Offset,
+		ReportingCurrency: query.ReportingCurrency,
+		PageToken:         query.Cursor,
+		CountMode:         countMode,
+		Filter:            convertFilterExprToProto(query.Expr),
 	})
 	if err != nil {
-		return nil, 0, mapReturnedCodeToSvcError(err)
+		return entities.TransactionPage{}, mapReturnedCodeToSvcError(err)
 	}
 
 	transactions, err := convertProtoTransactionsToEntities(resp.Transaction)
 	if err != nil {
-		return nil, 0, err
+		return entities.TransactionPage{}, err
+	}
+
+	return entities.TransactionPage{
+		Transactions: transactions,
+		Total:        resp.Total,
+		TotalExact:   resp.TotalExact,
+		NextCursor:   resp.NextPageToken,
+	}, nil
+}
+
+func (c *TxManagerClient) GetRound(ctx context.Context, provider, id string) (entities.Round, error) {
+	resp, err := c.cli.GetRound(ctx, &txProto.GetRoundRequest{
+		Id:       id,
+		Provider: provider,
+	})
+	if err != nil {
+		return entities.Round{}, mapReturnedCodeToSvcError(err)
+	}
+
+	return convertProtoRoundToEntity(resp.Round)
+}
+
+func (c *TxManagerClient) ListOpenRounds(ctx context.Context, filter entities.RoundFilter, limit, offset int64) ([]entities.Round, error) {
+	resp, err := c.cli.ListOpenRounds(ctx, &txProto.ListOpenRoundsRequest{
+		UserId:       filter.UserID,
+		OrphanedOnly: filter.OrphanedOnly,
+		Limit:        limit,
+		Offset:       offset,
+	})
+	if err != nil {
+		return nil, mapReturnedCodeToSvcError(err)
+	}
+
+	rounds := make([]entities.Round, 0, len(resp.Rounds))
+	for _, r := range resp.Rounds {
+		round, err := convertProtoRoundToEntity(r)
+		if err != nil {
+			return nil, err
+		}
+
+		rounds = append(rounds, round)
+	}
+
+	return rounds, nil
+}
+
+func (c *TxManagerClient) GetUserBalance(ctx context.Context, userID uuid.UUID, currency, reportingCurrency string) (entities.Balance, error) {
+	resp, err := c.cli.GetUserBalance(ctx, &txProto.GetUserBalanceRequest{
+		UserId:            userID.String(),
+		Currency:          currency,
+		ReportingCurrency: reportingCurrency,
+	})
+	if err != nil {
+		return entities.Balance{}, mapReturnedCodeToSvcError(err)
+	}
+
+	return convertProtoBalanceToEntity(resp.Balance)
+}
+
+func (c *TxManagerClient) GetBalanceAt(ctx context.Context, userID uuid.UUID, currency, reportingCurrency string, at time.Time) (entities.BalancePoint, error) {
+	resp, err := c.cli.GetBalanceAt(ctx, &txProto.GetBalanceAtRequest{
+		UserId:            userID.String(),
+		Timestamp:         at.Unix(),
+		Currency:          currency,
+		ReportingCurrency: reportingCurrency,
+	})
+	if err != nil {
+		return entities.BalancePoint{}, mapReturnedCodeToSvcError(err)
+	}
+
+	if resp.Balance == nil {
+		return entities.BalancePoint{}, errors.New("balance is empty")
+	}
+
+	return convertProtoBalancePointToEntity(resp.Balance), nil
+}
+
+func (c *TxManagerClient) GetBalanceHistory(ctx context.Context, userID uuid.UUID, currency, reportingCurrency string, from, to time.Time, step time.Duration) ([]entities.BalancePoint, error) {
+	resp, err := c.cli.GetBalanceHistory(ctx, &txProto.GetBalanceHistoryRequest{
+		UserId:            userID.String(),
+		From:              from.Unix(),
+		To:                to.Unix(),
+		StepSeconds:       int64(step / time.Second),
+		Currency:          currency,
+		ReportingCurrency: reportingCurrency,
+	})
+	if err != nil {
+		return nil, mapReturnedCodeToSvcError(err)
+	}
+
+	points := make([]entities.BalancePoint, 0, len(resp.Points))
+	for _, p := range resp.Points {
+		points = append(points, convertProtoBalancePointToEntity(p))
+	}
+
+	return points, nil
+}
+
+func (c *TxManagerClient) ListPostings(ctx context.Context, account, currency, reportingCurrency string, limit, offset int64) ([]entities.Posting, entities.AccountBalance, error) {
+	resp, err := c.cli.ListPostings(ctx, &txProto.ListPostingsRequest{
+		Account:           account,
+		Currency:          currency,
+		ReportingCurrency: reportingCurrency,
+		Limit:             limit,
+		Offset:            offset,
+	})
+	if err != nil {
+		return nil, entities.AccountBalance{}, mapReturnedCodeToSvcError(err)
+	}
+
+	postings := make([]entities.Posting, 0, len(resp.Postings))
+	for _, p := range resp.Postings {
+		posting, err := convertProtoPostingToEntity(p)
+		if err != nil {
+			return nil, entities.AccountBalance{}, err
+		}
+
+		postings = append(postings, posting)
 	}
 
-	return transactions, len(transactions), nil
+	return postings, entities.AccountBalance{
+		Balance:   resp.Balance,
+		Reporting: convertProtoConversionToEntity(resp.ReportingBalance),
+	}, nil
 }
@@ -2,7 +2,9 @@ package client
 
 import (
 	"context"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/e1esm/casino-transaction-system/api-gateway/src/internal/client/mocks"
 	"github.com/e1esm/casino-transaction-system/api-gateway/src/internal/entities"
@@ -27,7 +29,28 @@ func TestTxManagerClientGetTransactionByID(t *testing.T) {
 		mockErr     error
 		expectedErr bool
 		expectedID  uuid.UUID
+		chainLen    int
 	}{
+		{
+			name:  "success with chain",
+			reqID: id,
+			mockResp: &txProto.GetTransactionByIDResponse{
+				Transaction: &txProto.Transaction{Id: id.String(), UserId: uuid.NewString(), Type: txProto.TransactionType_Bet, Status: txProto.Status_Voided},
+				Chain: []*txProto.Transaction{
+					{Id: id.String(), UserId: uuid.NewString(), Type: txProto.TransactionType_Bet, Status: txProto.Status_Voided},
+					{Id: uuid.NewString(), UserId: uuid.NewString(), Type: txProto.TransactionType_Rollback, ReferenceId: "bet-1", OriginalId: id.String()},
+				},
+			},
+			expectedID: id,
+			chainLen:   2,
+		},
+		{
+			name:        "invalid original id in chain",
+			reqID:       id,
+			mockResp:    &txProto.GetTransactionByIDResponse{Transaction: &txProto.Transaction{Id: id.String(), UserId: uuid.NewString()}, Chain: []*txProto.Transaction{{Id: uuid.NewString(), UserId: uuid.NewString(), OriginalId: "invalid-id"}}},
+			expectedErr: true,
+			expectedID:  id,
+		},
 		{
 			name:        "success",
 			reqID:       id,
@@ -68,6 +91,7 @@ func TestTxManagerClientGetTransactionByID(t *testing.T) {
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, tt.expectedID.String(), tx.ID.String())
+				assert.Len(t, tx.Chain, tt.chainLen)
 			}
 
 			mockCli.AssertExpectations(t)
@@ -80,13 +104,18 @@ func TestTxManagerClient_GetTransactions(t *testing.T) {
 	client := NewClientFromProto(mockCli)
 
 	userID := uuid.New().String()
+	from := time.Date(2025, 1, 1, 22, 0, 0, 0, time.UTC)
+	to := from.Add(8 * time.Hour)
+	minAmount := int64(1000)
 
 	tests := []struct {
 		name          string
 		filter        entities.TransactionFilter
-		orderBy       string
+		orderBy       []entities.Sort
+		cursor        string
 		limit         int64
 		offset        int64
+		exactTotal    bool
 		mockResp      *txProto.GetTransactionByFiltersResponse
 		mockErr       error
 		expectedErr   bool
@@ -98,7 +127,7 @@ func TestTxManagerClient_GetTransactions(t *testing.T) {
 				Type:   entities.Bet,
 				UserID: userID,
 			},
-			orderBy: "amount",
+			orderBy: []entities.Sort{{Field: entities.SortByAmount, Desc: true}, {Field: entities.SortByTimestamp}},
 			limit:   10,
 			offset:  0,
 			mockResp: &txProto.GetTransactionByFiltersResponse{
@@ -106,18 +135,79 @@ func TestTxManagerClient_GetTransactions(t *testing.T) {
 					{Id: uuid.New().String(), UserId: uuid.NewString(), Amount: 100, Type: txProto.TransactionType_Bet},
 					{Id: uuid.New().String(), UserId: uuid.NewString(), Amount: 200, Type: txProto.TransactionType_Bet},
 				},
+				Total:      2,
+				TotalExact: true,
 			},
 			mockErr:       nil,
 			expectedErr:   false,
 			expectedCount: 2,
 		},
+		{
+			name: "success with game context filters",
+			filter: entities.TransactionFilter{
+				RoundID:   "round-1",
+				GameID:    "book-of-dead",
+				Provider:  "playngo",
+				SessionID: "session-1",
+				Channel:   "mobile",
+			},
+			limit: 10,
+			mockResp: &txProto.GetTransactionByFiltersResponse{
+				Transaction: []*txProto.Transaction{
+					{Id: uuid.New().String(), UserId: uuid.NewString(), Amount: 100, Type: txProto.TransactionType_Bet, RoundId: "round-1"},
+				},
+			},
+			expectedCount: 1,
+		},
+		{
+			name: "success with time and amount ranges",
+			filter: entities.TransactionFilter{
+				Type:      entities.Bet,
+				From:      &from,
+				To:        &to,
+				MinAmount: &minAmount,
+			},
+			limit: 10,
+			mockResp: &txProto.GetTransactionByFiltersResponse{
+				Transaction: []*txProto.Transaction{
+					{Id: uuid.New().String(), UserId: uuid.NewString(), Amount: 1500, Type: txProto.TransactionType_Bet},
+				},
+			},
+			expectedCount: 1,
+		},
+		{
+			name:       "next page by cursor with an exact total",
+			cursor:     "abc",
+			limit:      1,
+			exactTotal: true,
+			mockResp: &txProto.GetTransactionByFiltersResponse{
+				Transaction: []*txProto.Transaction{
+					{Id: uuid.New().String(), UserId: uuid.NewString(), Amount: 100, Type: txProto.TransactionType_Bet},
+				},
+				NextPageToken: "def",
+				Total:         5,
+				TotalExact:    true,
+			},
+			expectedCount: 1,
+		},
+		{
+			name:  "estimated total by default",
+			limit: 1,
+			mockResp: &txProto.GetTransactionByFiltersResponse{
+				Transaction: []*txProto.Transaction{
+					{Id: uuid.New().String(), UserId: uuid.NewString(), Amount: 100, Type: txProto.TransactionType_Bet},
+				},
+				Total: 1200000,
+			},
+			expectedCount: 1,
+		},
 		{
 			name: "grpc error",
 			filter: entities.TransactionFilter{
 				Type:   entities.Win,
 				UserID: userID,
 			},
-			orderBy:       "amount",
+			orderBy:       []entities.Sort{{Field: entities.SortByAmount}},
 			limit:         5,
 			offset:        0,
 			mockResp:      nil,
@@ -131,7 +221,7 @@ func TestTxManagerClient_GetTransactions(t *testing.T) {
 				Type:   entities.Win,
 				UserID: userID,
 			},
-			orderBy: "amount",
+			orderBy: []entities.Sort{{Field: entities.SortByAmount}},
 			limit:   5,
 			offset:  0,
 			mockResp: &txProto.GetTransactionByFiltersResponse{
@@ -149,7 +239,7 @@ func TestTxManagerClient_GetTransactions(t *testing.T) {
 				Type:   entities.Win,
 				UserID: userID,
 			},
-			orderBy: "amount",
+			orderBy: []entities.Sort{{Field: entities.SortByAmount}},
 			limit:   5,
 			offset:  -2,
 			mockResp: &txProto.GetTransactionByFiltersResponse{
@@ -166,41 +256,345 @@ func TestTxManagerClient_GetTransactions(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			expectedProtoType := transactionTypeEntityToProto[tt.filter.Type]
+			expectedCountMode := txProto.CountMode_CountEstimated
+			if tt.exactTotal {
+				expectedCountMode = txProto.CountMode_CountExact
+			}
 
 			mockCli.
 				On("GetTransactionByFilters",
 					mock.Anything,
 					mock.MatchedBy(func(req *txProto.GetTransactionByFiltersRequest) bool {
-						return req.OrderBy == tt.orderBy &&
+						return slices.EqualFunc(req.OrderBy, convertSortToProto(tt.orderBy), func(a, b *txProto.SortField) bool {
+							return a.Field == b.Field && a.Direction == b.Direction
+						}) &&
 							req.Limit == tt.limit &&
 							req.Offset == tt.offset &&
+							req.PageToken == tt.cursor &&
+							req.CountMode == expectedCountMode &&
 							req.Filters != nil &&
 							req.Filters.UserId == tt.filter.UserID &&
-							req.Filters.Type == expectedProtoType
+							req.Filters.Type == expectedProtoType &&
+							req.Filters.RoundId == tt.filter.RoundID &&
+							req.Filters.GameId == tt.filter.GameID &&
+							req.Filters.Provider == tt.filter.Provider &&
+							req.Filters.SessionId == tt.filter.SessionID &&
+							req.Filters.Channel == tt.filter.Channel &&
+							assert.ObjectsAreEqual(unixOrNil(tt.filter.From), req.Filters.From) &&
+							assert.ObjectsAreEqual(unixOrNil(tt.filter.To), req.Filters.To) &&
+							assert.ObjectsAreEqual(tt.filter.MinAmount, req.Filters.MinAmount) &&
+							assert.ObjectsAreEqual(tt.filter.MaxAmount, req.Filters.MaxAmount)
 					}),
 				).
 				Return(tt.mockResp, tt.mockErr).
 				Once()
 
-			result, count, err := client.GetTransactions(
-				context.Background(),
-				tt.filter,
-				tt.orderBy,
-				tt.limit,
-				tt.offset,
-			)
+			result, err := client.GetTransactions(context.Background(), entities.TransactionQuery{
+				Filter:     tt.filter,
+				OrderBy:    tt.orderBy,
+				Cursor:     tt.cursor,
+				Limit:      tt.limit,
+				Offset:     tt.offset,
+				ExactTotal: tt.exactTotal,
+			})
+
+			if tt.expectedErr {
+				assert.Error(t, err)
+				assert.Nil(t, result.Transactions)
+				assert.Zero(t, result.Total)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, result.Transactions, tt.expectedCount)
+				assert.Equal(t, tt.mockResp.Total, result.Total)
+				assert.Equal(t, tt.mockResp.TotalExact, result.TotalExact)
+				assert.Equal(t, tt.mockResp.NextPageToken, result.NextCursor)
+			}
+
+			mockCli.AssertExpectations(t)
+		})
+	}
+}
+
+func TestConvertSortToProto(t *testing.T) {
+	got := convertSortToProto([]entities.Sort{
+		{Field: entities.SortByAmount, Desc: true},
+		{Field: entities.SortByTimestamp},
+		{Field: entities.SortByUserID},
+		{Field: entities.SortByType, Desc: true},
+	})
+
+	want := []*txProto.SortField{
+		{Field: txProto.SortKey_SortAmount, Direction: txProto.SortDirection_Descending},
+		{Field: txProto.SortKey_SortTime, Direction: txProto.SortDirection_Ascending},
+		{Field: txProto.SortKey_SortUserId, Direction: txProto.SortDirection_Ascending},
+		{Field: txProto.SortKey_SortType, Direction: txProto.SortDirection_Descending},
+	}
+
+	assert.Len(t, got, len(want))
+	for i := range want {
+		assert.Equal(t, want[i].Field, got[i].Field)
+		assert.Equal(t, want[i].Direction, got[i].Direction)
+	}
+}
 
+func TestConvertFilterExprToProto(t *testing.T) {
+	day := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	got := convertFilterExprToProto(&entities.FilterExpr{And: []entities.FilterExpr{
+		{Comparison: &entities.Comparison{Field: entities.FilterType, Op: entities.OpIn, Values: []any{"bet", "win"}}},
+		{Or: []entities.FilterExpr{
+			{Comparison: &entities.Comparison{Field: entities.FilterAmount, Op: entities.OpGreater, Values: []any{int64(1000)}}},
+			{Comparison: &entities.Comparison{Field: entities.FilterDate, Op: entities.OpGreaterOrEqual, Values: []any{day}}},
+		}},
+	}})
+
+	and := got.GetAnd().GetOperands()
+	assert.Len(t, and, 2)
+
+	typeCmp := and[0].GetComparison()
+	assert.Equal(t, txProto.FilterField_FilterType, typeCmp.GetField())
+	assert.Equal(t, txProto.ComparisonOp_In, typeCmp.GetOp())
+	assert.Equal(t, "bet", typeCmp.GetValues()[0].GetText())
+	assert.Equal(t, "win", typeCmp.GetValues()[1].GetText())
+
+	or := and[1].GetOr().GetOperands()
+	assert.Len(t, or, 2)
+	assert.Equal(t, txProto.ComparisonOp_Greater, or[0].GetComparison().GetOp())
+	assert.Equal(t, int64(1000), or[0].GetComparison().GetValues()[0].GetInteger())
+	assert.Equal(t, txProto.FilterField_FilterDate, or[1].GetComparison().GetField())
+	assert.Equal(t, day.Unix(), or[1].GetComparison().GetValues()[0].GetTime())
+
+	assert.Nil(t, convertFilterExprToProto(nil))
+}
+
+func TestTxManagerClient_BatchGetTransactions(t *testing.T) {
+	mockCli := new(mocks.MockProtoClient)
+	client := NewClientFromProto(mockCli)
+	found, missing := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name        string
+		mockResp    *txProto.BatchGetTransactionsResponse
+		mockErr     error
+		expectedErr bool
+	}{
+		{
+			name: "success",
+			mockResp: &txProto.BatchGetTransactionsResponse{
+				Transactions: []*txProto.Transaction{{Id: found.String(), UserId: uuid.NewString(), Type: txProto.TransactionType_Bet}},
+				MissingIds:   []string{missing.String()},
+			},
+		},
+		{
+			name:        "too many ids",
+			mockErr:     status.Error(codes.InvalidArgument, "too many ids"),
+			expectedErr: true,
+		},
+		{
+			name:        "invalid missing id",
+			mockResp:    &txProto.BatchGetTransactionsResponse{MissingIds: []string{"invalid-id"}},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCli.On("BatchGetTransactions", mock.Anything, &txProto.BatchGetTransactionsRequest{
+				Ids:               []string{found.String(), missing.String()},
+				ReportingCurrency: "USD",
+			}).Return(tt.mockResp, tt.mockErr).Once()
+
+			batch, err := client.BatchGetTransactions(context.Background(), []uuid.UUID{found, missing}, "USD")
 			if tt.expectedErr {
 				assert.Error(t, err)
-				assert.Nil(t, result)
-				assert.Equal(t, 0, count)
 			} else {
 				assert.NoError(t, err)
-				assert.NotNil(t, result)
-				assert.Equal(t, tt.expectedCount, count)
+				assert.Len(t, batch.Transactions, 1)
+				assert.Equal(t, found, batch.Transactions[0].ID)
+				assert.Equal(t, []uuid.UUID{missing}, batch.MissingIDs)
 			}
 
 			mockCli.AssertExpectations(t)
 		})
 	}
 }
+
+func TestTxManagerClient_GetRound(t *testing.T) {
+	mockCli := new(mocks.MockProtoClient)
+	client := NewClientFromProto(mockCli)
+	userID := uuid.New()
+
+	tests := []struct {
+		name        string
+		mockResp    *txProto.GetRoundResponse
+		mockErr     error
+		expectedErr bool
+	}{
+		{
+			name: "success",
+			mockResp: &txProto.GetRoundResponse{Round: &txProto.Round{
+				Id:           "round-1",
+				UserId:       userID.String(),
+				Status:       txProto.RoundStatus_Open,
+				Orphaned:     true,
+				Transactions: []*txProto.Transaction{{Id: uuid.NewString(), UserId: userID.String(), Type: txProto.TransactionType_Bet}},
+			}},
+		},
+		{
+			name:        "round not found",
+			mockErr:     status.Error(codes.NotFound, "round not found"),
+			expectedErr: true,
+		},
+		{
+			name:        "invalid user id",
+			mockResp:    &txProto.GetRoundResponse{Round: &txProto.Round{Id: "round-1", UserId: "invalid-id"}},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCli.On("GetRound", mock.Anything, &txProto.GetRoundRequest{Id: "round-1", Provider: "pragmatic"}).Return(tt.mockResp, tt.mockErr).Once()
+
+			round, err := client.GetRound(context.Background(), "pragmatic", "round-1")
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "round-1", round.ID)
+				assert.Equal(t, entities.RoundOpen, round.Status)
+				assert.True(t, round.Orphaned)
+				assert.Len(t, round.Transactions, 1)
+			}
+
+			mockCli.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTxManagerClient_ListOpenRounds(t *testing.T) {
+	mockCli := new(mocks.MockProtoClient)
+	client := NewClientFromProto(mockCli)
+	userID := uuid.New()
+
+	mockCli.On("ListOpenRounds", mock.Anything, mock.MatchedBy(func(req *txProto.ListOpenRoundsRequest) bool {
+		return req.UserId == userID.String() && req.OrphanedOnly && req.Limit == 10 && req.Offset == 5
+	})).Return(&txProto.ListOpenRoundsResponse{Rounds: []*txProto.Round{
+		{Id: "round-1", UserId: userID.String()},
+		{Id: "round-2", UserId: userID.String()},
+	}}, nil).Once()
+
+	rounds, err := client.ListOpenRounds(context.Background(), entities.RoundFilter{UserID: userID.String(), OrphanedOnly: true}, 10, 5)
+	assert.NoError(t, err)
+	assert.Len(t, rounds, 2)
+
+	mockCli.On("ListOpenRounds", mock.Anything, mock.Anything).Return(nil, status.Error(codes.InvalidArgument, "invalid limit")).Once()
+
+	_, err = client.ListOpenRounds(context.Background(), entities.RoundFilter{}, 0, 0)
+	assert.Error(t, err)
+
+	mockCli.AssertExpectations(t)
+}
+
+func TestTxManagerClient_GetUserBalance(t *testing.T) {
+	mockCli := new(mocks.MockProtoClient)
+	client := NewClientFromProto(mockCli)
+	userID := uuid.New()
+
+	tests := []struct {
+		name        string
+		mockResp    *txProto.GetUserBalanceResponse
+		mockErr     error
+		expectedErr bool
+	}{
+		{
+			name:     "success",
+			mockResp: &txProto.GetUserBalanceResponse{Balance: &txProto.Balance{UserId: userID.String(), Balance: 750, CreditTotal: 1050, DebitTotal: 300, TransactionCount: 3}},
+		},
+		{
+			name:        "user without transactions",
+			mockErr:     status.Error(codes.NotFound, "user has no transactions"),
+			expectedErr: true,
+		},
+		{
+			name:        "invalid user id",
+			mockResp:    &txProto.GetUserBalanceResponse{Balance: &txProto.Balance{UserId: "invalid-id"}},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCli.On("GetUserBalance", mock.Anything, &txProto.GetUserBalanceRequest{UserId: userID.String(), Currency: "EUR"}).Return(tt.mockResp, tt.mockErr).Once()
+
+			balance, err := client.GetUserBalance(context.Background(), userID, "EUR", "")
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, userID, balance.UserID)
+				assert.Equal(t, int64(750), balance.Balance)
+				assert.Equal(t, int64(3), balance.TransactionCount)
+			}
+
+			mockCli.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTxManagerClient_GetBalanceHistory(t *testing.T) {
+	mockCli := new(mocks.MockProtoClient)
+	client := NewClientFromProto(mockCli)
+	userID := uuid.New()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(2 * time.Hour)
+
+	mockCli.On("GetBalanceHistory", mock.Anything, &txProto.GetBalanceHistoryRequest{
+		UserId: userID.String(), From: from.Unix(), To: to.Unix(), StepSeconds: 3600,
+	}).Return(&txProto.GetBalanceHistoryResponse{Points: []*txProto.BalancePoint{
+		{Timestamp: from.Unix(), Balance: 100},
+		{Timestamp: from.Add(time.Hour).Unix(), Balance: 70},
+		{Timestamp: to.Unix(), Balance: 120},
+	}}, nil).Once()
+
+	points, err := client.GetBalanceHistory(context.Background(), userID, "", "", from, to, time.Hour)
+	assert.NoError(t, err)
+	assert.Len(t, points, 3)
+	assert.Equal(t, int64(70), points[1].Balance)
+
+	mockCli.On("GetBalanceAt", mock.Anything, mock.Anything).Return(nil, status.Error(codes.Unavailable, "unavailable")).Once()
+
+	_, err = client.GetBalanceAt(context.Background(), userID, "", "", from)
+	assert.Error(t, err)
+
+	mockCli.AssertExpectations(t)
+}
+
+func TestTxManagerClient_ListPostings(t *testing.T) {
+	mockCli := new(mocks.MockProtoClient)
+	client := NewClientFromProto(mockCli)
+
+	mockCli.On("ListPostings", mock.Anything, &txProto.ListPostingsRequest{Account: "house", Currency: "USD", ReportingCurrency: "EUR", Limit: 10}).
+		Return(&txProto.ListPostingsResponse{
+			Postings:         []*txProto.Posting{{Id: uuid.NewString(), TransactionId: uuid.NewString(), Account: "house", Amount: 100}},
+			Balance:          100,
+			ReportingBalance: &txProto.Conversion{Currency: "EUR", Amount: 96, CurrencyExponent: 2, Rate: "0.9615384615", RateDate: 1735776000},
+		}, nil).Once()
+
+	postings, balance, err := client.ListPostings(context.Background(), "house", "USD", "EUR", 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, postings, 1)
+	assert.Nil(t, postings[0].Reporting)
+	assert.Equal(t, entities.AccountBalance{
+		Balance:   100,
+		Reporting: &entities.Conversion{Currency: "EUR", Amount: 96, CurrencyExponent: 2, Rate: "0.9615384615", RateDate: 1735776000},
+	}, balance)
+
+	mockCli.On("ListPostings", mock.Anything, mock.Anything).
+		Return(&txProto.ListPostingsResponse{Postings: []*txProto.Posting{{Id: "invalid-id"}}}, nil).Once()
+
+	_, _, err = client.ListPostings(context.Background(), "house", "USD", "", 10, 0)
+	assert.Error(t, err)
+
+	mockCli.AssertExpectations(t)
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/e1esm/casino-transaction-system/api-gateway/src/internal/entities"
 	txProto "github.com/e1esm/casino-transaction-system/api-gateway/src/internal/proto/tx-manager"
@@ -15,13 +16,75 @@ import (
 
 var (
 	transactionTypeProtoToEntity = map[txProto.TransactionType]entities.TransactionType{
-		txProto.TransactionType_Bet: entities.Bet,
-		txProto.TransactionType_Win: entities.Win,
+		txProto.TransactionType_Bet:             entities.Bet,
+		txProto.TransactionType_Win:             entities.Win,
+		txProto.TransactionType_Deposit:         entities.Deposit,
+		txProto.TransactionType_Withdrawal:      entities.Withdrawal,
+		txProto.TransactionType_Refund:          entities.Refund,
+		txProto.TransactionType_Rollback:        entities.Rollback,
+		txProto.TransactionType_BonusCredit:     entities.BonusCredit,
+		txProto.TransactionType_BonusConversion: entities.BonusConversion,
+		txProto.TransactionType_JackpotWin:      entities.JackpotWin,
 	}
 
 	transactionTypeEntityToProto = map[entities.TransactionType]txProto.TransactionType{
-		entities.Bet: txProto.TransactionType_Bet,
-		entities.Win: txProto.TransactionType_Win}
+		entities.Bet:             txProto.TransactionType_Bet,
+		entities.Win:             txProto.TransactionType_Win,
+		entities.Deposit:         txProto.TransactionType_Deposit,
+		entities.Withdrawal:      txProto.TransactionType_Withdrawal,
+		entities.Refund:          txProto.TransactionType_Refund,
+		entities.Rollback:        txProto.TransactionType_Rollback,
+		entities.BonusCredit:     txProto.TransactionType_BonusCredit,
+		entities.BonusConversion: txProto.TransactionType_BonusConversion,
+		entities.JackpotWin:      txProto.TransactionType_JackpotWin,
+	}
+
+	filterFieldEntityToProto = map[entities.FilterField]txProto.FilterField{
+		entities.FilterID:        txProto.FilterField_FilterId,
+		entities.FilterUserID:    txProto.FilterField_FilterUserId,
+		entities.FilterType:      txProto.FilterField_FilterType,
+		entities.FilterAmount:    txProto.FilterField_FilterAmount,
+		entities.FilterCurrency:  txProto.FilterField_FilterCurrency,
+		entities.FilterDate:      txProto.FilterField_FilterDate,
+		entities.FilterRoundID:   txProto.FilterField_FilterRoundId,
+		entities.FilterGameID:    txProto.FilterField_FilterGameId,
+		entities.FilterProvider:  txProto.FilterField_FilterProvider,
+		entities.FilterSessionID: txProto.FilterField_FilterSessionId,
+		entities.FilterChannel:   txProto.FilterField_FilterChannel,
+	}
+
+	comparisonOpEntityToProto = map[entities.ComparisonOp]txProto.ComparisonOp{
+		entities.OpEqual:          txProto.ComparisonOp_Equal,
+		entities.OpNotEqual:       txProto.ComparisonOp_NotEqual,
+		entities.OpLess:           txProto.ComparisonOp_Less,
+		entities.OpLessOrEqual:    txProto.ComparisonOp_LessOrEqual,
+		entities.OpGreater:        txProto.ComparisonOp_Greater,
+		entities.OpGreaterOrEqual: txProto.ComparisonOp_GreaterOrEqual,
+		entities.OpIn:             txProto.ComparisonOp_In,
+		entities.OpNotIn:          txProto.ComparisonOp_NotIn,
+	}
+
+	sortFieldEntityToProto = map[entities.SortField]txProto.SortKey{
+		entities.SortByUserID:    txProto.SortKey_SortUserId,
+		entities.SortByType:      txProto.SortKey_SortType,
+		entities.SortByAmount:    txProto.SortKey_SortAmount,
+		entities.SortByTimestamp: txProto.SortKey_SortTime,
+	}
+
+	directionProtoToEntity = map[txProto.Direction]entities.Direction{
+		txProto.Direction_Credit: entities.Credit,
+		txProto.Direction_Debit:  entities.Debit,
+	}
+
+	statusProtoToEntity = map[txProto.Status]entities.Status{
+		txProto.Status_Completed: entities.Completed,
+		txProto.Status_Voided:    entities.Voided,
+	}
+
+	roundStatusProtoToEntity = map[txProto.RoundStatus]entities.RoundStatus{
+		txProto.RoundStatus_Open:    entities.RoundOpen,
+		txProto.RoundStatus_Settled: entities.RoundSettled,
+	}
 )
 
 func mapReturnedCodeToSvcError(err error) error {
@@ -69,11 +132,208 @@ func convertProtoTransactionToEntity(transaction *txProto.Transaction) (entities
 		return entities.Transaction{}, err
 	}
 
+	var originalID *uuid.UUID
+	if len(transaction.OriginalId) > 0 {
+		parsed, err := uuid.Parse(transaction.OriginalId)
+		if err != nil {
+			return entities.Transaction{}, err
+		}
+
+		originalID = &parsed
+	}
+
 	return entities.Transaction{
-		ID:        id,
-		UserID:    userID,
-		Amount:    transaction.Amount,
-		Timestamp: transaction.Timestamp,
-		Type:      transactionTypeProtoToEntity[transaction.Type],
+		ID:               id,
+		UserID:           userID,
+		Amount:           transaction.Amount,
+		Currency:         transaction.Currency,
+		CurrencyExponent: transaction.CurrencyExponent,
+		Timestamp:        transaction.Timestamp,
+		Type:             transactionTypeProtoToEntity[transaction.Type],
+		Direction:        directionProtoToEntity[transaction.Direction],
+		ReferenceID:      transaction.ReferenceId,
+		OriginalID:       originalID,
+		Status:           statusProtoToEntity[transaction.Status],
+		RoundID:          transaction.RoundId,
+		GameID:           transaction.GameId,
+		Provider:         transaction.Provider,
+		SessionID:        transaction.SessionId,
+		Channel:          transaction.Channel,
+		Reporting:        convertProtoConversionToEntity(transaction.Reporting),
 	}, nil
 }
+
+func convertProtoRoundToEntity(round *txProto.Round) (entities.Round, error) {
+	if round == nil {
+		return entities.Round{}, errors.New("round is empty")
+	}
+
+	userID, err := uuid.Parse(round.UserId)
+	if err != nil {
+		return entities.Round{}, err
+	}
+
+	transactions, err := convertProtoTransactionsToEntities(round.Transactions)
+	if err != nil {
+		return entities.Round{}, err
+	}
+
+	return entities.Round{
+		ID:           round.Id,
+		UserID:       userID,
+		GameID:       round.GameId,
+		Provider:     round.Provider,
+		Status:       roundStatusProtoToEntity[round.Status],
+		Currency:     round.Currency,
+		BetTotal:     round.BetTotal,
+		WinTotal:     round.WinTotal,
+		Net:          round.Net,
+		BetCount:     round.BetCount,
+		WinCount:     round.WinCount,
+		OpenedAt:     round.OpenedAt,
+		SettledAt:    round.SettledAt,
+		Orphaned:     round.Orphaned,
+		UnmatchedWin: round.UnmatchedWin,
+		Transactions: transactions,
+	}, nil
+}
+
+func convertProtoBalanceToEntity(balance *txProto.Balance) (entities.Balance, error) {
+	if balance == nil {
+		return entities.Balance{}, errors.New("balance is empty")
+	}
+
+	userID, err := uuid.Parse(balance.UserId)
+	if err != nil {
+		return entities.Balance{}, err
+	}
+
+	return entities.Balance{
+		UserID:              userID,
+		Currency:            balance.Currency,
+		Balance:             balance.Balance,
+		CreditTotal:         balance.CreditTotal,
+		DebitTotal:          balance.DebitTotal,
+		TransactionCount:    balance.TransactionCount,
+		LastTransactionTime: balance.LastTransactionTime,
+		UpdatedAt:           balance.UpdatedAt,
+		Reporting:           convertProtoConversionToEntity(balance.Reporting),
+	}, nil
+}
+
+func convertProtoBalancePointToEntity(point *txProto.BalancePoint) entities.BalancePoint {
+	return entities.BalancePoint{
+		Timestamp:        point.Timestamp,
+		Currency:         point.Currency,
+		Balance:          point.Balance,
+		TransactionCount: point.TransactionCount,
+		Reporting:        convertProtoConversionToEntity(point.Reporting),
+	}
+}
+
+func convertProtoPostingToEntity(posting *txProto.Posting) (entities.Posting, error) {
+	id, err := uuid.Parse(posting.Id)
+	if err != nil {
+		return entities.Posting{}, err
+	}
+
+	transactionID, err := uuid.Parse(posting.TransactionId)
+	if err != nil {
+		return entities.Posting{}, err
+	}
+
+	return entities.Posting{
+		ID:            id,
+		TransactionID: transactionID,
+		Account:       posting.Account,
+		Amount:        posting.Amount,
+		Currency:      posting.Currency,
+		Timestamp:     posting.Timestamp,
+		Reporting:     convertProtoConversionToEntity(posting.Reporting),
+	}, nil
+}
+
+func convertProtoConversionToEntity(conversion *txProto.Conversion) *entities.Conversion {
+	if conversion == nil {
+		return nil
+	}
+
+	return &entities.Conversion{
+		Currency:         conversion.Currency,
+		Amount:           conversion.Amount,
+		CurrencyExponent: conversion.CurrencyExponent,
+		Rate:             conversion.Rate,
+		RateDate:         conversion.RateDate,
+	}
+}
+
+func unixOrNil(t *time.Time) *int64 {
+	if t == nil {
+		return nil
+	}
+
+	unix := t.Unix()
+
+	return &unix
+}
+
+func convertSortToProto(sort []entities.Sort) []*txProto.SortField {
+	fields := make([]*txProto.SortField, 0, len(sort))
+	for _, s := range sort {
+		direction := txProto.SortDirection_Ascending
+		if s.Desc {
+			direction = txProto.SortDirection_Descending
+		}
+
+		fields = append(fields, &txProto.SortField{
+			Field:     sortFieldEntityToProto[s.Field],
+			Direction: direction,
+		})
+	}
+
+	return fields
+}
+
+func convertFilterExprToProto(expr *entities.FilterExpr) *txProto.FilterExpr {
+	if expr == nil {
+		return nil
+	}
+
+	switch {
+	case expr.Comparison != nil:
+		values := make([]*txProto.FilterValue, 0, len(expr.Comparison.Values))
+		for _, v := range expr.Comparison.Values {
+			values = append(values, convertFilterValueToProto(v))
+		}
+
+		return &txProto.FilterExpr{Node: &txProto.FilterExpr_Comparison{Comparison: &txProto.Comparison{
+			Field:  filterFieldEntityToProto[expr.Comparison.Field],
+			Op:     comparisonOpEntityToProto[expr.Comparison.Op],
+			Values: values,
+		}}}
+	case len(expr.Or) > 0:
+		return &txProto.FilterExpr{Node: &txProto.FilterExpr_Or{Or: convertFilterListToProto(expr.Or)}}
+	default:
+		return &txProto.FilterExpr{Node: &txProto.FilterExpr_And{And: convertFilterListToProto(expr.And)}}
+	}
+}
+
+func convertFilterListToProto(operands []entities.FilterExpr) *txProto.FilterList {
+	list := &txProto.FilterList{Operands: make([]*txProto.FilterExpr, 0, len(operands))}
+	for i := range operands {
+		list.Operands = append(list.Operands, convertFilterExprToProto(&operands[i]))
+	}
+
+	return list
+}
+
+func convertFilterValueToProto(v any) *txProto.FilterValue {
+	switch v := v.(type) {
+	case int64:
+		return &txProto.FilterValue{Kind: &txProto.FilterValue_Integer{Integer: v}}
+	case time.Time:
+		return &txProto.FilterValue{Kind: &txProto.FilterValue_Time{Time: v.Unix()}}
+	default:
+		return &txProto.FilterValue{Kind: &txProto.FilterValue_Text{Text: fmt.Sprint(v)}}
+	}
+}
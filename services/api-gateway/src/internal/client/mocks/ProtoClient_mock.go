@@ -39,6 +39,338 @@ func (_m *MockProtoClient) EXPECT() *MockProtoClient_Expecter {
 	return &MockProtoClient_Expecter{mock: &_m.Mock}
 }
 
+// BatchGetTransactions provides a mock function for the type MockProtoClient
+func (_mock *MockProtoClient) BatchGetTransactions(ctx context.Context, in *tx_manager.BatchGetTransactionsRequest, opts ...grpc.CallOption) (*tx_manager.BatchGetTransactionsResponse, error) {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, in, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, in)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for BatchGetTransactions")
+	}
+
+	var r0 *tx_manager.BatchGetTransactionsResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *tx_manager.BatchGetTransactionsRequest, ...grpc.CallOption) (*tx_manager.BatchGetTransactionsResponse, error)); ok {
+		return returnFunc(ctx, in, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *tx_manager.BatchGetTransactionsRequest, ...grpc.CallOption) *tx_manager.BatchGetTransactionsResponse); ok {
+		r0 = returnFunc(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*tx_manager.BatchGetTransactionsResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *tx_manager.BatchGetTransactionsRequest, ...grpc.CallOption) error); ok {
+		r1 = returnFunc(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProtoClient_BatchGetTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BatchGetTransactions'
+type MockProtoClient_BatchGetTransactions_Call struct {
+	*mock.Call
+}
+
+// BatchGetTransactions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *tx_manager.BatchGetTransactionsRequest
+//   - opts ...grpc.CallOption
+func (_e *MockProtoClient_Expecter) BatchGetTransactions(ctx interface{}, in interface{}, opts ...interface{}) *MockProtoClient_BatchGetTransactions_Call {
+	return &MockProtoClient_BatchGetTransactions_Call{Call: _e.mock.On("BatchGetTransactions",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockProtoClient_BatchGetTransactions_Call) Run(run func(ctx context.Context, in *tx_manager.BatchGetTransactionsRequest, opts ...grpc.CallOption)) *MockProtoClient_BatchGetTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *tx_manager.BatchGetTransactionsRequest
+		if args[1] != nil {
+			arg1 = args[1].(*tx_manager.BatchGetTransactionsRequest)
+		}
+		var arg2 []grpc.CallOption
+		var variadicArgs []grpc.CallOption
+		if len(args) > 2 {
+			variadicArgs = args[2].([]grpc.CallOption)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProtoClient_BatchGetTransactions_Call) Return(batchGetTransactionsResponse *tx_manager.BatchGetTransactionsResponse, err error) *MockProtoClient_BatchGetTransactions_Call {
+	_c.Call.Return(batchGetTransactionsResponse, err)
+	return _c
+}
+
+func (_c *MockProtoClient_BatchGetTransactions_Call) RunAndReturn(run func(ctx context.Context, in *tx_manager.BatchGetTransactionsRequest, opts ...grpc.CallOption) (*tx_manager.BatchGetTransactionsResponse, error)) *MockProtoClient_BatchGetTransactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBalanceAt provides a mock function for the type MockProtoClient
+func (_mock *MockProtoClient) GetBalanceAt(ctx context.Context, in *tx_manager.GetBalanceAtRequest, opts ...grpc.CallOption) (*tx_manager.GetBalanceAtResponse, error) {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, in, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, in)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBalanceAt")
+	}
+
+	var r0 *tx_manager.GetBalanceAtResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *tx_manager.GetBalanceAtRequest, ...grpc.CallOption) (*tx_manager.GetBalanceAtResponse, error)); ok {
+		return returnFunc(ctx, in, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *tx_manager.GetBalanceAtRequest, ...grpc.CallOption) *tx_manager.GetBalanceAtResponse); ok {
+		r0 = returnFunc(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*tx_manager.GetBalanceAtResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *tx_manager.GetBalanceAtRequest, ...grpc.CallOption) error); ok {
+		r1 = returnFunc(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProtoClient_GetBalanceAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBalanceAt'
+type MockProtoClient_GetBalanceAt_Call struct {
+	*mock.Call
+}
+
+// GetBalanceAt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *tx_manager.GetBalanceAtRequest
+//   - opts ...grpc.CallOption
+func (_e *MockProtoClient_Expecter) GetBalanceAt(ctx interface{}, in interface{}, opts ...interface{}) *MockProtoClient_GetBalanceAt_Call {
+	return &MockProtoClient_GetBalanceAt_Call{Call: _e.mock.On("GetBalanceAt",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockProtoClient_GetBalanceAt_Call) Run(run func(ctx context.Context, in *tx_manager.GetBalanceAtRequest, opts ...grpc.CallOption)) *MockProtoClient_GetBalanceAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *tx_manager.GetBalanceAtRequest
+		if args[1] != nil {
+			arg1 = args[1].(*tx_manager.GetBalanceAtRequest)
+		}
+		var arg2 []grpc.CallOption
+		var variadicArgs []grpc.CallOption
+		if len(args) > 2 {
+			variadicArgs = args[2].([]grpc.CallOption)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProtoClient_GetBalanceAt_Call) Return(getBalanceAtResponse *tx_manager.GetBalanceAtResponse, err error) *MockProtoClient_GetBalanceAt_Call {
+	_c.Call.Return(getBalanceAtResponse, err)
+	return _c
+}
+
+func (_c *MockProtoClient_GetBalanceAt_Call) RunAndReturn(run func(ctx context.Context, in *tx_manager.GetBalanceAtRequest, opts ...grpc.CallOption) (*tx_manager.GetBalanceAtResponse, error)) *MockProtoClient_GetBalanceAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBalanceHistory provides a mock function for the type MockProtoClient
+func (_mock *MockProtoClient) GetBalanceHistory(ctx context.Context, in *tx_manager.GetBalanceHistoryRequest, opts ...grpc.CallOption) (*tx_manager.GetBalanceHistoryResponse, error) {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, in, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, in)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBalanceHistory")
+	}
+
+	var r0 *tx_manager.GetBalanceHistoryResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *tx_manager.GetBalanceHistoryRequest, ...grpc.CallOption) (*tx_manager.GetBalanceHistoryResponse, error)); ok {
+		return returnFunc(ctx, in, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *tx_manager.GetBalanceHistoryRequest, ...grpc.CallOption) *tx_manager.GetBalanceHistoryResponse); ok {
+		r0 = returnFunc(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*tx_manager.GetBalanceHistoryResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *tx_manager.GetBalanceHistoryRequest, ...grpc.CallOption) error); ok {
+		r1 = returnFunc(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProtoClient_GetBalanceHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBalanceHistory'
+type MockProtoClient_GetBalanceHistory_Call struct {
+	*mock.Call
+}
+
+// GetBalanceHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *tx_manager.GetBalanceHistoryRequest
+//   - opts ...grpc.CallOption
+func (_e *MockProtoClient_Expecter) GetBalanceHistory(ctx interface{}, in interface{}, opts ...interface{}) *MockProtoClient_GetBalanceHistory_Call {
+	return &MockProtoClient_GetBalanceHistory_Call{Call: _e.mock.On("GetBalanceHistory",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockProtoClient_GetBalanceHistory_Call) Run(run func(ctx context.Context, in *tx_manager.GetBalanceHistoryRequest, opts ...grpc.CallOption)) *MockProtoClient_GetBalanceHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *tx_manager.GetBalanceHistoryRequest
+		if args[1] != nil {
+			arg1 = args[1].(*tx_manager.GetBalanceHistoryRequest)
+		}
+		var arg2 []grpc.CallOption
+		var variadicArgs []grpc.CallOption
+		if len(args) > 2 {
+			variadicArgs = args[2].([]grpc.CallOption)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProtoClient_GetBalanceHistory_Call) Return(getBalanceHistoryResponse *tx_manager.GetBalanceHistoryResponse, err error) *MockProtoClient_GetBalanceHistory_Call {
+	_c.Call.Return(getBalanceHistoryResponse, err)
+	return _c
+}
+
+func (_c *MockProtoClient_GetBalanceHistory_Call) RunAndReturn(run func(ctx context.Context, in *tx_manager.GetBalanceHistoryRequest, opts ...grpc.CallOption) (*tx_manager.GetBalanceHistoryResponse, error)) *MockProtoClient_GetBalanceHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRound provides a mock function for the type MockProtoClient
+func (_mock *MockProtoClient) GetRound(ctx context.Context, in *tx_manager.GetRoundRequest, opts ...grpc.CallOption) (*tx_manager.GetRoundResponse, error) {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, in, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, in)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRound")
+	}
+
+	var r0 *tx_manager.GetRoundResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *tx_manager.GetRoundRequest, ...grpc.CallOption) (*tx_manager.GetRoundResponse, error)); ok {
+		return returnFunc(ctx, in, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *tx_manager.GetRoundRequest, ...grpc.CallOption) *tx_manager.GetRoundResponse); ok {
+		r0 = returnFunc(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*tx_manager.GetRoundResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *tx_manager.GetRoundRequest, ...grpc.CallOption) error); ok {
+		r1 = returnFunc(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProtoClient_GetRound_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRound'
+type MockProtoClient_GetRound_Call struct {
+	*mock.Call
+}
+
+// GetRound is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *tx_manager.GetRoundRequest
+//   - opts ...grpc.CallOption
+func (_e *MockProtoClient_Expecter) GetRound(ctx interface{}, in interface{}, opts ...interface{}) *MockProtoClient_GetRound_Call {
+	return &MockProtoClient_GetRound_Call{Call: _e.mock.On("GetRound",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockProtoClient_GetRound_Call) Run(run func(ctx context.Context, in *tx_manager.GetRoundRequest, opts ...grpc.CallOption)) *MockProtoClient_GetRound_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *tx_manager.GetRoundRequest
+		if args[1] != nil {
+			arg1 = args[1].(*tx_manager.GetRoundRequest)
+		}
+		var arg2 []grpc.CallOption
+		var variadicArgs []grpc.CallOption
+		if len(args) > 2 {
+			variadicArgs = args[2].([]grpc.CallOption)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProtoClient_GetRound_Call) Return(getRoundResponse *tx_manager.GetRoundResponse, err error) *MockProtoClient_GetRound_Call {
+	_c.Call.Return(getRoundResponse, err)
+	return _c
+}
+
+func (_c *MockProtoClient_GetRound_Call) RunAndReturn(run func(ctx context.Context, in *tx_manager.GetRoundRequest, opts ...grpc.CallOption) (*tx_manager.GetRoundResponse, error)) *MockProtoClient_GetRound_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetTransactionByFilters provides a mock function for the type MockProtoClient
 func (_mock *MockProtoClient) GetTransactionByFilters(ctx context.Context, in *tx_manager.GetTransactionByFiltersRequest, opts ...grpc.CallOption) (*tx_manager.GetTransactionByFiltersResponse, error) {
 	var tmpRet mock.Arguments
@@ -204,3 +536,252 @@ func (_c *MockProtoClient_GetTransactionByID_Call) RunAndReturn(run func(ctx con
 	_c.Call.Return(run)
 	return _c
 }
+
+// GetUserBalance provides a mock function for the type MockProtoClient
+func (_mock *MockProtoClient) GetUserBalance(ctx context.Context, in *tx_manager.GetUserBalanceRequest, opts ...grpc.CallOption) (*tx_manager.GetUserBalanceResponse, error) {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, in, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, in)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserBalance")
+	}
+
+	var r0 *tx_manager.GetUserBalanceResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *tx_manager.GetUserBalanceRequest, ...grpc.CallOption) (*tx_manager.GetUserBalanceResponse, error)); ok {
+		return returnFunc(ctx, in, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *tx_manager.GetUserBalanceRequest, ...grpc.CallOption) *tx_manager.GetUserBalanceResponse); ok {
+		r0 = returnFunc(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*tx_manager.GetUserBalanceResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *tx_manager.GetUserBalanceRequest, ...grpc.CallOption) error); ok {
+		r1 = returnFunc(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProtoClient_GetUserBalance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserBalance'
+type MockProtoClient_GetUserBalance_Call struct {
+	*mock.Call
+}
+
+// GetUserBalance is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *tx_manager.GetUserBalanceRequest
+//   - opts ...grpc.CallOption
+func (_e *MockProtoClient_Expecter) GetUserBalance(ctx interface{}, in interface{}, opts ...interface{}) *MockProtoClient_GetUserBalance_Call {
+	return &MockProtoClient_GetUserBalance_Call{Call: _e.mock.On("GetUserBalance",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockProtoClient_GetUserBalance_Call) Run(run func(ctx context.Context, in *tx_manager.GetUserBalanceRequest, opts ...grpc.CallOption)) *MockProtoClient_GetUserBalance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *tx_manager.GetUserBalanceRequest
+		if args[1] != nil {
+			arg1 = args[1].(*tx_manager.GetUserBalanceRequest)
+		}
+		var arg2 []grpc.CallOption
+		var variadicArgs []grpc.CallOption
+		if len(args) > 2 {
+			variadicArgs = args[2].([]grpc.CallOption)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProtoClient_GetUserBalance_Call) Return(getUserBalanceResponse *tx_manager.GetUserBalanceResponse, err error) *MockProtoClient_GetUserBalance_Call {
+	_c.Call.Return(getUserBalanceResponse, err)
+	return _c
+}
+
+func (_c *MockProtoClient_GetUserBalance_Call) RunAndReturn(run func(ctx context.Context, in *tx_manager.GetUserBalanceRequest, opts ...grpc.CallOption) (*tx_manager.GetUserBalanceResponse, error)) *MockProtoClient_GetUserBalance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOpenRounds provides a mock function for the type MockProtoClient
+func (_mock *MockProtoClient) ListOpenRounds(ctx context.Context, in *tx_manager.ListOpenRoundsRequest, opts ...grpc.CallOption) (*tx_manager.ListOpenRoundsResponse, error) {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, in, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, in)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOpenRounds")
+	}
+
+	var r0 *tx_manager.ListOpenRoundsResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *tx_manager.ListOpenRoundsRequest, ...grpc.CallOption) (*tx_manager.ListOpenRoundsResponse, error)); ok {
+		return returnFunc(ctx, in, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *tx_manager.ListOpenRoundsRequest, ...grpc.CallOption) *tx_manager.ListOpenRoundsResponse); ok {
+		r0 = returnFunc(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*tx_manager.ListOpenRoundsResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *tx_manager.ListOpenRoundsRequest, ...grpc.CallOption) error); ok {
+		r1 = returnFunc(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProtoClient_ListOpenRounds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOpenRounds'
+type MockProtoClient_ListOpenRounds_Call struct {
+	*mock.Call
+}
+
+// ListOpenRounds is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *tx_manager.ListOpenRoundsRequest
+//   - opts ...grpc.CallOption
+func (_e *MockProtoClient_Expecter) ListOpenRounds(ctx interface{}, in interface{}, opts ...interface{}) *MockProtoClient_ListOpenRounds_Call {
+	return &MockProtoClient_ListOpenRounds_Call{Call: _e.mock.On("ListOpenRounds",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockProtoClient_ListOpenRounds_Call) Run(run func(ctx context.Context, in *tx_manager.ListOpenRoundsRequest, opts ...grpc.CallOption)) *MockProtoClient_ListOpenRounds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *tx_manager.ListOpenRoundsRequest
+		if args[1] != nil {
+			arg1 = args[1].(*tx_manager.ListOpenRoundsRequest)
+		}
+		var arg2 []grpc.CallOption
+		var variadicArgs []grpc.CallOption
+		if len(args) > 2 {
+			variadicArgs = args[2].([]grpc.CallOption)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProtoClient_ListOpenRounds_Call) Return(listOpenRoundsResponse *tx_manager.ListOpenRoundsResponse, err error) *MockProtoClient_ListOpenRounds_Call {
+	_c.Call.Return(listOpenRoundsResponse, err)
+	return _c
+}
+
+func (_c *MockProtoClient_ListOpenRounds_Call) RunAndReturn(run func(ctx context.Context, in *tx_manager.ListOpenRoundsRequest, opts ...grpc.CallOption) (*tx_manager.ListOpenRoundsResponse, error)) *MockProtoClient_ListOpenRounds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPostings provides a mock function for the type MockProtoClient
+func (_mock *MockProtoClient) ListPostings(ctx context.Context, in *tx_manager.ListPostingsRequest, opts ...grpc.CallOption) (*tx_manager.ListPostingsResponse, error) {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, in, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, in)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPostings")
+	}
+
+	var r0 *tx_manager.ListPostingsResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *tx_manager.ListPostingsRequest, ...grpc.CallOption) (*tx_manager.ListPostingsResponse, error)); ok {
+		return returnFunc(ctx, in, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *tx_manager.ListPostingsRequest, ...grpc.CallOption) *tx_manager.ListPostingsResponse); ok {
+		r0 = returnFunc(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*tx_manager.ListPostingsResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *tx_manager.ListPostingsRequest, ...grpc.CallOption) error); ok {
+		r1 = returnFunc(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProtoClient_ListPostings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPostings'
+type MockProtoClient_ListPostings_Call struct {
+	*mock.Call
+}
+
+// ListPostings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *tx_manager.ListPostingsRequest
+//   - opts ...grpc.CallOption
+func (_e *MockProtoClient_Expecter) ListPostings(ctx interface{}, in interface{}, opts ...interface{}) *MockProtoClient_ListPostings_Call {
+	return &MockProtoClient_ListPostings_Call{Call: _e.mock.On("ListPostings",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockProtoClient_ListPostings_Call) Run(run func(ctx context.Context, in *tx_manager.ListPostingsRequest, opts ...grpc.CallOption)) *MockProtoClient_ListPostings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *tx_manager.ListPostingsRequest
+		if args[1] != nil {
+			arg1 = args[1].(*tx_manager.ListPostingsRequest)
+		}
+		var arg2 []grpc.CallOption
+		var variadicArgs []grpc.CallOption
+		if len(args) > 2 {
+			variadicArgs = args[2].([]grpc.CallOption)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProtoClient_ListPostings_Call) Return(listPostingsResponse *tx_manager.ListPostingsResponse, err error) *MockProtoClient_ListPostings_Call {
+	_c.Call.Return(listPostingsResponse, err)
+	return _c
+}
+
+func (_c *MockProtoClient_ListPostings_Call) RunAndReturn(run func(ctx context.Context, in *tx_manager.ListPostingsRequest, opts ...grpc.CallOption) (*tx_manager.ListPostingsResponse, error)) *MockProtoClient_ListPostings_Call {
+	_c.Call.Return(run)
+	return _c
+}
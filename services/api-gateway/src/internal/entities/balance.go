@@ -0,0 +1,27 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+)
+
+type Balance struct {
+	UserID              uuid.UUID
+	Currency            string
+	Balance             int64
+	CreditTotal         int64
+	DebitTotal          int64
+	TransactionCount    int64
+	LastTransactionTime int64
+	UpdatedAt           int64
+	// Reporting is set when a reporting currency was requested.
+	Reporting *Conversion
+}
+
+// BalancePoint is the balance of a user including every transaction up to Timestamp.
+type BalancePoint struct {
+	Timestamp        int64
+	Currency         string
+	Balance          int64
+	TransactionCount int64
+	Reporting        *Conversion
+}
@@ -0,0 +1,12 @@
+package entities
+
+// Conversion is an amount converted to a reporting currency.
+type Conversion struct {
+	Currency         string
+	Amount           int64
+	CurrencyExponent int32
+	// Rate is the decimal number of units of Currency per unit of the original currency.
+	Rate string
+	// RateDate is the date of the oldest rate Rate was derived from, zero when no conversion was needed.
+	RateDate int64
+}
@@ -0,0 +1,45 @@
+package entities
+
+type FilterField string
+
+var (
+	FilterID        FilterField = "id"
+	FilterUserID    FilterField = "user_id"
+	FilterType      FilterField = "type"
+	FilterAmount    FilterField = "amount"
+	FilterCurrency  FilterField = "currency"
+	FilterDate      FilterField = "date"
+	FilterRoundID   FilterField = "round_id"
+	FilterGameID    FilterField = "game_id"
+	FilterProvider  FilterField = "provider"
+	FilterSessionID FilterField = "session_id"
+	FilterChannel   FilterField = "channel"
+)
+
+type ComparisonOp string
+
+var (
+	OpEqual          ComparisonOp = "=="
+	OpNotEqual       ComparisonOp = "!="
+	OpLess           ComparisonOp = "<"
+	OpLessOrEqual    ComparisonOp = "<="
+	OpGreater        ComparisonOp = ">"
+	OpGreaterOrEqual ComparisonOp = ">="
+	OpIn             ComparisonOp = "=in="
+	OpNotIn          ComparisonOp = "=out="
+)
+
+// Comparison matches transactions whose field compares to the values.
+// Values are an int64 for amount, a time.Time for date and a string otherwise.
+type Comparison struct {
+	Field  FilterField
+	Op     ComparisonOp
+	Values []any
+}
+
+// FilterExpr is a node of a filter expression: a comparison, or the conjunction or disjunction of its operands.
+type FilterExpr struct {
+	Comparison *Comparison
+	And        []FilterExpr
+	Or         []FilterExpr
+}
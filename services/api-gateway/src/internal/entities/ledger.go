@@ -0,0 +1,22 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+)
+
+// Posting is one leg of a transaction in the double-entry ledger. Positive amounts credit the account.
+type Posting struct {
+	ID            uuid.UUID
+	TransactionID uuid.UUID
+	Account       string
+	Amount        int64
+	Currency      string
+	Timestamp     int64
+	Reporting     *Conversion
+}
+
+// AccountBalance is the sum of all postings of an account in a currency.
+type AccountBalance struct {
+	Balance   int64
+	Reporting *Conversion
+}
@@ -0,0 +1,36 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+)
+
+type RoundStatus string
+
+var (
+	RoundOpen    RoundStatus = "open"
+	RoundSettled RoundStatus = "settled"
+)
+
+type Round struct {
+	ID           string
+	UserID       uuid.UUID
+	GameID       string
+	Provider     string
+	Status       RoundStatus
+	Currency     string
+	BetTotal     int64
+	WinTotal     int64
+	Net          int64
+	BetCount     int32
+	WinCount     int32
+	OpenedAt     int64
+	SettledAt    int64
+	Orphaned     bool
+	UnmatchedWin bool
+	Transactions []Transaction
+}
+
+type RoundFilter struct {
+	UserID       string
+	OrphanedOnly bool
+}
@@ -1,25 +1,132 @@
 package entities
 
 import (
+	"slices"
+	"time"
+
 	"github.com/google/uuid"
 )
 
 type TransactionType string
 
 var (
-	Bet TransactionType = "bet"
-	Win TransactionType = "win"
+	Bet             TransactionType = "bet"
+	Win             TransactionType = "win"
+	Deposit         TransactionType = "deposit"
+	Withdrawal      TransactionType = "withdrawal"
+	Refund          TransactionType = "refund"
+	Rollback        TransactionType = "rollback"
+	BonusCredit     TransactionType = "bonus_credit"
+	BonusConversion TransactionType = "bonus_conversion"
+	JackpotWin      TransactionType = "jackpot_win"
+)
+
+var TransactionTypes = []TransactionType{Bet, Win, Deposit, Withdrawal, Refund, Rollback, BonusCredit, BonusConversion, JackpotWin}
+
+func (t TransactionType) Valid() bool {
+	return slices.Contains(TransactionTypes, t)
+}
+
+type SortField string
+
+var (
+	SortByUserID    SortField = "user_id"
+	SortByType      SortField = "type"
+	SortByAmount    SortField = "amount"
+	SortByTimestamp SortField = "timestamp"
+)
+
+var SortFields = []SortField{SortByUserID, SortByType, SortByAmount, SortByTimestamp}
+
+func (f SortField) Valid() bool {
+	return slices.Contains(SortFields, f)
+}
+
+type Sort struct {
+	Field SortField
+	Desc  bool
+}
+
+type Direction string
+
+var (
+	Credit Direction = "credit"
+	Debit  Direction = "debit"
+)
+
+type Status string
+
+var (
+	Completed Status = "completed"
+	Voided    Status = "voided"
 )
 
 type Transaction struct {
 	ID        uuid.UUID
 	UserID    uuid.UUID
 	Type      TransactionType
-	Amount    int64
-	Timestamp int64
+	Direction Direction
+	// Amount is in minor units of Currency, CurrencyExponent tells where the decimal point goes.
+	Amount           int64
+	Currency         string
+	CurrencyExponent int32
+	Timestamp        int64
+	ReferenceID      string
+	OriginalID       *uuid.UUID
+	Status           Status
+	RoundID          string
+	GameID           string
+	Provider         string
+	SessionID        string
+	Channel          string
+	// Chain holds the related transactions, only set when fetched by id.
+	Chain []Transaction
+	// Reporting is set when a reporting currency was requested.
+	Reporting *Conversion
+}
+
+// TransactionBatch holds the transactions found by a batch lookup, in request order, and the ids that weren't.
+type TransactionBatch struct {
+	Transactions []Transaction
+	MissingIDs   []uuid.UUID
+}
+
+type TransactionPage struct {
+	Transactions []Transaction
+	// Total is the number of transactions matching the filter across all pages.
+	Total      int64
+	TotalExact bool
+	// NextCursor is set when a keyset page is followed by more transactions.
+	NextCursor string
+}
+
+type TransactionQuery struct {
+	Filter TransactionFilter
+	// Expr is matched together with Filter.
+	Expr              *FilterExpr
+	ReportingCurrency string
+	// OrderBy is applied in turn, ties are broken by id.
+	OrderBy []Sort
+	Cursor  string
+	Limit   int64
+	Offset  int64
+	// ExactTotal asks for an exact total instead of the planner's estimate.
+	ExactTotal bool
 }
 
 type TransactionFilter struct {
-	UserID string
-	Type   TransactionType
+	UserID    string          `json:"user_id"`
+	Type      TransactionType `json:"type"`
+	RoundID   string          `json:"round_id"`
+	GameID    string          `json:"game_id"`
+	Provider  string          `json:"provider"`
+	SessionID string          `json:"session_id"`
+	Channel   string          `json:"channel"`
+	Currency  string          `json:"currency"`
+	// From is inclusive and To exclusive.
+	From *time.Time `json:"from"`
+	To   *time.Time `json:"to"`
+	// MinAmount and MaxAmount are in minor units and inclusive.
+	MinAmount *int64 `json:"min_amount"`
+	MaxAmount *int64 `json:"max_amount"`
 }
@@ -5,15 +5,27 @@ import (
 	"encoding/json"
 	"log"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/e1esm/casino-transaction-system/api-gateway/src/internal/entities"
 	"github.com/e1esm/casino-transaction-system/api-gateway/src/internal/handlers/errors"
 	"github.com/google/uuid"
 )
 
+// maxBatchGetBodySize bounds a batch lookup body, the number of ids is capped by the handler.
+const maxBatchGetBodySize = 1 << 20
+
 type Client interface {
 	GetTransactionByID(ctx context.Context, id uuid.UUID) (entities.Transaction, error)
-	GetTransactions(ctx context.Context, filter entities.TransactionFilter, orderBy string, limit, offset int64) ([]entities.Transaction, int, error)
+	GetTransactions(ctx context.Context, query entities.TransactionQuery) (entities.TransactionPage, error)
+	GetRound(ctx context.Context, provider, id string) (entities.Round, error)
+	ListOpenRounds(ctx context.Context, filter entities.RoundFilter, limit, offset int64) ([]entities.Round, error)
+	GetUserBalance(ctx context.Context, userID uuid.UUID, currency, reportingCurrency string) (entities.Balance, error)
+	GetBalanceAt(ctx context.Context, userID uuid.UUID, currency, reportingCurrency string, at time.Time) (entities.BalancePoint, error)
+	GetBalanceHistory(ctx context.Context, userID uuid.UUID, currency, reportingCurrency string, from, to time.Time, step time.Duration) ([]entities.BalancePoint, error)
+	ListPostings(ctx context.Context, account, currency, reportingCurrency string, limit, offset int64) ([]entities.Posting, entities.AccountBalance, error)
+	BatchGetTransactions(ctx context.Context, ids []uuid.UUID, reportingCurrency string) (entities.TransactionBatch, error)
 }
 
 type Handler struct {
@@ -28,14 +40,17 @@ func New(cli Client) *Handler {
 
 // GetTransactions godoc
 // @Summary Get a list of transactions
-// @Description Returns transactions with optional filtering, pagination, and ordering
+// @Description Returns transactions matching filters, newest first and paged by cursor unless orderBy or offset is given. Amounts are in minor units.
 // @Tags transactions
 // @Accept json
 // @Produce json
-// @Param limit query int false "Number of transactions to return" default(10)
-// @Param offset query int false "Pagination offset" default(0)
-// @Param orderBy query string false "Field to order by, e.g., amount desc"
-// @Param filters query string false "JSON-encoded filters, e.g., {\"user_id\":\"uuid\",\"type\":\"bet\"}"
+// @Param limit query int false "Number of transactions to return, at most 1000" default(10)
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param offset query int false "Legacy pagination offset, can't be combined with cursor" default(0)
+// @Param orderBy query string false "Comma separated fields to order by, - for descending, e.g. -amount,timestamp. One of user_id, type, amount, timestamp. Selects the legacy offset paging"
+// @Param filters query string false "RSQL filter expression, e.g. type==bet;amount>1000, or legacy JSON-encoded filters, e.g., {\"type\":\"bet\"}"
+// @Param reporting_currency query string false "ISO 4217 code to also convert amounts to, with the rate valid at their time"
+// @Param count query string false "How to count total, estimated is the planner's cheaper but rougher estimate, exact counts every matching row" Enums(exact, estimated) default(estimated)
 // @Success 200 {object} transactions "Transactions list and total count"
 // @Failure 400 {object} string "Invalid request parameters"
 // @Failure 500 {object} string "Internal server error"
@@ -43,15 +58,35 @@ func New(cli Client) *Handler {
 func (h *Handler) GetTransactions(w http.ResponseWriter, r *http.Request) {
 	limit := strToIntWithDefault(r.URL.Query().Get("limit"), 10)
 	offset := strToIntWithDefault(r.URL.Query().Get("offset"), 0)
-	orderBy := r.URL.Query().Get("orderBy")
 
-	filters, err := parseFiltersStruct(r.URL.Query().Get("filters"))
+	filters, expr, err := parseFilters(r.URL.Query().Get("filters"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid filters parameter: "+err.Error())
+		return
+	}
+
+	orderBy, err := parseOrderBy(r.URL.Query().Get("orderBy"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid orderBy parameter")
+		return
+	}
+
+	exactTotal, err := parseCountMode(r.URL.Query().Get("count"))
 	if err != nil {
-		writeJSONError(w, http.StatusBadRequest, "invalid filters parameter")
+		writeJSONError(w, http.StatusBadRequest, "invalid count parameter")
 		return
 	}
 
-	trResp, total, err := h.cli.GetTransactions(r.Context(), filters, orderBy, limit, offset)
+	trResp, err := h.cli.GetTransactions(r.Context(), entities.TransactionQuery{
+		Filter:            filters,
+		Expr:              expr,
+		ReportingCurrency: r.URL.Query().Get("reporting_currency"),
+		OrderBy:           orderBy,
+		Cursor:            r.URL.Query().Get("cursor"),
+		Limit:             limit,
+		Offset:            offset,
+		ExactTotal:        exactTotal,
+	})
 	if err != nil {
 		code, errMsg := errors.ParseSvcErrToResp(err)
 		if code == http.StatusInternalServerError {
@@ -63,7 +98,7 @@ func (h *Handler) GetTransactions(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	if err = json.NewEncoder(w).Encode(convertTransactionEntitiesToResponse(trResp, total)); err != nil {
+	if err = json.NewEncoder(w).Encode(convertTransactionPageToResponse(trResp)); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -72,12 +107,13 @@ func (h *Handler) GetTransactions(w http.ResponseWriter, r *http.Request) {
 
 // GetTransactionByID godoc
 // @Summary Get a single transaction by ID
-// @Description Returns a transaction by its UUID
+// @Description Returns a transaction by its UUID together with its chain: the original transaction and everything referencing it, e.g. rollbacks, oldest first.
+// @Description A transaction referenced by a rollback has status voided.
 // @Tags transactions
 // @Accept json
 // @Produce json
 // @Param id path string true "Transaction ID"
-// @Success 200 {object} transaction "Transaction object"
+// @Success 200 {object} transactionWithChain "Transaction object"
 // @Failure 400 {object} string "Invalid or missing ID"
 // @Failure 404 {object} string "Transaction not found"
 // @Failure 500 {object} string "Internal server error"
@@ -107,7 +143,144 @@ func (h *Handler) GetTransactionByID(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	if err = json.NewEncoder(w).Encode(convertTransactionEntityToResponse(resp)); err != nil {
+	if err = json.NewEncoder(w).Encode(convertTransactionEntityToChainResponse(resp)); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// BatchGetTransactions godoc
+// @Summary Get several transactions by ID
+// @Description Returns the transactions with the given UUIDs in request order, repeated ids once, and the ids that weren't found.
+// @Description The number of ids per request is capped by TRANSACTIONS_MAX_BATCH_SIZE of the transaction manager, default 100.
+// @Tags transactions
+// @Accept json
+// @Produce json
+// @Param request body batchGetRequest true "Transaction IDs"
+// @Param reporting_currency query string false "Currency to also report amounts in, e.g. USD"
+// @Success 200 {object} transactionBatch "Found transactions and missing IDs"
+// @Failure 400 {object} string "Invalid body, no ids or too many ids"
+// @Failure 500 {object} string "Internal server error"
+// @Router /transactions:batchGet [post]
+func (h *Handler) BatchGetTransactions(w http.ResponseWriter, r *http.Request) {
+	var req batchGetRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBatchGetBodySize)).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	if len(req.IDs) == 0 {
+		writeJSONError(w, http.StatusBadRequest, "missing ids")
+		return
+	}
+
+	resp, err := h.cli.BatchGetTransactions(r.Context(), req.IDs, r.URL.Query().Get("reporting_currency"))
+	if err != nil {
+		code, errMsg := errors.ParseSvcErrToResp(err)
+		if code == http.StatusInternalServerError {
+			log.Println(err.Error())
+		}
+
+		writeJSONError(w, code, errMsg)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(convertTransactionBatchToResponse(resp)); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// GetRound godoc
+// @Summary Get a game round
+// @Description Returns a round with its transactions, totals and net result.
+// @Description A round is settled once it has a bet and a win, refund or rollback.
+// @Description Orphaned rounds have been open for longer than the configured timeout, unmatched_win marks wins without a bet.
+// @Tags rounds
+// @Accept json
+// @Produce json
+// @Param id path string true "Round ID"
+// @Param provider query string false "Provider of the round, round ids are only unique per provider"
+// @Success 200 {object} round "Round object"
+// @Failure 400 {object} string "Missing ID"
+// @Failure 404 {object} string "Round not found"
+// @Failure 500 {object} string "Internal server error"
+// @Router /rounds/{id} [get]
+func (h *Handler) GetRound(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		writeJSONError(w, http.StatusBadRequest, "Missing id parameter")
+		return
+	}
+
+	resp, err := h.cli.GetRound(r.Context(), r.URL.Query().Get("provider"), id)
+	if err != nil {
+		code, errMsg := errors.ParseSvcErrToResp(err)
+		if code == http.StatusInternalServerError {
+			log.Println(err.Error())
+		}
+
+		writeJSONError(w, code, errMsg)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(convertRoundEntityToResponse(resp)); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// ListOpenRounds godoc
+// @Summary Get a list of open rounds
+// @Description Returns rounds that are not settled yet, oldest first, with their transactions
+// @Tags rounds
+// @Accept json
+// @Produce json
+// @Param user_id query string false "Only rounds of this user"
+// @Param orphaned query bool false "Only rounds open for longer than the orphan timeout"
+// @Param limit query int false "Number of rounds to return" default(10)
+// @Param offset query int false "Pagination offset" default(0)
+// @Success 200 {object} rounds "Open rounds"
+// @Failure 400 {object} string "Invalid request parameters"
+// @Failure 500 {object} string "Internal server error"
+// @Router /rounds/open [get]
+func (h *Handler) ListOpenRounds(w http.ResponseWriter, r *http.Request) {
+	limit := strToIntWithDefault(r.URL.Query().Get("limit"), 10)
+	offset := strToIntWithDefault(r.URL.Query().Get("offset"), 0)
+
+	filter := entities.RoundFilter{UserID: r.URL.Query().Get("user_id")}
+	if filter.UserID != "" {
+		if _, err := uuid.Parse(filter.UserID); err != nil {
+			writeJSONError(w, http.StatusBadRequest, "invalid user_id parameter")
+			return
+		}
+	}
+
+	if orphaned := r.URL.Query().Get("orphaned"); orphaned != "" {
+		v, err := strconv.ParseBool(orphaned)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, "invalid orphaned parameter")
+			return
+		}
+
+		filter.OrphanedOnly = v
+	}
+
+	resp, err := h.cli.ListOpenRounds(r.Context(), filter, limit, offset)
+	if err != nil {
+		code, errMsg := errors.ParseSvcErrToResp(err)
+		if code == http.StatusInternalServerError {
+			log.Println(err.Error())
+		}
+
+		writeJSONError(w, code, errMsg)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(convertRoundEntitiesToResponse(resp)); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -116,3 +289,203 @@ func (h *Handler) GetTransactionByID(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) Healthcheck(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
+
+// GetUserBalance godoc
+// @Summary Get the balance of a user
+// @Description Returns the wallet balance of a user: the sum of credits minus debits of all their transactions.
+// @Description Bet and withdrawal are debits, a rollback takes the reverse of the transaction it voids, all other types are credits.
+// @Description A rollback counts once the transaction it voids arrives. Balances are kept per currency, in minor units.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param currency query string false "ISO 4217 code" default(EUR)
+// @Param reporting_currency query string false "ISO 4217 code to also convert amounts to, with the rate valid at their time"
+// @Success 200 {object} balance "Balance object"
+// @Failure 400 {object} string "Invalid or missing ID"
+// @Failure 404 {object} string "User has no transactions"
+// @Failure 500 {object} string "Internal server error"
+// @Router /users/{id}/balance [get]
+func (h *Handler) GetUserBalance(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		writeJSONError(w, http.StatusBadRequest, "Missing id parameter")
+		return
+	}
+
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid id parameter")
+		return
+	}
+
+	resp, err := h.cli.GetUserBalance(r.Context(), parsedID, r.URL.Query().Get("currency"), r.URL.Query().Get("reporting_currency"))
+	if err != nil {
+		code, errMsg := errors.ParseSvcErrToResp(err)
+		if code == http.StatusInternalServerError {
+			log.Println(err.Error())
+		}
+
+		writeJSONError(w, code, errMsg)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(convertBalanceEntityToResponse(resp)); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// GetBalanceAt godoc
+// @Summary Get the balance of a user at a point in time
+// @Description Returns the balance of a user including every transaction up to the given time.
+// @Description It is replayed from the nearest balance snapshot, a user without transactions by then has balance 0.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param time query string true "RFC3339 timestamp, e.g., 2025-01-01T14:03:00Z"
+// @Param currency query string false "ISO 4217 code" default(EUR)
+// @Param reporting_currency query string false "ISO 4217 code to also convert amounts to, with the rate valid at their time"
+// @Success 200 {object} balancePoint "Balance at the given time"
+// @Failure 400 {object} string "Invalid request parameters"
+// @Failure 500 {object} string "Internal server error"
+// @Router /users/{id}/balance/at [get]
+func (h *Handler) GetBalanceAt(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid id parameter")
+		return
+	}
+
+	at, err := time.Parse(time.RFC3339, r.URL.Query().Get("time"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid time parameter")
+		return
+	}
+
+	resp, err := h.cli.GetBalanceAt(r.Context(), userID, r.URL.Query().Get("currency"), r.URL.Query().Get("reporting_currency"), at)
+	if err != nil {
+		code, errMsg := errors.ParseSvcErrToResp(err)
+		if code == http.StatusInternalServerError {
+			log.Println(err.Error())
+		}
+
+		writeJSONError(w, code, errMsg)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(convertBalancePointEntityToResponse(resp)); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// GetBalanceHistory godoc
+// @Summary Get the balance history of a user
+// @Description Returns the balance of a user at from, after every step and at to, the last step may be shorter.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param from query string true "RFC3339 start of the range"
+// @Param to query string true "RFC3339 end of the range"
+// @Param step query string false "Duration between points, e.g., 15m or 24h" default(1h)
+// @Param currency query string false "ISO 4217 code" default(EUR)
+// @Param reporting_currency query string false "ISO 4217 code to also convert amounts to, with the rate valid at their time"
+// @Success 200 {object} balanceHistory "Balance time series"
+// @Failure 400 {object} string "Invalid request parameters"
+// @Failure 500 {object} string "Internal server error"
+// @Router /users/{id}/balance/history [get]
+func (h *Handler) GetBalanceHistory(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid id parameter")
+		return
+	}
+
+	from, err := time.Parse(time.RFC3339, r.URL.Query().Get("from"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid from parameter")
+		return
+	}
+
+	to, err := time.Parse(time.RFC3339, r.URL.Query().Get("to"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid to parameter")
+		return
+	}
+
+	step := time.Hour
+	if v := r.URL.Query().Get("step"); v != "" {
+		step, err = time.ParseDuration(v)
+		if err != nil || step < time.Second {
+			writeJSONError(w, http.StatusBadRequest, "invalid step parameter")
+			return
+		}
+	}
+
+	resp, err := h.cli.GetBalanceHistory(r.Context(), userID, r.URL.Query().Get("currency"), r.URL.Query().Get("reporting_currency"), from, to, step)
+	if err != nil {
+		code, errMsg := errors.ParseSvcErrToResp(err)
+		if code == http.StatusInternalServerError {
+			log.Println(err.Error())
+		}
+
+		writeJSONError(w, code, errMsg)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(convertBalancePointEntitiesToResponse(resp)); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// ListPostings godoc
+// @Summary Get the ledger postings of an account
+// @Description Returns postings of a ledger account, newest first, with the account balance.
+// @Description Every transaction is posted twice: to the player wallet and to house, bank, bonus_pool or jackpot_pool, and its postings sum to zero.
+// @Description Player wallets are named player:<user id>.
+// @Tags ledger
+// @Accept json
+// @Produce json
+// @Param account path string true "Account name"
+// @Param currency query string false "ISO 4217 code" default(EUR)
+// @Param reporting_currency query string false "ISO 4217 code to also convert amounts to, with the rate valid at their time"
+// @Param limit query int false "Number of postings to return" default(10)
+// @Param offset query int false "Pagination offset" default(0)
+// @Success 200 {object} postings "Postings and account balance"
+// @Failure 400 {object} string "Invalid request parameters"
+// @Failure 500 {object} string "Internal server error"
+// @Router /ledger/accounts/{account}/postings [get]
+func (h *Handler) ListPostings(w http.ResponseWriter, r *http.Request) {
+	account := r.PathValue("account")
+	if account == "" {
+		writeJSONError(w, http.StatusBadRequest, "Missing account parameter")
+		return
+	}
+
+	limit := strToIntWithDefault(r.URL.Query().Get("limit"), 10)
+	offset := strToIntWithDefault(r.URL.Query().Get("offset"), 0)
+
+	resp, balance, err := h.cli.ListPostings(r.Context(), account, r.URL.Query().Get("currency"), r.URL.Query().Get("reporting_currency"), limit, offset)
+	if err != nil {
+		code, errMsg := errors.ParseSvcErrToResp(err)
+		if code == http.StatusInternalServerError {
+			log.Println(err.Error())
+		}
+
+		writeJSONError(w, code, errMsg)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(convertPostingEntitiesToResponse(resp, balance)); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
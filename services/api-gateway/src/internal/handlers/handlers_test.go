@@ -3,12 +3,16 @@ package handlers
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/e1esm/casino-transaction-system/api-gateway/src/internal/entities"
 	mocks "github.com/e1esm/casino-transaction-system/api-gateway/src/internal/handlers/mocks"
+	"github.com/e1esm/casino-transaction-system/api-gateway/src/internal/svcerr"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -81,8 +85,13 @@ func TestHandler_GetTransactions(t *testing.T) {
 		name           string
 		query          string
 		queryFilters   entities.TransactionFilter
+		expr           *entities.FilterExpr
 		mockReturn     []entities.Transaction
-		mockTotal      int
+		mockTotal      int64
+		cursor         string
+		orderBy        []entities.Sort
+		exactTotal     bool
+		nextCursor     string
 		mockErr        error
 		expectedStatus int
 	}{
@@ -97,6 +106,22 @@ func TestHandler_GetTransactions(t *testing.T) {
 			mockErr:        nil,
 			expectedStatus: http.StatusOK,
 		},
+		{
+			name:  "success with filter expression",
+			query: "?filters=type%3D%3Dbet%3Bamount%3E1000",
+			expr: &entities.FilterExpr{And: []entities.FilterExpr{
+				{Comparison: &entities.Comparison{Field: entities.FilterType, Op: entities.OpEqual, Values: []any{"bet"}}},
+				{Comparison: &entities.Comparison{Field: entities.FilterAmount, Op: entities.OpGreater, Values: []any{int64(1000)}}},
+			}},
+			mockReturn:     []entities.Transaction{{ID: uuid.New(), Type: entities.Bet, Amount: 1500}},
+			mockTotal:      1,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid filter expression",
+			query:          "?filters=date%3D%3D2025-01-01",
+			expectedStatus: http.StatusBadRequest,
+		},
 		{
 			name:           "success",
 			query:          "",
@@ -105,11 +130,58 @@ func TestHandler_GetTransactions(t *testing.T) {
 			mockErr:        nil,
 			expectedStatus: http.StatusOK,
 		},
+		{
+			name:           "next page by cursor",
+			query:          "?cursor=abc",
+			cursor:         "abc",
+			mockReturn:     []entities.Transaction{{ID: uuid.New(), Amount: 100}},
+			mockTotal:      1,
+			nextCursor:     "def",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "estimated total",
+			query:          "?count=estimated",
+			mockReturn:     []entities.Transaction{{ID: uuid.New(), Amount: 100}},
+			mockTotal:      1200000,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "exact total",
+			query:          "?count=exact",
+			exactTotal:     true,
+			mockReturn:     []entities.Transaction{{ID: uuid.New(), Amount: 100}},
+			mockTotal:      1,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "ordered by several fields",
+			query:          "?orderBy=-amount,timestamp",
+			orderBy:        []entities.Sort{{Field: entities.SortByAmount, Desc: true}, {Field: entities.SortByTimestamp}},
+			mockReturn:     []entities.Transaction{{ID: uuid.New(), Amount: 200}, {ID: uuid.New(), Amount: 100}},
+			mockTotal:      2,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "unknown sort direction",
+			query:          "?orderBy=amount%20foo",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown count mode",
+			query:          "?count=approximate",
+			expectedStatus: http.StatusBadRequest,
+		},
 		{
 			name:           "bad filters",
 			query:          "?filters=invalid",
 			expectedStatus: http.StatusBadRequest,
 		},
+		{
+			name:           "unknown transaction type",
+			query:          "?filters=%7B%22type%22%3A%20%22cashback%22%7D",
+			expectedStatus: http.StatusBadRequest,
+		},
 		{
 			name:           "internal server error",
 			query:          "",
@@ -120,12 +192,21 @@ func TestHandler_GetTransactions(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			query := entities.TransactionQuery{
+				Filter:     tt.queryFilters,
+				Expr:       tt.expr,
+				OrderBy:    tt.orderBy,
+				Cursor:     tt.cursor,
+				Limit:      10,
+				ExactTotal: tt.exactTotal,
+			}
+
 			if tt.mockErr == nil && tt.expectedStatus == http.StatusOK {
-				cliMock.On("GetTransactions", mock.Anything, tt.queryFilters, "", int64(10), int64(0)).
-					Return(tt.mockReturn, tt.mockTotal, nil).Once()
+				cliMock.On("GetTransactions", mock.Anything, query).
+					Return(entities.TransactionPage{Transactions: tt.mockReturn, Total: tt.mockTotal, TotalExact: tt.exactTotal, NextCursor: tt.nextCursor}, nil).Once()
 			} else if tt.mockErr != nil {
-				cliMock.On("GetTransactions", mock.Anything, tt.queryFilters, "", int64(10), int64(0)).
-					Return(nil, 0, tt.mockErr).Once()
+				cliMock.On("GetTransactions", mock.Anything, query).
+					Return(entities.TransactionPage{}, tt.mockErr).Once()
 			}
 
 			req := httptest.NewRequest(http.MethodGet, "/transactions"+tt.query, nil)
@@ -134,6 +215,14 @@ func TestHandler_GetTransactions(t *testing.T) {
 			h.GetTransactions(w, req)
 
 			assert.Equal(t, tt.expectedStatus, w.Result().StatusCode)
+			if tt.expectedStatus == http.StatusOK {
+				var resp transactions
+				assert.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+				assert.Equal(t, tt.nextCursor, resp.NextCursor)
+				assert.Equal(t, tt.mockTotal, resp.Total)
+				assert.Equal(t, tt.exactTotal, resp.TotalExact)
+			}
+
 			cliMock.AssertExpectations(t)
 			cliMock.ExpectedCalls = nil
 		})
@@ -197,3 +286,434 @@ func TestHandler_GetTransactionByID(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_BatchGetTransactions(t *testing.T) {
+	cliMock := mocks.NewMockClient(t)
+	h := New(cliMock)
+
+	found, missing := uuid.New(), uuid.New()
+	tests := []struct {
+		name           string
+		body           string
+		mockReturn     entities.TransactionBatch
+		mockErr        error
+		expectedStatus int
+	}{
+		{
+			name:           "found and missing",
+			body:           fmt.Sprintf(`{"ids":["%s","%s"]}`, found, missing),
+			mockReturn:     entities.TransactionBatch{Transactions: []entities.Transaction{{ID: found}}, MissingIDs: []uuid.UUID{missing}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid body",
+			body:           `{"ids":`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid id",
+			body:           `{"ids":["42"]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "no ids",
+			body:           `{"ids":[]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "rejected by the transaction manager",
+			body:           fmt.Sprintf(`{"ids":["%s","%s"]}`, found, missing),
+			mockErr:        fmt.Errorf("%w: batch is limited to 1 ids", svcerr.ErrBadField),
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockReturn.Transactions != nil || tt.mockErr != nil {
+				cliMock.On("BatchGetTransactions", mock.Anything, []uuid.UUID{found, missing}, "USD").Return(tt.mockReturn, tt.mockErr).Once()
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/transactions:batchGet?reporting_currency=USD", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			h.BatchGetTransactions(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Result().StatusCode)
+			if tt.expectedStatus == http.StatusOK {
+				var resp transactionBatch
+				assert.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+				assert.Len(t, resp.Transactions, 1)
+				assert.Equal(t, []uuid.UUID{missing}, resp.MissingIDs)
+			}
+
+			cliMock.AssertExpectations(t)
+			cliMock.ExpectedCalls = nil
+		})
+	}
+}
+
+func TestHandler_GetRound(t *testing.T) {
+	cliMock := mocks.NewMockClient(t)
+	h := New(cliMock)
+
+	userID := uuid.New()
+	tests := []struct {
+		name           string
+		id             string
+		mockReturn     entities.Round
+		mockErr        error
+		expectedStatus int
+	}{
+		{
+			name: "success",
+			id:   "round-1",
+			mockReturn: entities.Round{
+				ID:           "round-1",
+				UserID:       userID,
+				Status:       entities.RoundSettled,
+				BetTotal:     100,
+				WinTotal:     250,
+				Net:          150,
+				SettledAt:    1735743600,
+				Transactions: []entities.Transaction{{ID: uuid.New(), Type: entities.Bet}, {ID: uuid.New(), Type: entities.Win}},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing id",
+			id:             "",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "not found",
+			id:             "round-1",
+			mockErr:        fmt.Errorf("%w: round not found", svcerr.ErrNotFound),
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.id != "" {
+				cliMock.On("GetRound", mock.Anything, "pragmatic", tt.id).Return(tt.mockReturn, tt.mockErr).Once()
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/rounds/?provider=pragmatic", nil)
+			req.SetPathValue("id", tt.id)
+			w := httptest.NewRecorder()
+
+			h.GetRound(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Result().StatusCode)
+			if tt.expectedStatus == http.StatusOK {
+				var resp round
+				assert.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+				assert.Equal(t, "settled", resp.Status)
+				assert.Equal(t, int64(150), resp.Net)
+				assert.NotNil(t, resp.SettledAt)
+				assert.Len(t, resp.Transactions, 2)
+			}
+
+			cliMock.AssertExpectations(t)
+			cliMock.ExpectedCalls = nil
+		})
+	}
+}
+
+func TestHandler_ListOpenRounds(t *testing.T) {
+	cliMock := mocks.NewMockClient(t)
+	h := New(cliMock)
+
+	userID := uuid.New()
+	tests := []struct {
+		name           string
+		query          string
+		filter         entities.RoundFilter
+		mockReturn     []entities.Round
+		mockErr        error
+		expectedStatus int
+	}{
+		{
+			name:           "orphaned rounds of a user",
+			query:          "?user_id=" + userID.String() + "&orphaned=true",
+			filter:         entities.RoundFilter{UserID: userID.String(), OrphanedOnly: true},
+			mockReturn:     []entities.Round{{ID: "round-1", UserID: userID, Status: entities.RoundOpen, Orphaned: true}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "all open rounds",
+			query:          "",
+			mockReturn:     []entities.Round{},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid user id",
+			query:          "?user_id=invalid",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid orphaned flag",
+			query:          "?orphaned=maybe",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "internal server error",
+			query:          "",
+			mockErr:        errors.New("internal"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedStatus != http.StatusBadRequest {
+				cliMock.On("ListOpenRounds", mock.Anything, tt.filter, int64(10), int64(0)).
+					Return(tt.mockReturn, tt.mockErr).Once()
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/rounds/open"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			h.ListOpenRounds(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Result().StatusCode)
+			if tt.expectedStatus == http.StatusOK {
+				var resp rounds
+				assert.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+				assert.Len(t, resp.Rounds, len(tt.mockReturn))
+			}
+
+			cliMock.AssertExpectations(t)
+			cliMock.ExpectedCalls = nil
+		})
+	}
+}
+
+func TestHandler_GetUserBalance(t *testing.T) {
+	cliMock := mocks.NewMockClient(t)
+	h := New(cliMock)
+
+	userID := uuid.New()
+	tests := []struct {
+		name           string
+		id             string
+		mockReturn     entities.Balance
+		mockErr        error
+		expectedStatus int
+	}{
+		{
+			name:           "success",
+			id:             userID.String(),
+			mockReturn:     entities.Balance{UserID: userID, Balance: 750, CreditTotal: 1050, DebitTotal: 300, TransactionCount: 3, LastTransactionTime: 1735743600},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing id",
+			id:             "",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid id",
+			id:             "invalid",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "user without transactions",
+			id:             userID.String(),
+			mockErr:        fmt.Errorf("%w: user has no transactions", svcerr.ErrNotFound),
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedStatus != http.StatusBadRequest {
+				cliMock.On("GetUserBalance", mock.Anything, userID, "", "").Return(tt.mockReturn, tt.mockErr).Once()
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/users/balance", nil)
+			req.SetPathValue("id", tt.id)
+			w := httptest.NewRecorder()
+
+			h.GetUserBalance(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Result().StatusCode)
+			if tt.expectedStatus == http.StatusOK {
+				var resp balance
+				assert.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+				assert.Equal(t, userID, resp.UserID)
+				assert.Equal(t, int64(750), resp.Balance)
+				assert.Equal(t, int64(3), resp.TransactionCount)
+				assert.Equal(t, time.Unix(1735743600, 0).UTC(), resp.LastTransactionTime)
+			}
+
+			cliMock.AssertExpectations(t)
+			cliMock.ExpectedCalls = nil
+		})
+	}
+}
+
+func TestHandler_GetBalanceAt(t *testing.T) {
+	cliMock := mocks.NewMockClient(t)
+	h := New(cliMock)
+
+	userID := uuid.New()
+	at := time.Date(2025, 1, 1, 14, 3, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		id             string
+		query          string
+		expectedStatus int
+	}{
+		{name: "success", id: userID.String(), query: "?time=2025-01-01T14:03:00Z&currency=USD&reporting_currency=EUR", expectedStatus: http.StatusOK},
+		{name: "invalid id", id: "invalid", query: "?time=2025-01-01T14:03:00Z", expectedStatus: http.StatusBadRequest},
+		{name: "missing time", id: userID.String(), expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedStatus == http.StatusOK {
+				cliMock.On("GetBalanceAt", mock.Anything, userID, "USD", "EUR", at).
+					Return(entities.BalancePoint{
+						Timestamp:        at.Unix(),
+						Currency:         "USD",
+						Balance:          120,
+						TransactionCount: 4,
+						Reporting:        &entities.Conversion{Currency: "EUR", Amount: 115, CurrencyExponent: 2, Rate: "0.9615384615", RateDate: 1735689600},
+					}, nil).Once()
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/users/balance/at"+tt.query, nil)
+			req.SetPathValue("id", tt.id)
+			w := httptest.NewRecorder()
+
+			h.GetBalanceAt(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Result().StatusCode)
+			if tt.expectedStatus == http.StatusOK {
+				var resp balancePoint
+				assert.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+				assert.Equal(t, at, resp.Time)
+				assert.Equal(t, "USD", resp.Currency)
+				assert.Equal(t, int64(120), resp.Balance)
+				assert.Equal(t, "1.15", resp.Reporting.AmountDecimal)
+				assert.Equal(t, "0.9615384615", resp.Reporting.Rate)
+				assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), *resp.Reporting.RateDate)
+			}
+
+			cliMock.AssertExpectations(t)
+			cliMock.ExpectedCalls = nil
+		})
+	}
+}
+
+func TestHandler_GetBalanceHistory(t *testing.T) {
+	cliMock := mocks.NewMockClient(t)
+	h := New(cliMock)
+
+	userID := uuid.New()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	tests := []struct {
+		name           string
+		query          string
+		step           time.Duration
+		mockErr        error
+		expectedStatus int
+	}{
+		{name: "default step", query: "?from=2025-01-01T00:00:00Z&to=2025-01-02T00:00:00Z", step: time.Hour, expectedStatus: http.StatusOK},
+		{name: "custom step", query: "?from=2025-01-01T00:00:00Z&to=2025-01-02T00:00:00Z&step=15m", step: 15 * time.Minute, expectedStatus: http.StatusOK},
+		{name: "invalid step", query: "?from=2025-01-01T00:00:00Z&to=2025-01-02T00:00:00Z&step=fast", expectedStatus: http.StatusBadRequest},
+		{name: "missing to", query: "?from=2025-01-01T00:00:00Z", expectedStatus: http.StatusBadRequest},
+		{
+			name:           "too many points",
+			query:          "?from=2025-01-01T00:00:00Z&to=2025-01-02T00:00:00Z&step=1s",
+			step:           time.Second,
+			mockErr:        fmt.Errorf("%w: history is limited to 1000 points", svcerr.ErrBadField),
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.step != 0 {
+				cliMock.On("GetBalanceHistory", mock.Anything, userID, "", "", from, to, tt.step).
+					Return([]entities.BalancePoint{{Timestamp: from.Unix(), Balance: 100}, {Timestamp: to.Unix(), Balance: 120}}, tt.mockErr).Once()
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/users/balance/history"+tt.query, nil)
+			req.SetPathValue("id", userID.String())
+			w := httptest.NewRecorder()
+
+			h.GetBalanceHistory(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Result().StatusCode)
+			if tt.expectedStatus == http.StatusOK {
+				var resp balanceHistory
+				assert.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+				assert.Len(t, resp.Points, 2)
+			}
+
+			cliMock.AssertExpectations(t)
+			cliMock.ExpectedCalls = nil
+		})
+	}
+}
+
+func TestHandler_ListPostings(t *testing.T) {
+	cliMock := mocks.NewMockClient(t)
+	h := New(cliMock)
+
+	tests := []struct {
+		name           string
+		account        string
+		mockReturn     []entities.Posting
+		mockErr        error
+		expectedStatus int
+	}{
+		{
+			name:           "house postings",
+			account:        "house",
+			mockReturn:     []entities.Posting{{ID: uuid.New(), TransactionID: uuid.New(), Account: "house", Amount: 100}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing account",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown account",
+			account:        "casino",
+			mockErr:        fmt.Errorf("%w: unknown account", svcerr.ErrBadField),
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.account != "" {
+				cliMock.On("ListPostings", mock.Anything, tt.account, "", "", int64(10), int64(0)).Return(tt.mockReturn, entities.AccountBalance{Balance: 100}, tt.mockErr).Once()
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/ledger/accounts/postings", nil)
+			req.SetPathValue("account", tt.account)
+			w := httptest.NewRecorder()
+
+			h.ListPostings(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Result().StatusCode)
+			if tt.expectedStatus == http.StatusOK {
+				var resp postings
+				assert.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+				assert.Len(t, resp.Postings, 1)
+				assert.Equal(t, int64(100), resp.Balance)
+			}
+
+			cliMock.AssertExpectations(t)
+			cliMock.ExpectedCalls = nil
+		})
+	}
+}
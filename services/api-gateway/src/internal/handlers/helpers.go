@@ -2,12 +2,17 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/e1esm/casino-transaction-system/api-gateway/src/internal/entities"
+	"github.com/e1esm/casino-transaction-system/api-gateway/src/internal/rsql"
+	"github.com/google/uuid"
 )
 
 func writeJSONError(w http.ResponseWriter, status int, errMsg string) {
@@ -18,15 +23,34 @@ func writeJSONError(w http.ResponseWriter, status int, errMsg string) {
 	}
 }
 
-func convertTransactionEntitiesToResponse(entities []entities.Transaction, total int) transactions {
-	response := make([]transaction, 0, len(entities))
-	for _, entity := range entities {
+func convertTransactionPageToResponse(page entities.TransactionPage) transactions {
+	response := make([]transaction, 0, len(page.Transactions))
+	for _, entity := range page.Transactions {
 		response = append(response, convertTransactionEntityToResponse(entity))
 	}
 
 	return transactions{
 		Transactions: response,
-		Total:        total,
+		Total:        page.Total,
+		TotalExact:   page.TotalExact,
+		NextCursor:   page.NextCursor,
+	}
+}
+
+func convertTransactionBatchToResponse(batch entities.TransactionBatch) transactionBatch {
+	response := make([]transaction, 0, len(batch.Transactions))
+	for _, entity := range batch.Transactions {
+		response = append(response, convertTransactionEntityToResponse(entity))
+	}
+
+	missing := batch.MissingIDs
+	if missing == nil {
+		missing = []uuid.UUID{}
+	}
+
+	return transactionBatch{
+		Transactions: response,
+		MissingIDs:   missing,
 	}
 }
 
@@ -35,24 +59,275 @@ func convertTransactionEntityToResponse(tr entities.Transaction) transaction {
 		ID:              tr.ID,
 		UserID:          tr.UserID,
 		Amount:          tr.Amount,
+		AmountDecimal:   formatAmount(tr.Amount, tr.CurrencyExponent),
+		Currency:        tr.Currency,
 		TransactionType: string(tr.Type),
+		Direction:       string(tr.Direction),
 		TransactionDate: time.Unix(tr.Timestamp, 0).UTC(),
+		Status:          string(tr.Status),
+		ReferenceID:     tr.ReferenceID,
+		OriginalID:      tr.OriginalID,
+		RoundID:         tr.RoundID,
+		GameID:          tr.GameID,
+		Provider:        tr.Provider,
+		SessionID:       tr.SessionID,
+		Channel:         tr.Channel,
+		Reporting:       convertConversionEntityToResponse(tr.Reporting),
+	}
+}
+
+func convertTransactionEntityToChainResponse(tr entities.Transaction) transactionWithChain {
+	chain := make([]transaction, 0, len(tr.Chain))
+	for _, related := range tr.Chain {
+		chain = append(chain, convertTransactionEntityToResponse(related))
+	}
+
+	return transactionWithChain{
+		transaction: convertTransactionEntityToResponse(tr),
+		Chain:       chain,
+	}
+}
+
+func convertRoundEntityToResponse(r entities.Round) round {
+	resp := round{
+		ID:           r.ID,
+		UserID:       r.UserID,
+		GameID:       r.GameID,
+		Provider:     r.Provider,
+		Status:       string(r.Status),
+		Currency:     r.Currency,
+		BetTotal:     r.BetTotal,
+		WinTotal:     r.WinTotal,
+		Net:          r.Net,
+		BetCount:     r.BetCount,
+		WinCount:     r.WinCount,
+		OpenedAt:     time.Unix(r.OpenedAt, 0).UTC(),
+		Orphaned:     r.Orphaned,
+		UnmatchedWin: r.UnmatchedWin,
+		Transactions: make([]transaction, 0, len(r.Transactions)),
+	}
+
+	if r.SettledAt != 0 {
+		settledAt := time.Unix(r.SettledAt, 0).UTC()
+		resp.SettledAt = &settledAt
+	}
+
+	for _, tr := range r.Transactions {
+		resp.Transactions = append(resp.Transactions, convertTransactionEntityToResponse(tr))
+	}
+
+	return resp
+}
+
+func convertRoundEntitiesToResponse(entities []entities.Round) rounds {
+	response := make([]round, 0, len(entities))
+	for _, entity := range entities {
+		response = append(response, convertRoundEntityToResponse(entity))
+	}
+
+	return rounds{Rounds: response}
+}
+
+func convertBalanceEntityToResponse(b entities.Balance) balance {
+	return balance{
+		UserID:              b.UserID,
+		Currency:            b.Currency,
+		Balance:             b.Balance,
+		CreditTotal:         b.CreditTotal,
+		DebitTotal:          b.DebitTotal,
+		TransactionCount:    b.TransactionCount,
+		LastTransactionTime: time.Unix(b.LastTransactionTime, 0).UTC(),
+		UpdatedAt:           time.Unix(b.UpdatedAt, 0).UTC(),
+		Reporting:           convertConversionEntityToResponse(b.Reporting),
+	}
+}
+
+func convertBalancePointEntityToResponse(p entities.BalancePoint) balancePoint {
+	return balancePoint{
+		Time:             time.Unix(p.Timestamp, 0).UTC(),
+		Currency:         p.Currency,
+		Balance:          p.Balance,
+		TransactionCount: p.TransactionCount,
+		Reporting:        convertConversionEntityToResponse(p.Reporting),
 	}
 }
 
+func convertBalancePointEntitiesToResponse(entities []entities.BalancePoint) balanceHistory {
+	response := make([]balancePoint, 0, len(entities))
+	for _, entity := range entities {
+		response = append(response, convertBalancePointEntityToResponse(entity))
+	}
+
+	return balanceHistory{Points: response}
+}
+
+func convertPostingEntitiesToResponse(entities []entities.Posting, balance entities.AccountBalance) postings {
+	response := make([]posting, 0, len(entities))
+	for _, entity := range entities {
+		response = append(response, posting{
+			ID:            entity.ID,
+			TransactionID: entity.TransactionID,
+			Account:       entity.Account,
+			Amount:        entity.Amount,
+			Currency:      entity.Currency,
+			Date:          time.Unix(entity.Timestamp, 0).UTC(),
+			Reporting:     convertConversionEntityToResponse(entity.Reporting),
+		})
+	}
+
+	return postings{
+		Postings:         response,
+		Balance:          balance.Balance,
+		ReportingBalance: convertConversionEntityToResponse(balance.Reporting),
+	}
+}
+
+func convertConversionEntityToResponse(c *entities.Conversion) *conversion {
+	if c == nil {
+		return nil
+	}
+
+	resp := &conversion{
+		Currency:      c.Currency,
+		Amount:        c.Amount,
+		AmountDecimal: formatAmount(c.Amount, c.CurrencyExponent),
+		Rate:          c.Rate,
+	}
+
+	if c.RateDate != 0 {
+		rateDate := time.Unix(c.RateDate, 0).UTC()
+		resp.RateDate = &rateDate
+	}
+
+	return resp
+}
+
+// parseFilters reads either an RSQL expression like type==bet;amount>1000 or the legacy JSON object.
+func parseFilters(filters string) (entities.TransactionFilter, *entities.FilterExpr, error) {
+	if filters == "" || strings.HasPrefix(strings.TrimSpace(filters), "{") {
+		resp, err := parseFiltersStruct(filters)
+		return resp, nil, err
+	}
+
+	expr, err := rsql.Parse(filters)
+	if err != nil {
+		return entities.TransactionFilter{}, nil, err
+	}
+
+	return entities.TransactionFilter{}, expr, nil
+}
+
 func parseFiltersStruct(filters string) (entities.TransactionFilter, error) {
 	if filters == "" {
 		return entities.TransactionFilter{}, nil
 	}
 
+	// A misspelled key would otherwise silently drop its filter.
+	decoder := json.NewDecoder(strings.NewReader(filters))
+	decoder.DisallowUnknownFields()
+
 	resp := entities.TransactionFilter{}
-	if err := json.Unmarshal([]byte(filters), &resp); err != nil {
+	if err := decoder.Decode(&resp); err != nil {
 		return resp, err
 	}
 
+	if resp.Type != "" && !resp.Type.Valid() {
+		return resp, fmt.Errorf("unknown transaction type %q", resp.Type)
+	}
+
+	if resp.From != nil && resp.To != nil && !resp.From.Before(*resp.To) {
+		return resp, errors.New("from must be before to")
+	}
+
+	if resp.MinAmount != nil && resp.MaxAmount != nil && *resp.MinAmount > *resp.MaxAmount {
+		return resp, errors.New("min_amount must not exceed max_amount")
+	}
+
 	return resp, nil
 }
 
+// parseOrderBy reads comma separated fields, each optionally prefixed with - for descending order, e.g. -amount,timestamp.
+// The "amount desc" form is accepted as well.
+func parseOrderBy(orderBy string) ([]entities.Sort, error) {
+	if orderBy == "" {
+		return nil, nil
+	}
+
+	terms := strings.Split(orderBy, ",")
+	sort := make([]entities.Sort, 0, len(terms))
+	seen := make(map[entities.SortField]struct{}, len(terms))
+
+	for _, term := range terms {
+		var s entities.Sort
+
+		term = strings.TrimSpace(term)
+		if field, direction, ok := strings.Cut(term, " "); ok {
+			switch strings.ToLower(strings.TrimSpace(direction)) {
+			case "asc":
+			case "desc":
+				s.Desc = true
+			default:
+				return nil, fmt.Errorf("unknown sort direction %q", direction)
+			}
+
+			term = field
+		} else if rest, ok := strings.CutPrefix(term, "-"); ok {
+			s.Desc = true
+			term = rest
+		} else {
+			term = strings.TrimPrefix(term, "+")
+		}
+
+		s.Field = entities.SortField(term)
+		if !s.Field.Valid() {
+			return nil, fmt.Errorf("unknown sort field %q", term)
+		}
+
+		if _, ok := seen[s.Field]; ok {
+			return nil, fmt.Errorf("duplicate sort field %q", term)
+		}
+		seen[s.Field] = struct{}{}
+
+		sort = append(sort, s)
+	}
+
+	return sort, nil
+}
+
+// parseCountMode reports whether the count parameter asks for an exact total.
+func parseCountMode(mode string) (bool, error) {
+	switch mode {
+	case "", "estimated":
+		return false, nil
+	case "exact":
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown count mode %q", mode)
+	}
+}
+
+// formatAmount renders an amount in minor units as a decimal, e.g. 1050 with exponent 2 as "10.50".
+func formatAmount(minor int64, exponent int32) string {
+	if exponent <= 0 {
+		return strconv.FormatInt(minor, 10)
+	}
+
+	sign := ""
+	digits := strconv.FormatUint(uint64(minor), 10)
+	if minor < 0 {
+		sign = "-"
+		digits = strconv.FormatUint(uint64(-minor), 10)
+	}
+
+	if pad := int(exponent) + 1 - len(digits); pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+
+	point := len(digits) - int(exponent)
+
+	return sign + digits[:point] + "." + digits[point:]
+}
+
 func strToIntWithDefault(str string, def int64) int64 {
 	i, err := strconv.Atoi(str)
 	if err != nil {
@@ -1,9 +1,12 @@
 package handlers
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/e1esm/casino-transaction-system/api-gateway/src/internal/entities"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -16,7 +19,7 @@ func TestParseFiltersStruct(t *testing.T) {
 	}{
 		{
 			name:    "both filters provided",
-			filters: "{\"UserID\":\"461805a5-d762-441b-91ac-961629f926e7\",\"Type\":\"bet\"}",
+			filters: "{\"user_id\":\"461805a5-d762-441b-91ac-961629f926e7\",\"type\":\"bet\"}",
 			expected: entities.TransactionFilter{
 				UserID: "461805a5-d762-441b-91ac-961629f926e7",
 				Type:   "bet",
@@ -29,6 +32,73 @@ func TestParseFiltersStruct(t *testing.T) {
 			expected: entities.TransactionFilter{},
 			isErr:    false,
 		},
+		{
+			name:    "new transaction type",
+			filters: "{\"Type\":\"jackpot_win\"}",
+			expected: entities.TransactionFilter{
+				Type: entities.JackpotWin,
+			},
+			isErr: false,
+		},
+		{
+			name:    "game context filters",
+			filters: "{\"round_id\":\"round-1\",\"game_id\":\"book-of-dead\",\"provider\":\"playngo\",\"session_id\":\"s-1\",\"channel\":\"web\"}",
+			expected: entities.TransactionFilter{
+				RoundID:   "round-1",
+				GameID:    "book-of-dead",
+				Provider:  "playngo",
+				SessionID: "s-1",
+				Channel:   "web",
+			},
+			isErr: false,
+		},
+		{
+			name:     "currency filter",
+			filters:  "{\"currency\":\"USD\"}",
+			expected: entities.TransactionFilter{Currency: "USD"},
+			isErr:    false,
+		},
+		{
+			name:    "time and amount ranges",
+			filters: "{\"type\":\"bet\",\"from\":\"2025-01-01T22:00:00Z\",\"to\":\"2025-01-02T06:00:00Z\",\"min_amount\":1000}",
+			expected: entities.TransactionFilter{
+				Type:      entities.Bet,
+				From:      ptr(time.Date(2025, 1, 1, 22, 0, 0, 0, time.UTC)),
+				To:        ptr(time.Date(2025, 1, 2, 6, 0, 0, 0, time.UTC)),
+				MinAmount: ptr(int64(1000)),
+			},
+			isErr: false,
+		},
+		{
+			name:     "from not before to",
+			filters:  "{\"from\":\"2025-01-02T06:00:00Z\",\"to\":\"2025-01-01T22:00:00Z\"}",
+			expected: entities.TransactionFilter{},
+			isErr:    true,
+		},
+		{
+			name:     "min amount above max amount",
+			filters:  "{\"min_amount\":5000,\"max_amount\":1000}",
+			expected: entities.TransactionFilter{},
+			isErr:    true,
+		},
+		{
+			name:     "invalid time bound",
+			filters:  "{\"from\":\"yesterday\"}",
+			expected: entities.TransactionFilter{},
+			isErr:    true,
+		},
+		{
+			name:     "unknown transaction type",
+			filters:  "{\"Type\":\"cashback\"}",
+			expected: entities.TransactionFilter{},
+			isErr:    true,
+		},
+		{
+			name:     "unknown key",
+			filters:  "{\"UserID\":\"461805a5-d762-441b-91ac-961629f926e7\"}",
+			expected: entities.TransactionFilter{},
+			isErr:    true,
+		},
 		{
 			name:     "invalid filters provided",
 			filters:  "{\"Typ",
@@ -51,6 +121,70 @@ func TestParseFiltersStruct(t *testing.T) {
 	}
 }
 
+func TestParseOrderBy(t *testing.T) {
+	tests := []struct {
+		name     string
+		orderBy  string
+		expected []entities.Sort
+		isErr    bool
+	}{
+		{
+			name:     "not provided",
+			orderBy:  "",
+			expected: nil,
+		},
+		{
+			name:    "prefixed directions",
+			orderBy: "-amount,timestamp,+user_id",
+			expected: []entities.Sort{
+				{Field: entities.SortByAmount, Desc: true},
+				{Field: entities.SortByTimestamp},
+				{Field: entities.SortByUserID},
+			},
+		},
+		{
+			name:    "field and direction",
+			orderBy: "type DESC, amount asc",
+			expected: []entities.Sort{
+				{Field: entities.SortByType, Desc: true},
+				{Field: entities.SortByAmount},
+			},
+		},
+		{
+			name:    "unknown direction",
+			orderBy: "amount foo",
+			isErr:   true,
+		},
+		{
+			name:    "column that isn't a sort field",
+			orderBy: "transaction_time",
+			isErr:   true,
+		},
+		{
+			name:    "duplicate field",
+			orderBy: "amount,-amount",
+			isErr:   true,
+		},
+		{
+			name:    "empty term",
+			orderBy: "amount,",
+			isErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		resp, err := parseOrderBy(tt.orderBy)
+
+		if tt.isErr {
+			assert.Error(t, err, tt.name)
+			continue
+		}
+
+		assert.NoError(t, err, tt.name)
+		assert.Equal(t, tt.expected, resp, tt.name)
+	}
+}
+
 func TestStrToIntWithDefault(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -77,3 +211,51 @@ func TestStrToIntWithDefault(t *testing.T) {
 		assert.Equal(t, tt.expected, resp, tt.name)
 	}
 }
+
+func TestFormatAmount(t *testing.T) {
+	tests := []struct {
+		minor    int64
+		exponent int32
+		expected string
+	}{
+		{minor: 1050, exponent: 2, expected: "10.50"},
+		{minor: 5, exponent: 2, expected: "0.05"},
+		{minor: -1050, exponent: 2, expected: "-10.50"},
+		{minor: -5, exponent: 3, expected: "-0.005"},
+		{minor: 1500, exponent: 0, expected: "1500"},
+		{minor: 0, exponent: 2, expected: "0.00"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, formatAmount(tt.minor, tt.exponent))
+	}
+}
+
+func TestConvertTransactionEntityToChainResponse(t *testing.T) {
+	originalID := uuid.MustParse("461805a5-d762-441b-91ac-961629f926e7")
+	rollbackID := uuid.MustParse("0b6b2c5e-4f4e-4b8a-9a43-3a3c0d9b7f10")
+
+	original := entities.Transaction{ID: originalID, Type: entities.Bet, Direction: entities.Debit, Amount: 100, Currency: "EUR", CurrencyExponent: 2, Status: entities.Voided}
+	rollback := entities.Transaction{ID: rollbackID, Type: entities.Rollback, Direction: entities.Credit, Amount: 100, Status: entities.Completed, ReferenceID: "bet-1", OriginalID: &originalID}
+	original.Chain = []entities.Transaction{original, rollback}
+
+	data, err := json.Marshal(convertTransactionEntityToChainResponse(original))
+	assert.NoError(t, err)
+
+	var got map[string]any
+	assert.NoError(t, json.Unmarshal(data, &got))
+
+	assert.Equal(t, originalID.String(), got["id"])
+	assert.Equal(t, "voided", got["status"])
+	assert.Equal(t, "EUR", got["currency"])
+	assert.Equal(t, "1.00", got["amount_decimal"])
+	assert.NotContains(t, got, "original_id")
+
+	chain, ok := got["chain"].([]any)
+	assert.True(t, ok)
+	assert.Len(t, chain, 2)
+	assert.Equal(t, "bet-1", chain[1].(map[string]any)["reference_id"])
+	assert.Equal(t, originalID.String(), chain[1].(map[string]any)["original_id"])
+}
+
+func ptr[T any](v T) *T { return &v }
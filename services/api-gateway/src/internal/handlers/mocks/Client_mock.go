@@ -6,6 +6,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/e1esm/casino-transaction-system/api-gateway/src/internal/entities"
 	"github.com/google/uuid"
@@ -39,6 +40,332 @@ func (_m *MockClient) EXPECT() *MockClient_Expecter {
 	return &MockClient_Expecter{mock: &_m.Mock}
 }
 
+// BatchGetTransactions provides a mock function for the type MockClient
+func (_mock *MockClient) BatchGetTransactions(ctx context.Context, ids []uuid.UUID, reportingCurrency string) (entities.TransactionBatch, error) {
+	ret := _mock.Called(ctx, ids, reportingCurrency)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BatchGetTransactions")
+	}
+
+	var r0 entities.TransactionBatch
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []uuid.UUID, string) (entities.TransactionBatch, error)); ok {
+		return returnFunc(ctx, ids, reportingCurrency)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []uuid.UUID, string) entities.TransactionBatch); ok {
+		r0 = returnFunc(ctx, ids, reportingCurrency)
+	} else {
+		r0 = ret.Get(0).(entities.TransactionBatch)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []uuid.UUID, string) error); ok {
+		r1 = returnFunc(ctx, ids, reportingCurrency)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockClient_BatchGetTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BatchGetTransactions'
+type MockClient_BatchGetTransactions_Call struct {
+	*mock.Call
+}
+
+// BatchGetTransactions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []uuid.UUID
+//   - reportingCurrency string
+func (_e *MockClient_Expecter) BatchGetTransactions(ctx interface{}, ids interface{}, reportingCurrency interface{}) *MockClient_BatchGetTransactions_Call {
+	return &MockClient_BatchGetTransactions_Call{Call: _e.mock.On("BatchGetTransactions", ctx, ids, reportingCurrency)}
+}
+
+func (_c *MockClient_BatchGetTransactions_Call) Run(run func(ctx context.Context, ids []uuid.UUID, reportingCurrency string)) *MockClient_BatchGetTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].([]uuid.UUID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_BatchGetTransactions_Call) Return(transactionBatch entities.TransactionBatch, err error) *MockClient_BatchGetTransactions_Call {
+	_c.Call.Return(transactionBatch, err)
+	return _c
+}
+
+func (_c *MockClient_BatchGetTransactions_Call) RunAndReturn(run func(ctx context.Context, ids []uuid.UUID, reportingCurrency string) (entities.TransactionBatch, error)) *MockClient_BatchGetTransactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBalanceAt provides a mock function for the type MockClient
+func (_mock *MockClient) GetBalanceAt(ctx context.Context, userID uuid.UUID, currency string, reportingCurrency string, at time.Time) (entities.BalancePoint, error) {
+	ret := _mock.Called(ctx, userID, currency, reportingCurrency, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBalanceAt")
+	}
+
+	var r0 entities.BalancePoint
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string, time.Time) (entities.BalancePoint, error)); ok {
+		return returnFunc(ctx, userID, currency, reportingCurrency, at)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string, time.Time) entities.BalancePoint); ok {
+		r0 = returnFunc(ctx, userID, currency, reportingCurrency, at)
+	} else {
+		r0 = ret.Get(0).(entities.BalancePoint)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, string, time.Time) error); ok {
+		r1 = returnFunc(ctx, userID, currency, reportingCurrency, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockClient_GetBalanceAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBalanceAt'
+type MockClient_GetBalanceAt_Call struct {
+	*mock.Call
+}
+
+// GetBalanceAt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - currency string
+//   - reportingCurrency string
+//   - at time.Time
+func (_e *MockClient_Expecter) GetBalanceAt(ctx interface{}, userID interface{}, currency interface{}, reportingCurrency interface{}, at interface{}) *MockClient_GetBalanceAt_Call {
+	return &MockClient_GetBalanceAt_Call{Call: _e.mock.On("GetBalanceAt", ctx, userID, currency, reportingCurrency, at)}
+}
+
+func (_c *MockClient_GetBalanceAt_Call) Run(run func(ctx context.Context, userID uuid.UUID, currency string, reportingCurrency string, at time.Time)) *MockClient_GetBalanceAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 time.Time
+		if args[4] != nil {
+			arg4 = args[4].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_GetBalanceAt_Call) Return(balancePoint entities.BalancePoint, err error) *MockClient_GetBalanceAt_Call {
+	_c.Call.Return(balancePoint, err)
+	return _c
+}
+
+func (_c *MockClient_GetBalanceAt_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, currency string, reportingCurrency string, at time.Time) (entities.BalancePoint, error)) *MockClient_GetBalanceAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBalanceHistory provides a mock function for the type MockClient
+func (_mock *MockClient) GetBalanceHistory(ctx context.Context, userID uuid.UUID, currency string, reportingCurrency string, from time.Time, to time.Time, step time.Duration) ([]entities.BalancePoint, error) {
+	ret := _mock.Called(ctx, userID, currency, reportingCurrency, from, to, step)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBalanceHistory")
+	}
+
+	var r0 []entities.BalancePoint
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string, time.Time, time.Time, time.Duration) ([]entities.BalancePoint, error)); ok {
+		return returnFunc(ctx, userID, currency, reportingCurrency, from, to, step)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string, time.Time, time.Time, time.Duration) []entities.BalancePoint); ok {
+		r0 = returnFunc(ctx, userID, currency, reportingCurrency, from, to, step)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.BalancePoint)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, string, time.Time, time.Time, time.Duration) error); ok {
+		r1 = returnFunc(ctx, userID, currency, reportingCurrency, from, to, step)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockClient_GetBalanceHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBalanceHistory'
+type MockClient_GetBalanceHistory_Call struct {
+	*mock.Call
+}
+
+// GetBalanceHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - currency string
+//   - reportingCurrency string
+//   - from time.Time
+//   - to time.Time
+//   - step time.Duration
+func (_e *MockClient_Expecter) GetBalanceHistory(ctx interface{}, userID interface{}, currency interface{}, reportingCurrency interface{}, from interface{}, to interface{}, step interface{}) *MockClient_GetBalanceHistory_Call {
+	return &MockClient_GetBalanceHistory_Call{Call: _e.mock.On("GetBalanceHistory", ctx, userID, currency, reportingCurrency, from, to, step)}
+}
+
+func (_c *MockClient_GetBalanceHistory_Call) Run(run func(ctx context.Context, userID uuid.UUID, currency string, reportingCurrency string, from time.Time, to time.Time, step time.Duration)) *MockClient_GetBalanceHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 time.Time
+		if args[4] != nil {
+			arg4 = args[4].(time.Time)
+		}
+		var arg5 time.Time
+		if args[5] != nil {
+			arg5 = args[5].(time.Time)
+		}
+		var arg6 time.Duration
+		if args[6] != nil {
+			arg6 = args[6].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+			arg6,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_GetBalanceHistory_Call) Return(balancePoints []entities.BalancePoint, err error) *MockClient_GetBalanceHistory_Call {
+	_c.Call.Return(balancePoints, err)
+	return _c
+}
+
+func (_c *MockClient_GetBalanceHistory_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, currency string, reportingCurrency string, from time.Time, to time.Time, step time.Duration) ([]entities.BalancePoint, error)) *MockClient_GetBalanceHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRound provides a mock function for the type MockClient
+func (_mock *MockClient) GetRound(ctx context.Context, provider string, id string) (entities.Round, error) {
+	ret := _mock.Called(ctx, provider, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRound")
+	}
+
+	var r0 entities.Round
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (entities.Round, error)); ok {
+		return returnFunc(ctx, provider, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) entities.Round); ok {
+		r0 = returnFunc(ctx, provider, id)
+	} else {
+		r0 = ret.Get(0).(entities.Round)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, provider, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockClient_GetRound_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRound'
+type MockClient_GetRound_Call struct {
+	*mock.Call
+}
+
+// GetRound is a helper method to define mock.On call
+//   - ctx context.Context
+//   - provider string
+//   - id string
+func (_e *MockClient_Expecter) GetRound(ctx interface{}, provider interface{}, id interface{}) *MockClient_GetRound_Call {
+	return &MockClient_GetRound_Call{Call: _e.mock.On("GetRound", ctx, provider, id)}
+}
+
+func (_c *MockClient_GetRound_Call) Run(run func(ctx context.Context, provider string, id string)) *MockClient_GetRound_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_GetRound_Call) Return(round entities.Round, err error) *MockClient_GetRound_Call {
+	_c.Call.Return(round, err)
+	return _c
+}
+
+func (_c *MockClient_GetRound_Call) RunAndReturn(run func(ctx context.Context, provider string, id string) (entities.Round, error)) *MockClient_GetRound_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetTransactionByID provides a mock function for the type MockClient
 func (_mock *MockClient) GetTransactionByID(ctx context.Context, id uuid.UUID) (entities.Transaction, error) {
 	ret := _mock.Called(ctx, id)
@@ -106,93 +433,323 @@ func (_c *MockClient_GetTransactionByID_Call) RunAndReturn(run func(ctx context.
 }
 
 // GetTransactions provides a mock function for the type MockClient
-func (_mock *MockClient) GetTransactions(ctx context.Context, filter entities.TransactionFilter, orderBy string, limit int64, offset int64) ([]entities.Transaction, int, error) {
-	ret := _mock.Called(ctx, filter, orderBy, limit, offset)
+func (_mock *MockClient) GetTransactions(ctx context.Context, query entities.TransactionQuery) (entities.TransactionPage, error) {
+	ret := _mock.Called(ctx, query)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetTransactions")
 	}
 
-	var r0 []entities.Transaction
-	var r1 int
+	var r0 entities.TransactionPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, entities.TransactionQuery) (entities.TransactionPage, error)); ok {
+		return returnFunc(ctx, query)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, entities.TransactionQuery) entities.TransactionPage); ok {
+		r0 = returnFunc(ctx, query)
+	} else {
+		r0 = ret.Get(0).(entities.TransactionPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, entities.TransactionQuery) error); ok {
+		r1 = returnFunc(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockClient_GetTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransactions'
+type MockClient_GetTransactions_Call struct {
+	*mock.Call
+}
+
+// GetTransactions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query entities.TransactionQuery
+func (_e *MockClient_Expecter) GetTransactions(ctx interface{}, query interface{}) *MockClient_GetTransactions_Call {
+	return &MockClient_GetTransactions_Call{Call: _e.mock.On("GetTransactions", ctx, query)}
+}
+
+func (_c *MockClient_GetTransactions_Call) Run(run func(ctx context.Context, query entities.TransactionQuery)) *MockClient_GetTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 entities.TransactionQuery
+		if args[1] != nil {
+			arg1 = args[1].(entities.TransactionQuery)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_GetTransactions_Call) Return(transactionPage entities.TransactionPage, err error) *MockClient_GetTransactions_Call {
+	_c.Call.Return(transactionPage, err)
+	return _c
+}
+
+func (_c *MockClient_GetTransactions_Call) RunAndReturn(run func(ctx context.Context, query entities.TransactionQuery) (entities.TransactionPage, error)) *MockClient_GetTransactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserBalance provides a mock function for the type MockClient
+func (_mock *MockClient) GetUserBalance(ctx context.Context, userID uuid.UUID, currency string, reportingCurrency string) (entities.Balance, error) {
+	ret := _mock.Called(ctx, userID, currency, reportingCurrency)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserBalance")
+	}
+
+	var r0 entities.Balance
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string) (entities.Balance, error)); ok {
+		return returnFunc(ctx, userID, currency, reportingCurrency)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string) entities.Balance); ok {
+		r0 = returnFunc(ctx, userID, currency, reportingCurrency)
+	} else {
+		r0 = ret.Get(0).(entities.Balance)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, string) error); ok {
+		r1 = returnFunc(ctx, userID, currency, reportingCurrency)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockClient_GetUserBalance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserBalance'
+type MockClient_GetUserBalance_Call struct {
+	*mock.Call
+}
+
+// GetUserBalance is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - currency string
+//   - reportingCurrency string
+func (_e *MockClient_Expecter) GetUserBalance(ctx interface{}, userID interface{}, currency interface{}, reportingCurrency interface{}) *MockClient_GetUserBalance_Call {
+	return &MockClient_GetUserBalance_Call{Call: _e.mock.On("GetUserBalance", ctx, userID, currency, reportingCurrency)}
+}
+
+func (_c *MockClient_GetUserBalance_Call) Run(run func(ctx context.Context, userID uuid.UUID, currency string, reportingCurrency string)) *MockClient_GetUserBalance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_GetUserBalance_Call) Return(balance entities.Balance, err error) *MockClient_GetUserBalance_Call {
+	_c.Call.Return(balance, err)
+	return _c
+}
+
+func (_c *MockClient_GetUserBalance_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, currency string, reportingCurrency string) (entities.Balance, error)) *MockClient_GetUserBalance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOpenRounds provides a mock function for the type MockClient
+func (_mock *MockClient) ListOpenRounds(ctx context.Context, filter entities.RoundFilter, limit int64, offset int64) ([]entities.Round, error) {
+	ret := _mock.Called(ctx, filter, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOpenRounds")
+	}
+
+	var r0 []entities.Round
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, entities.RoundFilter, int64, int64) ([]entities.Round, error)); ok {
+		return returnFunc(ctx, filter, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, entities.RoundFilter, int64, int64) []entities.Round); ok {
+		r0 = returnFunc(ctx, filter, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.Round)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, entities.RoundFilter, int64, int64) error); ok {
+		r1 = returnFunc(ctx, filter, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockClient_ListOpenRounds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOpenRounds'
+type MockClient_ListOpenRounds_Call struct {
+	*mock.Call
+}
+
+// ListOpenRounds is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter entities.RoundFilter
+//   - limit int64
+//   - offset int64
+func (_e *MockClient_Expecter) ListOpenRounds(ctx interface{}, filter interface{}, limit interface{}, offset interface{}) *MockClient_ListOpenRounds_Call {
+	return &MockClient_ListOpenRounds_Call{Call: _e.mock.On("ListOpenRounds", ctx, filter, limit, offset)}
+}
+
+func (_c *MockClient_ListOpenRounds_Call) Run(run func(ctx context.Context, filter entities.RoundFilter, limit int64, offset int64)) *MockClient_ListOpenRounds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 entities.RoundFilter
+		if args[1] != nil {
+			arg1 = args[1].(entities.RoundFilter)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 int64
+		if args[3] != nil {
+			arg3 = args[3].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_ListOpenRounds_Call) Return(rounds []entities.Round, err error) *MockClient_ListOpenRounds_Call {
+	_c.Call.Return(rounds, err)
+	return _c
+}
+
+func (_c *MockClient_ListOpenRounds_Call) RunAndReturn(run func(ctx context.Context, filter entities.RoundFilter, limit int64, offset int64) ([]entities.Round, error)) *MockClient_ListOpenRounds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPostings provides a mock function for the type MockClient
+func (_mock *MockClient) ListPostings(ctx context.Context, account string, currency string, reportingCurrency string, limit int64, offset int64) ([]entities.Posting, entities.AccountBalance, error) {
+	ret := _mock.Called(ctx, account, currency, reportingCurrency, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPostings")
+	}
+
+	var r0 []entities.Posting
+	var r1 entities.AccountBalance
 	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, entities.TransactionFilter, string, int64, int64) ([]entities.Transaction, int, error)); ok {
-		return returnFunc(ctx, filter, orderBy, limit, offset)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, int64, int64) ([]entities.Posting, entities.AccountBalance, error)); ok {
+		return returnFunc(ctx, account, currency, reportingCurrency, limit, offset)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, entities.TransactionFilter, string, int64, int64) []entities.Transaction); ok {
-		r0 = returnFunc(ctx, filter, orderBy, limit, offset)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, int64, int64) []entities.Posting); ok {
+		r0 = returnFunc(ctx, account, currency, reportingCurrency, limit, offset)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]entities.Transaction)
+			r0 = ret.Get(0).([]entities.Posting)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, entities.TransactionFilter, string, int64, int64) int); ok {
-		r1 = returnFunc(ctx, filter, orderBy, limit, offset)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, int64, int64) entities.AccountBalance); ok {
+		r1 = returnFunc(ctx, account, currency, reportingCurrency, limit, offset)
 	} else {
-		r1 = ret.Get(1).(int)
+		r1 = ret.Get(1).(entities.AccountBalance)
 	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, entities.TransactionFilter, string, int64, int64) error); ok {
-		r2 = returnFunc(ctx, filter, orderBy, limit, offset)
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string, string, int64, int64) error); ok {
+		r2 = returnFunc(ctx, account, currency, reportingCurrency, limit, offset)
 	} else {
 		r2 = ret.Error(2)
 	}
 	return r0, r1, r2
 }
 
-// MockClient_GetTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransactions'
-type MockClient_GetTransactions_Call struct {
+// MockClient_ListPostings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPostings'
+type MockClient_ListPostings_Call struct {
 	*mock.Call
 }
 
-// GetTransactions is a helper method to define mock.On call
+// ListPostings is a helper method to define mock.On call
 //   - ctx context.Context
-//   - filter entities.TransactionFilter
-//   - orderBy string
+//   - account string
+//   - currency string
+//   - reportingCurrency string
 //   - limit int64
 //   - offset int64
-func (_e *MockClient_Expecter) GetTransactions(ctx interface{}, filter interface{}, orderBy interface{}, limit interface{}, offset interface{}) *MockClient_GetTransactions_Call {
-	return &MockClient_GetTransactions_Call{Call: _e.mock.On("GetTransactions", ctx, filter, orderBy, limit, offset)}
+func (_e *MockClient_Expecter) ListPostings(ctx interface{}, account interface{}, currency interface{}, reportingCurrency interface{}, limit interface{}, offset interface{}) *MockClient_ListPostings_Call {
+	return &MockClient_ListPostings_Call{Call: _e.mock.On("ListPostings", ctx, account, currency, reportingCurrency, limit, offset)}
 }
 
-func (_c *MockClient_GetTransactions_Call) Run(run func(ctx context.Context, filter entities.TransactionFilter, orderBy string, limit int64, offset int64)) *MockClient_GetTransactions_Call {
+func (_c *MockClient_ListPostings_Call) Run(run func(ctx context.Context, account string, currency string, reportingCurrency string, limit int64, offset int64)) *MockClient_ListPostings_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 entities.TransactionFilter
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(entities.TransactionFilter)
+			arg1 = args[1].(string)
 		}
 		var arg2 string
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
-		var arg3 int64
+		var arg3 string
 		if args[3] != nil {
-			arg3 = args[3].(int64)
+			arg3 = args[3].(string)
 		}
 		var arg4 int64
 		if args[4] != nil {
 			arg4 = args[4].(int64)
 		}
+		var arg5 int64
+		if args[5] != nil {
+			arg5 = args[5].(int64)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
 			arg3,
 			arg4,
+			arg5,
 		)
 	})
 	return _c
 }
 
-func (_c *MockClient_GetTransactions_Call) Return(transactions []entities.Transaction, n int, err error) *MockClient_GetTransactions_Call {
-	_c.Call.Return(transactions, n, err)
+func (_c *MockClient_ListPostings_Call) Return(postings []entities.Posting, accountBalance entities.AccountBalance, err error) *MockClient_ListPostings_Call {
+	_c.Call.Return(postings, accountBalance, err)
 	return _c
 }
 
-func (_c *MockClient_GetTransactions_Call) RunAndReturn(run func(ctx context.Context, filter entities.TransactionFilter, orderBy string, limit int64, offset int64) ([]entities.Transaction, int, error)) *MockClient_GetTransactions_Call {
+func (_c *MockClient_ListPostings_Call) RunAndReturn(run func(ctx context.Context, account string, currency string, reportingCurrency string, limit int64, offset int64) ([]entities.Posting, entities.AccountBalance, error)) *MockClient_ListPostings_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -7,14 +7,113 @@ import (
 )
 
 type transaction struct {
-	ID              uuid.UUID `json:"id"`
-	UserID          uuid.UUID `json:"user_id"`
-	Amount          int64     `json:"amount"`
-	TransactionType string    `json:"type"`
-	TransactionDate time.Time `json:"date"`
+	ID              uuid.UUID   `json:"id"`
+	UserID          uuid.UUID   `json:"user_id"`
+	Amount          int64       `json:"amount"`
+	AmountDecimal   string      `json:"amount_decimal"`
+	Currency        string      `json:"currency"`
+	TransactionType string      `json:"type"`
+	Direction       string      `json:"direction,omitempty"`
+	TransactionDate time.Time   `json:"date"`
+	Status          string      `json:"status"`
+	ReferenceID     string      `json:"reference_id,omitempty"`
+	OriginalID      *uuid.UUID  `json:"original_id,omitempty"`
+	RoundID         string      `json:"round_id,omitempty"`
+	GameID          string      `json:"game_id,omitempty"`
+	Provider        string      `json:"provider,omitempty"`
+	SessionID       string      `json:"session_id,omitempty"`
+	Channel         string      `json:"channel,omitempty"`
+	Reporting       *conversion `json:"reporting,omitempty"`
+}
+
+type conversion struct {
+	Currency      string     `json:"currency"`
+	Amount        int64      `json:"amount"`
+	AmountDecimal string     `json:"amount_decimal"`
+	Rate          string     `json:"rate"`
+	RateDate      *time.Time `json:"rate_date,omitempty"`
+}
+
+type transactionWithChain struct {
+	transaction
+	Chain []transaction `json:"chain"`
 }
 
 type transactions struct {
 	Transactions []transaction `json:"transactions"`
-	Total        int           `json:"total"`
+	Total        int64         `json:"total"`
+	TotalExact   bool          `json:"total_exact"`
+	NextCursor   string        `json:"next_cursor,omitempty"`
+}
+
+type batchGetRequest struct {
+	IDs []uuid.UUID `json:"ids"`
+}
+
+type transactionBatch struct {
+	Transactions []transaction `json:"transactions"`
+	MissingIDs   []uuid.UUID   `json:"missing_ids"`
+}
+
+type round struct {
+	ID           string        `json:"id"`
+	UserID       uuid.UUID     `json:"user_id"`
+	GameID       string        `json:"game_id,omitempty"`
+	Provider     string        `json:"provider,omitempty"`
+	Status       string        `json:"status"`
+	Currency     string        `json:"currency"`
+	BetTotal     int64         `json:"bet_total"`
+	WinTotal     int64         `json:"win_total"`
+	Net          int64         `json:"net"`
+	BetCount     int32         `json:"bet_count"`
+	WinCount     int32         `json:"win_count"`
+	OpenedAt     time.Time     `json:"opened_at"`
+	SettledAt    *time.Time    `json:"settled_at,omitempty"`
+	Orphaned     bool          `json:"orphaned"`
+	UnmatchedWin bool          `json:"unmatched_win"`
+	Transactions []transaction `json:"transactions"`
+}
+
+type rounds struct {
+	Rounds []round `json:"rounds"`
+}
+
+type balance struct {
+	UserID              uuid.UUID   `json:"user_id"`
+	Currency            string      `json:"currency"`
+	Balance             int64       `json:"balance"`
+	CreditTotal         int64       `json:"credit_total"`
+	DebitTotal          int64       `json:"debit_total"`
+	TransactionCount    int64       `json:"transaction_count"`
+	LastTransactionTime time.Time   `json:"last_transaction_time"`
+	UpdatedAt           time.Time   `json:"updated_at"`
+	Reporting           *conversion `json:"reporting,omitempty"`
+}
+
+type balancePoint struct {
+	Time             time.Time   `json:"time"`
+	Currency         string      `json:"currency"`
+	Balance          int64       `json:"balance"`
+	TransactionCount int64       `json:"transaction_count"`
+	Reporting        *conversion `json:"reporting,omitempty"`
+}
+
+type balanceHistory struct {
+	Points []balancePoint `json:"points"`
+}
+
+type posting struct {
+	ID            uuid.UUID   `json:"id"`
+	TransactionID uuid.UUID   `json:"transaction_id"`
+	Account       string      `json:"account"`
+	Amount        int64       `json:"amount"`
+	Currency      string      `json:"currency"`
+	Date          time.Time   `json:"date"`
+	Reporting     *conversion `json:"reporting,omitempty"`
+}
+
+type postings struct {
+	Postings         []posting   `json:"postings"`
+	Balance          int64       `json:"balance"`
+	ReportingBalance *conversion `json:"reporting_balance,omitempty"`
 }
@@ -21,6 +21,13 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	TransactionManager_GetTransactionByID_FullMethodName      = "/tx_manager.TransactionManager/GetTransactionByID"
 	TransactionManager_GetTransactionByFilters_FullMethodName = "/tx_manager.TransactionManager/GetTransactionByFilters"
+	TransactionManager_BatchGetTransactions_FullMethodName    = "/tx_manager.TransactionManager/BatchGetTransactions"
+	TransactionManager_GetRound_FullMethodName                = "/tx_manager.TransactionManager/GetRound"
+	TransactionManager_ListOpenRounds_FullMethodName          = "/tx_manager.TransactionManager/ListOpenRounds"
+	TransactionManager_GetUserBalance_FullMethodName          = "/tx_manager.TransactionManager/GetUserBalance"
+	TransactionManager_GetBalanceAt_FullMethodName            = "/tx_manager.TransactionManager/GetBalanceAt"
+	TransactionManager_GetBalanceHistory_FullMethodName       = "/tx_manager.TransactionManager/GetBalanceHistory"
+	TransactionManager_ListPostings_FullMethodName            = "/tx_manager.TransactionManager/ListPostings"
 )
 
 // TransactionManagerClient is the kafka API for TransactionManager service.
@@ -29,6 +36,13 @@ const (
 type TransactionManagerClient interface {
 	GetTransactionByID(ctx context.Context, in *GetTransactionByIDRequest, opts ...grpc.CallOption) (*GetTransactionByIDResponse, error)
 	GetTransactionByFilters(ctx context.Context, in *GetTransactionByFiltersRequest, opts ...grpc.CallOption) (*GetTransactionByFiltersResponse, error)
+	BatchGetTransactions(ctx context.Context, in *BatchGetTransactionsRequest, opts ...grpc.CallOption) (*BatchGetTransactionsResponse, error)
+	GetRound(ctx context.Context, in *GetRoundRequest, opts ...grpc.CallOption) (*GetRoundResponse, error)
+	ListOpenRounds(ctx context.Context, in *ListOpenRoundsRequest, opts ...grpc.CallOption) (*ListOpenRoundsResponse, error)
+	GetUserBalance(ctx context.Context, in *GetUserBalanceRequest, opts ...grpc.CallOption) (*GetUserBalanceResponse, error)
+	GetBalanceAt(ctx context.Context, in *GetBalanceAtRequest, opts ...grpc.CallOption) (*GetBalanceAtResponse, error)
+	GetBalanceHistory(ctx context.Context, in *GetBalanceHistoryRequest, opts ...grpc.CallOption) (*GetBalanceHistoryResponse, error)
+	ListPostings(ctx context.Context, in *ListPostingsRequest, opts ...grpc.CallOption) (*ListPostingsResponse, error)
 }
 
 type transactionManagerClient struct {
@@ -59,12 +73,89 @@ func (c *transactionManagerClient) GetTransactionByFilters(ctx context.Context,
 	return out, nil
 }
 
+func (c *transactionManagerClient) BatchGetTransactions(ctx context.Context, in *BatchGetTransactionsRequest, opts ...grpc.CallOption) (*BatchGetTransactionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetTransactionsResponse)
+	err := c.cc.Invoke(ctx, TransactionManager_BatchGetTransactions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionManagerClient) GetRound(ctx context.Context, in *GetRoundRequest, opts ...grpc.CallOption) (*GetRoundResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRoundResponse)
+	err := c.cc.Invoke(ctx, TransactionManager_GetRound_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionManagerClient) ListOpenRounds(ctx context.Context, in *ListOpenRoundsRequest, opts ...grpc.CallOption) (*ListOpenRoundsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOpenRoundsResponse)
+	err := c.cc.Invoke(ctx, TransactionManager_ListOpenRounds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionManagerClient) GetUserBalance(ctx context.Context, in *GetUserBalanceRequest, opts ...grpc.CallOption) (*GetUserBalanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserBalanceResponse)
+	err := c.cc.Invoke(ctx, TransactionManager_GetUserBalance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionManagerClient) GetBalanceAt(ctx context.Context, in *GetBalanceAtRequest, opts ...grpc.CallOption) (*GetBalanceAtResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBalanceAtResponse)
+	err := c.cc.Invoke(ctx, TransactionManager_GetBalanceAt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionManagerClient) GetBalanceHistory(ctx context.Context, in *GetBalanceHistoryRequest, opts ...grpc.CallOption) (*GetBalanceHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBalanceHistoryResponse)
+	err := c.cc.Invoke(ctx, TransactionManager_GetBalanceHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionManagerClient) ListPostings(ctx context.Context, in *ListPostingsRequest, opts ...grpc.CallOption) (*ListPostingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPostingsResponse)
+	err := c.cc.Invoke(ctx, TransactionManager_ListPostings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TransactionManagerServer is the server API for TransactionManager service.
 // All implementations must embed UnimplementedTransactionManagerServer
 // for forward compatibility.
 type TransactionManagerServer interface {
 	GetTransactionByID(context.Context, *GetTransactionByIDRequest) (*GetTransactionByIDResponse, error)
 	GetTransactionByFilters(context.Context, *GetTransactionByFiltersRequest) (*GetTransactionByFiltersResponse, error)
+	BatchGetTransactions(context.Context, *BatchGetTransactionsRequest) (*BatchGetTransactionsResponse, error)
+	GetRound(context.Context, *GetRoundRequest) (*GetRoundResponse, error)
+	ListOpenRounds(context.Context, *ListOpenRoundsRequest) (*ListOpenRoundsResponse, error)
+	GetUserBalance(context.Context, *GetUserBalanceRequest) (*GetUserBalanceResponse, error)
+	GetBalanceAt(context.Context, *GetBalanceAtRequest) (*GetBalanceAtResponse, error)
+	GetBalanceHistory(context.Context, *GetBalanceHistoryRequest) (*GetBalanceHistoryResponse, error)
+	ListPostings(context.Context, *ListPostingsRequest) (*ListPostingsResponse, error)
 	mustEmbedUnimplementedTransactionManagerServer()
 }
 
@@ -81,6 +172,27 @@ func (UnimplementedTransactionManagerServer) GetTransactionByID(context.Context,
 func (UnimplementedTransactionManagerServer) GetTransactionByFilters(context.Context, *GetTransactionByFiltersRequest) (*GetTransactionByFiltersResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTransactionByFilters not implemented")
 }
+func (UnimplementedTransactionManagerServer) BatchGetTransactions(context.Context, *BatchGetTransactionsRequest) (*BatchGetTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetTransactions not implemented")
+}
+func (UnimplementedTransactionManagerServer) GetRound(context.Context, *GetRoundRequest) (*GetRoundResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRound not implemented")
+}
+func (UnimplementedTransactionManagerServer) ListOpenRounds(context.Context, *ListOpenRoundsRequest) (*ListOpenRoundsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOpenRounds not implemented")
+}
+func (UnimplementedTransactionManagerServer) GetUserBalance(context.Context, *GetUserBalanceRequest) (*GetUserBalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserBalance not implemented")
+}
+func (UnimplementedTransactionManagerServer) GetBalanceAt(context.Context, *GetBalanceAtRequest) (*GetBalanceAtResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalanceAt not implemented")
+}
+func (UnimplementedTransactionManagerServer) GetBalanceHistory(context.Context, *GetBalanceHistoryRequest) (*GetBalanceHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalanceHistory not implemented")
+}
+func (UnimplementedTransactionManagerServer) ListPostings(context.Context, *ListPostingsRequest) (*ListPostingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPostings not implemented")
+}
 func (UnimplementedTransactionManagerServer) mustEmbedUnimplementedTransactionManagerServer() {}
 func (UnimplementedTransactionManagerServer) testEmbeddedByValue()                            {}
 
@@ -138,6 +250,132 @@ func _TransactionManager_GetTransactionByFilters_Handler(srv interface{}, ctx co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TransactionManager_BatchGetTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionManagerServer).BatchGetTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransactionManager_BatchGetTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionManagerServer).BatchGetTransactions(ctx, req.(*BatchGetTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionManager_GetRound_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRoundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionManagerServer).GetRound(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransactionManager_GetRound_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionManagerServer).GetRound(ctx, req.(*GetRoundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionManager_ListOpenRounds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOpenRoundsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionManagerServer).ListOpenRounds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransactionManager_ListOpenRounds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionManagerServer).ListOpenRounds(ctx, req.(*ListOpenRoundsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionManager_GetUserBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionManagerServer).GetUserBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransactionManager_GetUserBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionManagerServer).GetUserBalance(ctx, req.(*GetUserBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionManager_GetBalanceAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceAtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionManagerServer).GetBalanceAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransactionManager_GetBalanceAt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionManagerServer).GetBalanceAt(ctx, req.(*GetBalanceAtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionManager_GetBalanceHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionManagerServer).GetBalanceHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransactionManager_GetBalanceHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionManagerServer).GetBalanceHistory(ctx, req.(*GetBalanceHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionManager_ListPostings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPostingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionManagerServer).ListPostings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransactionManager_ListPostings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionManagerServer).ListPostings(ctx, req.(*ListPostingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TransactionManager_ServiceDesc is the grpc.ServiceDesc for TransactionManager service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,6 +391,34 @@ var TransactionManager_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTransactionByFilters",
 			Handler:    _TransactionManager_GetTransactionByFilters_Handler,
 		},
+		{
+			MethodName: "BatchGetTransactions",
+			Handler:    _TransactionManager_BatchGetTransactions_Handler,
+		},
+		{
+			MethodName: "GetRound",
+			Handler:    _TransactionManager_GetRound_Handler,
+		},
+		{
+			MethodName: "ListOpenRounds",
+			Handler:    _TransactionManager_ListOpenRounds_Handler,
+		},
+		{
+			MethodName: "GetUserBalance",
+			Handler:    _TransactionManager_GetUserBalance_Handler,
+		},
+		{
+			MethodName: "GetBalanceAt",
+			Handler:    _TransactionManager_GetBalanceAt_Handler,
+		},
+		{
+			MethodName: "GetBalanceHistory",
+			Handler:    _TransactionManager_GetBalanceHistory_Handler,
+		},
+		{
+			MethodName: "ListPostings",
+			Handler:    _TransactionManager_ListPostings_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "tx-manager.proto",
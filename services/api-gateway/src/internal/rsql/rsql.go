@@ -0,0 +1,375 @@
+package rsql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/e1esm/casino-transaction-system/api-gateway/src/internal/entities"
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxComparisons bounds the size of an expression.
+	MaxComparisons = 32
+	// MaxValues bounds the list of an =in= or =out= comparison.
+	MaxValues = 100
+	// MaxDepth bounds the nesting of parentheses.
+	MaxDepth = 8
+
+	dateLayout = "2006-01-02"
+	reserved   = "\"'();,=!~<> "
+)
+
+type fieldKind int
+
+const (
+	textField fieldKind = iota
+	idField
+	typeField
+	amountField
+	dateField
+)
+
+var fields = map[entities.FilterField]fieldKind{
+	entities.FilterID:        idField,
+	entities.FilterUserID:    idField,
+	entities.FilterType:      typeField,
+	entities.FilterAmount:    amountField,
+	entities.FilterCurrency:  textField,
+	entities.FilterDate:      dateField,
+	entities.FilterRoundID:   textField,
+	entities.FilterGameID:    textField,
+	entities.FilterProvider:  textField,
+	entities.FilterSessionID: textField,
+	entities.FilterChannel:   textField,
+}
+
+// operators maps both notations of an operator, e.g. =gt= and >.
+var operators = map[string]entities.ComparisonOp{
+	"==":    entities.OpEqual,
+	"!=":    entities.OpNotEqual,
+	"<":     entities.OpLess,
+	"=lt=":  entities.OpLess,
+	"<=":    entities.OpLessOrEqual,
+	"=le=":  entities.OpLessOrEqual,
+	">":     entities.OpGreater,
+	"=gt=":  entities.OpGreater,
+	">=":    entities.OpGreaterOrEqual,
+	"=ge=":  entities.OpGreaterOrEqual,
+	"=in=":  entities.OpIn,
+	"=out=": entities.OpNotIn,
+}
+
+type parser struct {
+	input       string
+	pos         int
+	depth       int
+	comparisons int
+}
+
+// Parse reads an RSQL expression: comparisons like amount=gt=1000 or type=in=(bet,win),
+// joined by ; for and or , for or, where and binds tighter and parentheses group.
+// Values containing reserved characters are quoted with ' or ".
+// Dates are 2006-01-02 or RFC3339 timestamps, amounts are in minor units.
+func Parse(input string) (*entities.FilterExpr, error) {
+	p := &parser{input: input}
+
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpaces()
+	if p.pos < len(p.input) {
+		return nil, p.errorf("unexpected %q", p.input[p.pos])
+	}
+
+	return &expr, nil
+}
+
+func (p *parser) parseOr() (entities.FilterExpr, error) {
+	var operands []entities.FilterExpr
+
+	for {
+		expr, err := p.parseAnd()
+		if err != nil {
+			return entities.FilterExpr{}, err
+		}
+
+		operands = append(operands, expr)
+
+		if !p.consume(',') {
+			break
+		}
+	}
+
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+
+	return entities.FilterExpr{Or: operands}, nil
+}
+
+func (p *parser) parseAnd() (entities.FilterExpr, error) {
+	var operands []entities.FilterExpr
+
+	for {
+		expr, err := p.parseConstraint()
+		if err != nil {
+			return entities.FilterExpr{}, err
+		}
+
+		operands = append(operands, expr)
+
+		if !p.consume(';') {
+			break
+		}
+	}
+
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+
+	return entities.FilterExpr{And: operands}, nil
+}
+
+func (p *parser) parseConstraint() (entities.FilterExpr, error) {
+	if !p.consume('(') {
+		return p.parseComparison()
+	}
+
+	if p.depth++; p.depth > MaxDepth {
+		return entities.FilterExpr{}, p.errorf("expression nested deeper than %d", MaxDepth)
+	}
+
+	expr, err := p.parseOr()
+	if err != nil {
+		return entities.FilterExpr{}, err
+	}
+
+	if !p.consume(')') {
+		return entities.FilterExpr{}, p.errorf("missing )")
+	}
+	p.depth--
+
+	return expr, nil
+}
+
+func (p *parser) parseComparison() (entities.FilterExpr, error) {
+	if p.comparisons++; p.comparisons > MaxComparisons {
+		return entities.FilterExpr{}, p.errorf("more than %d comparisons", MaxComparisons)
+	}
+
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.input) && (p.input[p.pos] == '_' || p.input[p.pos] >= 'a' && p.input[p.pos] <= 'z') {
+		p.pos++
+	}
+
+	field := entities.FilterField(p.input[start:p.pos])
+	if len(field) == 0 {
+		return entities.FilterExpr{}, p.errorf("missing field")
+	}
+
+	kind, ok := fields[field]
+	if !ok {
+		return entities.FilterExpr{}, fmt.Errorf("unknown field %q", field)
+	}
+
+	op, err := p.parseOperator()
+	if err != nil {
+		return entities.FilterExpr{}, err
+	}
+
+	if err = checkOperator(field, kind, op); err != nil {
+		return entities.FilterExpr{}, err
+	}
+
+	raw, err := p.parseArguments(op)
+	if err != nil {
+		return entities.FilterExpr{}, err
+	}
+
+	values := make([]any, 0, len(raw))
+	for _, r := range raw {
+		v, err := convertValue(field, kind, r)
+		if err != nil {
+			return entities.FilterExpr{}, err
+		}
+
+		values = append(values, v)
+	}
+
+	return entities.FilterExpr{Comparison: &entities.Comparison{Field: field, Op: op, Values: values}}, nil
+}
+
+func (p *parser) parseOperator() (entities.ComparisonOp, error) {
+	p.skipSpaces()
+	rest := p.input[p.pos:]
+
+	var token string
+	switch {
+	case strings.HasPrefix(rest, "=="), strings.HasPrefix(rest, "!="),
+		strings.HasPrefix(rest, "<="), strings.HasPrefix(rest, ">="):
+		token = rest[:2]
+	case strings.HasPrefix(rest, "<"), strings.HasPrefix(rest, ">"):
+		token = rest[:1]
+	case strings.HasPrefix(rest, "="):
+		if end := strings.IndexByte(rest[1:], '='); end >= 0 {
+			token = rest[:end+2]
+		}
+	}
+
+	op, ok := operators[token]
+	if !ok {
+		return "", p.errorf("unknown operator")
+	}
+
+	p.pos += len(token)
+
+	return op, nil
+}
+
+func (p *parser) parseArguments(op entities.ComparisonOp) ([]string, error) {
+	if op != entities.OpIn && op != entities.OpNotIn {
+		v, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+
+		return []string{v}, nil
+	}
+
+	if !p.consume('(') {
+		return nil, p.errorf("%s takes a list like (a,b)", op)
+	}
+
+	var values []string
+	for {
+		v, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+
+		if values = append(values, v); len(values) > MaxValues {
+			return nil, p.errorf("more than %d values", MaxValues)
+		}
+
+		if !p.consume(',') {
+			break
+		}
+	}
+
+	if !p.consume(')') {
+		return nil, p.errorf("missing )")
+	}
+
+	return values, nil
+}
+
+func (p *parser) parseValue() (string, error) {
+	p.skipSpaces()
+	if p.pos >= len(p.input) {
+		return "", p.errorf("missing value")
+	}
+
+	if quote := p.input[p.pos]; quote == '\'' || quote == '"' {
+		var b strings.Builder
+		for p.pos++; p.pos < len(p.input); p.pos++ {
+			c := p.input[p.pos]
+			if c == '\\' && p.pos+1 < len(p.input) {
+				p.pos++
+				b.WriteByte(p.input[p.pos])
+				continue
+			}
+
+			if c == quote {
+				p.pos++
+				return b.String(), nil
+			}
+
+			b.WriteByte(c)
+		}
+
+		return "", p.errorf("unterminated quote")
+	}
+
+	start := p.pos
+	for p.pos < len(p.input) && !strings.ContainsRune(reserved, rune(p.input[p.pos])) {
+		p.pos++
+	}
+
+	if p.pos == start {
+		return "", p.errorf("missing value")
+	}
+
+	return p.input[start:p.pos], nil
+}
+
+func (p *parser) consume(c byte) bool {
+	p.skipSpaces()
+	if p.pos < len(p.input) && p.input[p.pos] == c {
+		p.pos++
+		return true
+	}
+
+	return false
+}
+
+func (p *parser) skipSpaces() {
+	for p.pos < len(p.input) && p.input[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("at %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+func checkOperator(field entities.FilterField, kind fieldKind, op entities.ComparisonOp) error {
+	ordering := op == entities.OpLess || op == entities.OpLessOrEqual || op == entities.OpGreater || op == entities.OpGreaterOrEqual
+
+	switch {
+	case kind == dateField && !ordering:
+		return fmt.Errorf("%s can only be compared with <, <=, > and >=", field)
+	case kind != dateField && kind != amountField && ordering:
+		return fmt.Errorf("%s can't be compared with %s", field, op)
+	}
+
+	return nil
+}
+
+func convertValue(field entities.FilterField, kind fieldKind, raw string) (any, error) {
+	switch kind {
+	case idField:
+		if _, err := uuid.Parse(raw); err != nil {
+			return nil, fmt.Errorf("invalid %s %q", field, raw)
+		}
+	case typeField:
+		if !entities.TransactionType(raw).Valid() {
+			return nil, fmt.Errorf("unknown transaction type %q", raw)
+		}
+	case amountField:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid amount %q, amounts are integers in minor units", raw)
+		}
+
+		return n, nil
+	case dateField:
+		if t, err := time.Parse(dateLayout, raw); err == nil {
+			return t, nil
+		}
+
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q, dates are 2006-01-02 or RFC3339", raw)
+		}
+
+		return t, nil
+	}
+
+	return raw, nil
+}
@@ -0,0 +1,115 @@
+package rsql
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/e1esm/casino-transaction-system/api-gateway/src/internal/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func cmp(field entities.FilterField, op entities.ComparisonOp, values ...any) entities.FilterExpr {
+	return entities.FilterExpr{Comparison: &entities.Comparison{Field: field, Op: op, Values: values}}
+}
+
+func TestParse(t *testing.T) {
+	day := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := time.Date(2025, 1, 1, 22, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected entities.FilterExpr
+	}{
+		{
+			name:     "single comparison",
+			input:    "type==bet",
+			expected: cmp(entities.FilterType, entities.OpEqual, "bet"),
+		},
+		{
+			name:  "and of typed values",
+			input: "type==bet;amount>1000;date=ge=2025-01-01",
+			expected: entities.FilterExpr{And: []entities.FilterExpr{
+				cmp(entities.FilterType, entities.OpEqual, "bet"),
+				cmp(entities.FilterAmount, entities.OpGreater, int64(1000)),
+				cmp(entities.FilterDate, entities.OpGreaterOrEqual, day),
+			}},
+		},
+		{
+			name:  "and binds tighter than or",
+			input: "channel==web,amount=le=-5;date<2025-01-01T22:00:00Z",
+			expected: entities.FilterExpr{Or: []entities.FilterExpr{
+				cmp(entities.FilterChannel, entities.OpEqual, "web"),
+				{And: []entities.FilterExpr{
+					cmp(entities.FilterAmount, entities.OpLessOrEqual, int64(-5)),
+					cmp(entities.FilterDate, entities.OpLess, at),
+				}},
+			}},
+		},
+		{
+			name:  "parentheses and lists",
+			input: "(type=in=(win, jackpot_win) , amount=gt=50000) ; currency!=USD",
+			expected: entities.FilterExpr{And: []entities.FilterExpr{
+				{Or: []entities.FilterExpr{
+					cmp(entities.FilterType, entities.OpIn, "win", "jackpot_win"),
+					cmp(entities.FilterAmount, entities.OpGreater, int64(50000)),
+				}},
+				cmp(entities.FilterCurrency, entities.OpNotEqual, "USD"),
+			}},
+		},
+		{
+			name:     "quoted values",
+			input:    `game_id=out=('book of dead',"say \"hi\"")`,
+			expected: cmp(entities.FilterGameID, entities.OpNotIn, "book of dead", `say "hi"`),
+		},
+		{
+			name:     "ids are kept as text",
+			input:    "user_id==0b9f6a3c-3e0c-4a39-9c1a-0d8f3c1a9f11",
+			expected: cmp(entities.FilterUserID, entities.OpEqual, "0b9f6a3c-3e0c-4a39-9c1a-0d8f3c1a9f11"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := Parse(tt.input)
+			assert.NoError(t, err)
+			assert.Equal(t, &tt.expected, resp)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		errSubstr string
+	}{
+		{name: "empty", input: "", errSubstr: "missing field"},
+		{name: "unknown field", input: "t_hash==x", errSubstr: `unknown field "t_hash"`},
+		{name: "unknown operator", input: "amount=like=5", errSubstr: "unknown operator"},
+		{name: "missing operator", input: "amount", errSubstr: "unknown operator"},
+		{name: "missing value", input: "type==", errSubstr: "missing value"},
+		{name: "trailing separator", input: "type==bet;", errSubstr: "missing field"},
+		{name: "unbalanced parentheses", input: "(type==bet", errSubstr: "missing )"},
+		{name: "unexpected character", input: "type==bet)", errSubstr: "unexpected ')'"},
+		{name: "list without parentheses", input: "type=in=bet", errSubstr: "takes a list"},
+		{name: "unterminated quote", input: "game_id=='book", errSubstr: "unterminated quote"},
+		{name: "range on text", input: "channel>web", errSubstr: "channel can't be compared with >"},
+		{name: "equality on date", input: "date==2025-01-01", errSubstr: "date can only be compared"},
+		{name: "invalid date", input: "date>01.01.2025", errSubstr: "invalid date"},
+		{name: "decimal amount", input: "amount>10.5", errSubstr: "invalid amount"},
+		{name: "unknown type", input: "type==cashback", errSubstr: "unknown transaction type"},
+		{name: "invalid id", input: "id==42", errSubstr: "invalid id"},
+		{name: "too deep", input: strings.Repeat("(", MaxDepth+1) + "type==bet" + strings.Repeat(")", MaxDepth+1), errSubstr: "nested deeper"},
+		{name: "too many comparisons", input: strings.Repeat("amount>1;", MaxComparisons) + "amount>1", errSubstr: "comparisons"},
+		{name: "too many values", input: "channel=in=(" + strings.Repeat("a,", MaxValues) + "a)", errSubstr: "values"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.input)
+			assert.ErrorContains(t, err, tt.errSubstr)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/broker/kafka/replay"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/broker/types"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/config"
+)
+
+func main() {
+	from := flag.String("from", "", "replay entries that failed at or after this RFC3339 time")
+	to := flag.String("to", "", "replay entries that failed before this RFC3339 time")
+	categories := flag.String("category", "", "comma-separated error categories to replay (decode, validation, persistence)")
+	key := flag.String("key", "", "replay only entries with this record key")
+	mapping := flag.String("map", "", "comma-separated field renames applied to the payload, e.g. userId=user_id,type=transaction_type")
+	patch := flag.String("patch", "", "path to a JSON merge patch applied to the payload")
+	dryRun := flag.Bool("dry-run", false, "print what would be replayed without publishing anything")
+	idle := flag.Duration("idle-timeout", 10*time.Second, "stop once the DLQ yields no records for this long")
+	flag.Parse()
+
+	opts, err := parseOptions(*from, *to, *categories, *key, *mapping, *patch)
+	if err != nil {
+		log.Fatal(fmt.Sprintf("invalid arguments: %v", err))
+	}
+	opts.DryRun = *dryRun
+	opts.IdleTimeout = *idle
+
+	cfg, err := config.NewKafkaConfig()
+	if err != nil {
+		log.Fatal(fmt.Sprintf("failed to load config: %v", err))
+	}
+
+	cli, err := replay.NewWithConfig(*cfg, opts, os.Stdout)
+	if err != nil {
+		log.Fatal(fmt.Sprintf("failed to initialize replay client: %v", err))
+	}
+	defer cli.Close()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	stats, err := cli.Run(ctx)
+	if err != nil {
+		log.Printf("replay interrupted: %v", err)
+	}
+
+	log.Printf("read %d DLQ entries: %d matched, %d replayed, %d failed", stats.Read, stats.Matched, stats.Replayed, stats.Failed)
+}
+
+func parseOptions(from, to, categories, key, mapping, patchPath string) (replay.Options, error) {
+	var (
+		opts replay.Options
+		err  error
+	)
+
+	if from != "" {
+		if opts.Filter.From, err = time.Parse(time.RFC3339, from); err != nil {
+			return opts, fmt.Errorf("from: %w", err)
+		}
+	}
+
+	if to != "" {
+		if opts.Filter.To, err = time.Parse(time.RFC3339, to); err != nil {
+			return opts, fmt.Errorf("to: %w", err)
+		}
+	}
+
+	for _, c := range splitList(categories) {
+		opts.Filter.Categories = append(opts.Filter.Categories, types.ErrorCategory(c))
+	}
+	opts.Filter.Key = key
+
+	fields := make(map[string]string)
+	for _, pair := range splitList(mapping) {
+		src, dst, ok := strings.Cut(pair, "=")
+		if !ok || src == "" || dst == "" {
+			return opts, fmt.Errorf("map: expected old=new, got %q", pair)
+		}
+		fields[src] = dst
+	}
+
+	var patch []byte
+	if patchPath != "" {
+		if patch, err = os.ReadFile(patchPath); err != nil {
+			return opts, fmt.Errorf("patch: %w", err)
+		}
+	}
+
+	opts.Transform, err = replay.NewTransform(fields, patch)
+
+	return opts, err
+}
+
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
@@ -10,11 +10,16 @@ import (
 
 	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/broker/kafka/consumer"
 	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/broker/kafka/dlq"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/broker/kafka/rates"
 	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/config"
 	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/handlers"
 	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/handlers/interceptors"
 	proto "github.com/e1esm/casino-transaction-system/tx-manager/src/internal/proto/tx-manager"
 	txRepo "github.com/e1esm/casino-transaction-system/tx-manager/src/internal/repository/transaction"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/service/balance"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/service/fx"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/service/ledger"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/service/round"
 	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/service/transaction"
 
 	"github.com/go-playground/validator/v10"
@@ -27,14 +32,23 @@ func main() {
 	cfg := mustInitConfig()
 
 	repo := mustInitRepository(cfg)
-	txSvc := transaction.New(repo)
+	fxSvc := mustInitFX(cfg)
+	txSvc := transaction.New(repo, fxSvc, cfg.Transactions.MaxBatchSize)
+	roundSvc := round.New(repo, cfg.Rounds.OrphanTimeout)
+	balanceSvc := balance.New(repo, fxSvc, cfg.Balances.MaxHistoryPoints)
+	ledgerSvc := ledger.New(repo, fxSvc)
 	dlqProducer := mustInitDLQProducer(cfg)
 	broker := mustInitBroker(cfg, txSvc, dlqProducer)
-	h := handlers.New(txSvc)
+	h := handlers.New(txSvc, roundSvc, balanceSvc, ledgerSvc)
 	srv := newGrpcServer(h)
 
 	go serveGrpc(srv, cfg.Grpc)
 	go broker.Consume(ctx)
+	go balanceSvc.RunSnapshots(ctx, cfg.Balances.SnapshotInterval)
+
+	if len(cfg.FX.Topic) > 0 {
+		go mustInitRatesConsumer(cfg, fxSvc).Consume(ctx)
+	}
 
 	<-ctx.Done()
 	cancelFunc()
@@ -70,6 +84,31 @@ func mustInitDLQProducer(cfg *config.Config) *dlq.Client {
 	return cli
 }
 
+func mustInitFX(cfg *config.Config) *fx.Service {
+	svc := fx.New()
+	if len(cfg.FX.RatesFile) == 0 {
+		return svc
+	}
+
+	rates, err := fx.LoadFile(cfg.FX.RatesFile)
+	if err != nil {
+		log.Fatal(fmt.Sprintf("failed to load FX rates: %v", err))
+	}
+
+	svc.Set(rates...)
+
+	return svc
+}
+
+func mustInitRatesConsumer(cfg *config.Config, fxSvc *fx.Service) *rates.Client {
+	cli, err := rates.NewWithConfig(cfg.Kafka, cfg.FX.Topic, fxSvc)
+	if err != nil {
+		log.Fatal(fmt.Sprintf("failed to initialize FX rates consumer: %v", err))
+	}
+
+	return cli
+}
+
 func mustInitBroker(cfg *config.Config, txSvc *transaction.Service, dlqCli *dlq.Client) *consumer.Client {
 	cli, err := consumer.NewWithConfig(cfg.Kafka, txSvc, validator.New(), dlqCli)
 	if err != nil {
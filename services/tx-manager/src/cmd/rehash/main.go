@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/config"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/models"
+	txRepo "github.com/e1esm/casino-transaction-system/tx-manager/src/internal/repository/transaction"
+)
+
+func main() {
+	batchSize := flag.Int("batch-size", 1000, "number of transactions rehashed per query")
+	flag.Parse()
+
+	cfg, err := config.NewDatabaseConfig()
+	if err != nil {
+		log.Fatal(fmt.Sprintf("failed to load config: %v", err))
+	}
+
+	repo, err := txRepo.New(*cfg)
+	if err != nil {
+		log.Fatal(fmt.Sprintf("failed to initialize repository: %v", err))
+	}
+	defer repo.Close()
+
+	updated, skipped, err := repo.Rehash(context.Background(), *batchSize)
+	if err != nil {
+		log.Fatal(fmt.Sprintf("failed to rehash transactions: %v", err))
+	}
+
+	log.Printf("rehashed transactions to %s: %d updated, %d skipped as duplicates", models.HashVersion, updated, skipped)
+}
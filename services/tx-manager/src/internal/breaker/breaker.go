@@ -0,0 +1,84 @@
+package breaker
+
+import (
+	"sync"
+	"time"
+)
+
+type State int
+
+const (
+	Closed State = iota
+	Open
+	HalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+type Breaker struct {
+	mu sync.Mutex
+
+	state    State
+	openedAt time.Time
+	cooldown time.Duration
+
+	now func() time.Time
+}
+
+func New(cooldown time.Duration) *Breaker {
+	return &Breaker{
+		state:    Closed,
+		cooldown: cooldown,
+		now:      time.Now,
+	}
+}
+
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state
+}
+
+func (b *Breaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case Closed, HalfOpen:
+		return true
+	default:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return false
+		}
+
+		b.state = HalfOpen
+		return true
+	}
+}
+
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = Closed
+}
+
+func (b *Breaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = Open
+	b.openedAt = b.now()
+}
@@ -0,0 +1,72 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBreaker(t *testing.T) {
+	now := time.Date(2025, 1, 1, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		actions       func(b *Breaker)
+		elapsed       time.Duration
+		expectedAllow bool
+		expectedState State
+	}{
+		{
+			name:          "new breaker is closed",
+			actions:       func(b *Breaker) {},
+			expectedAllow: true,
+			expectedState: Closed,
+		},
+		{
+			name:          "failure opens breaker",
+			actions:       func(b *Breaker) { b.Failure() },
+			elapsed:       time.Second,
+			expectedAllow: false,
+			expectedState: Open,
+		},
+		{
+			name:          "breaker goes half-open after cooldown",
+			actions:       func(b *Breaker) { b.Failure() },
+			elapsed:       time.Minute,
+			expectedAllow: true,
+			expectedState: HalfOpen,
+		},
+		{
+			name: "success closes breaker",
+			actions: func(b *Breaker) {
+				b.Failure()
+				b.Success()
+			},
+			expectedAllow: true,
+			expectedState: Closed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := now
+
+			b := New(30 * time.Second)
+			b.now = func() time.Time { return current }
+
+			tt.actions(b)
+			current = current.Add(tt.elapsed)
+
+			assert.Equal(t, tt.expectedAllow, b.Allow())
+			assert.Equal(t, tt.expectedState, b.State())
+		})
+	}
+}
+
+func TestStateString(t *testing.T) {
+	assert.Equal(t, "closed", Closed.String())
+	assert.Equal(t, "open", Open.String())
+	assert.Equal(t, "half-open", HalfOpen.String())
+	assert.Equal(t, "unknown", State(42).String())
+}
@@ -0,0 +1,105 @@
+package avro
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const transactionSchema = `{
+	"type": "record",
+	"name": "TransactionEvent",
+	"namespace": "casino",
+	"fields": [
+		{"name": "event_id", "type": ["null", "string"]},
+		{"name": "user_id", "type": "string"},
+		{"name": "type", "type": {"type": "enum", "name": "Type", "symbols": ["bet", "win"]}},
+		{"name": "amount", "type": "long"},
+		{"name": "timestamp", "type": {"type": "long", "logicalType": "timestamp-millis"}},
+		{"name": "ratio", "type": "double"},
+		{"name": "previous", "type": ["null", "Type"]}
+	]
+}`
+
+func appendLong(b []byte, v int64) []byte {
+	return binary.AppendVarint(b, v)
+}
+
+func appendString(b []byte, s string) []byte {
+	return append(appendLong(b, int64(len(s))), s...)
+}
+
+func TestParse(t *testing.T) {
+	s, err := Parse(transactionSchema)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "record", s.Type)
+	assert.Len(t, s.Fields, 7)
+	assert.Equal(t, "timestamp-millis", s.Fields[4].Type.LogicalType)
+	assert.Same(t, s.Fields[2].Type, s.Fields[6].Type.Branches[1])
+
+	invalid := []string{
+		`42`,
+		`"string"`,
+		`{"type": "record", "name": "R", "fields": [{"name": "a", "type": "Unknown"}]}`,
+		`{"type": "record", "name": "R", "fields": [{"name": "a", "type": {"type": "array", "items": "string"}}]}`,
+		`{"type": "record", "name": "R", "fields": [{"name": "a", "type": {"type": "map", "values": "int"}}]}`,
+		`{"type": "record", "name": "R", "fields": [{"name": "a", "type": {"type": "fixed", "name": "F", "size": 2}}]}`,
+		`{"type": "record", "name": "R", "fields": [{"name": "a", "type": {"type": "record", "name": "S", "fields": []}}]}`,
+		`{"type": "record", "name": "R", "fields": [{"name": "a", "type": ["null", ["null", "string"]]}]}`,
+	}
+
+	for _, definition := range invalid {
+		_, err = Parse(definition)
+		assert.Error(t, err, definition)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	s, err := Parse(transactionSchema)
+	assert.NoError(t, err)
+
+	var data []byte
+	data = appendLong(data, 1)
+	data = appendString(data, "evt-1")
+	data = appendString(data, "461805a5-d762-441b-91ac-961629f926e7")
+	data = appendLong(data, 1)
+	data = appendLong(data, -100)
+	data = appendLong(data, 1735743600000)
+	data = binary.LittleEndian.AppendUint64(data, math.Float64bits(0.5))
+	data = appendLong(data, 0)
+
+	got, err := s.Decode(data)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"event_id":  "evt-1",
+		"user_id":   "461805a5-d762-441b-91ac-961629f926e7",
+		"type":      "win",
+		"amount":    int64(-100),
+		"timestamp": int64(1735743600000),
+		"ratio":     0.5,
+		"previous":  nil,
+	}, got)
+
+	_, err = s.Decode(data[:len(data)-3])
+	assert.Error(t, err)
+
+	_, err = s.Decode(append(data, 0))
+	assert.Error(t, err)
+}
+
+func TestDecodeInvalidIndex(t *testing.T) {
+	s, err := Parse(`{"type": "record", "name": "R", "fields": [{"name": "a", "type": ["null", "string"]}]}`)
+	assert.NoError(t, err)
+
+	_, err = s.Decode(appendLong(nil, 2))
+	assert.Error(t, err)
+
+	e, err := Parse(`{"type": "record", "name": "R", "fields": [{"name": "a", "type": {"type": "enum", "name": "E", "symbols": ["a"]}}]}`)
+	assert.NoError(t, err)
+
+	_, err = e.Decode(appendLong(nil, 1))
+	assert.Error(t, err)
+}
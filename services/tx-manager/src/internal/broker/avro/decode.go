@@ -0,0 +1,142 @@
+package avro
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+var errShortBuffer = errors.New("unexpected end of avro data")
+
+// Decode reads a single datum written with the schema. Records become
+// map[string]any, enums their symbol, ints and longs int64.
+func (s *Schema) Decode(data []byte) (any, error) {
+	d := &decoder{buf: data}
+
+	v, err := d.decode(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(d.buf) != 0 {
+		return nil, fmt.Errorf("%d trailing bytes after avro datum", len(d.buf))
+	}
+
+	return v, nil
+}
+
+type decoder struct {
+	buf []byte
+}
+
+func (d *decoder) decode(s *Schema) (any, error) {
+	switch s.Type {
+	case "null":
+		return nil, nil
+	case "boolean":
+		b, err := d.take(1)
+		if err != nil {
+			return nil, err
+		}
+
+		return b[0] != 0, nil
+	case "int", "long":
+		return d.long()
+	case "float":
+		b, err := d.take(4)
+		if err != nil {
+			return nil, err
+		}
+
+		return math.Float32frombits(binary.LittleEndian.Uint32(b)), nil
+	case "double":
+		b, err := d.take(8)
+		if err != nil {
+			return nil, err
+		}
+
+		return math.Float64frombits(binary.LittleEndian.Uint64(b)), nil
+	case "bytes":
+		return d.bytes()
+	case "string":
+		b, err := d.bytes()
+		if err != nil {
+			return nil, err
+		}
+
+		return string(b), nil
+	case "enum":
+		i, err := d.long()
+		if err != nil {
+			return nil, err
+		}
+
+		if i < 0 || int(i) >= len(s.Symbols) {
+			return nil, fmt.Errorf("enum index %d out of range", i)
+		}
+
+		return s.Symbols[i], nil
+	case "union":
+		i, err := d.long()
+		if err != nil {
+			return nil, err
+		}
+
+		if i < 0 || int(i) >= len(s.Branches) {
+			return nil, fmt.Errorf("union index %d out of range", i)
+		}
+
+		return d.decode(s.Branches[i])
+	case "record":
+		record := make(map[string]any, len(s.Fields))
+		for _, f := range s.Fields {
+			v, err := d.decode(f.Type)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", f.Name, err)
+			}
+
+			record[f.Name] = v
+		}
+
+		return record, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %q", s.Type)
+	}
+}
+
+func (d *decoder) long() (int64, error) {
+	// Avro longs are zig-zag varints, the same encoding binary.Varint reads.
+	v, n := binary.Varint(d.buf)
+	if n <= 0 {
+		return 0, errShortBuffer
+	}
+
+	d.buf = d.buf[n:]
+
+	return v, nil
+}
+
+func (d *decoder) bytes() ([]byte, error) {
+	n, err := d.long()
+	if err != nil {
+		return nil, err
+	}
+
+	if n < 0 {
+		return nil, fmt.Errorf("negative length %d", n)
+	}
+
+	return d.take(int(n))
+}
+
+func (d *decoder) take(n int) ([]byte, error) {
+	if n > len(d.buf) {
+		return nil, errShortBuffer
+	}
+
+	b := d.buf[:n]
+	d.buf = d.buf[n:]
+
+	return b, nil
+}
@@ -0,0 +1,160 @@
+package avro
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Schema is the subset of Avro that transaction events need: a flat record
+// whose fields are primitives, enums or unions of those. Nested records,
+// arrays, maps and fixed types are rejected when the schema is parsed.
+type Schema struct {
+	Type        string
+	Name        string
+	LogicalType string
+	Fields      []Field
+	Symbols     []string
+	Branches    []*Schema
+}
+
+type Field struct {
+	Name string
+	Type *Schema
+}
+
+type rawSchema struct {
+	Type        json.RawMessage `json:"type"`
+	Name        string          `json:"name"`
+	Namespace   string          `json:"namespace"`
+	LogicalType string          `json:"logicalType"`
+	Fields      []rawField      `json:"fields"`
+	Symbols     []string        `json:"symbols"`
+}
+
+type rawField struct {
+	Name string          `json:"name"`
+	Type json.RawMessage `json:"type"`
+}
+
+var primitives = map[string]struct{}{
+	"null":    {},
+	"boolean": {},
+	"int":     {},
+	"long":    {},
+	"float":   {},
+	"double":  {},
+	"bytes":   {},
+	"string":  {},
+}
+
+// Parse reads a writer schema in the Avro JSON notation. It must be a record,
+// enums may be referenced by name once they are defined.
+func Parse(definition string) (*Schema, error) {
+	var raw rawSchema
+	if err := json.Unmarshal([]byte(definition), &raw); err != nil {
+		return nil, err
+	}
+
+	var typ string
+	if err := json.Unmarshal(raw.Type, &typ); err != nil || typ != "record" {
+		return nil, fmt.Errorf("schema is %s, expected a record", raw.Type)
+	}
+
+	s := &Schema{Type: typ, Name: raw.Name}
+	named := make(map[string]*Schema)
+
+	for _, f := range raw.Fields {
+		ft, err := parse(f.Type, named)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", f.Name, err)
+		}
+
+		s.Fields = append(s.Fields, Field{Name: f.Name, Type: ft})
+	}
+
+	return s, nil
+}
+
+func parse(data json.RawMessage, named map[string]*Schema) (*Schema, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("empty schema")
+	}
+
+	switch data[0] {
+	case '"':
+		var name string
+		if err := json.Unmarshal(data, &name); err != nil {
+			return nil, err
+		}
+
+		if _, ok := primitives[name]; ok {
+			return &Schema{Type: name}, nil
+		}
+
+		if s, ok := named[name]; ok {
+			return s, nil
+		}
+
+		return nil, fmt.Errorf("unknown type %q", name)
+	case '[':
+		var branches []json.RawMessage
+		if err := json.Unmarshal(data, &branches); err != nil {
+			return nil, err
+		}
+
+		s := &Schema{Type: "union"}
+		for _, b := range branches {
+			branch, err := parse(b, named)
+			if err != nil {
+				return nil, err
+			}
+
+			if branch.Type == "union" {
+				return nil, fmt.Errorf("nested unions are not allowed")
+			}
+
+			s.Branches = append(s.Branches, branch)
+		}
+
+		return s, nil
+	case '{':
+		return parseComplex(data, named)
+	default:
+		return nil, fmt.Errorf("invalid schema %s", data)
+	}
+}
+
+func parseComplex(data json.RawMessage, named map[string]*Schema) (*Schema, error) {
+	var raw rawSchema
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	var typ string
+	if err := json.Unmarshal(raw.Type, &typ); err != nil {
+		// {"type": {...}} or {"type": [...]} wraps another schema.
+		return parse(raw.Type, named)
+	}
+
+	s := &Schema{Type: typ, Name: raw.Name, LogicalType: raw.LogicalType}
+
+	switch typ {
+	case "enum":
+		if raw.Name != "" {
+			named[raw.Name] = s
+			if raw.Namespace != "" {
+				named[raw.Namespace+"."+raw.Name] = s
+			}
+		}
+
+		s.Symbols = raw.Symbols
+	case "record", "error", "array", "map", "fixed":
+		return nil, fmt.Errorf("%s fields are not supported", typ)
+	default:
+		if _, ok := primitives[typ]; !ok {
+			return nil, fmt.Errorf("unknown type %q", typ)
+		}
+	}
+
+	return s, nil
+}
@@ -0,0 +1,267 @@
+package consumer
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"time"
+
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/broker/avro"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/broker/registry"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/broker/types"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/models"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/proto/events"
+
+	"github.com/google/uuid"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	contentTypeJSON     = "application/json"
+	contentTypeProtobuf = "application/x-protobuf"
+	contentTypeAvro     = "avro/binary"
+)
+
+var contentTypes = map[string]string{
+	"":                                   contentTypeJSON,
+	"application/json":                   contentTypeJSON,
+	"application/x-protobuf":             contentTypeProtobuf,
+	"application/protobuf":               contentTypeProtobuf,
+	"application/vnd.google.protobuf":    contentTypeProtobuf,
+	"avro/binary":                        contentTypeAvro,
+	"application/avro":                   contentTypeAvro,
+	"application/vnd.apache.avro+binary": contentTypeAvro,
+}
+
+func contentType(r *kgo.Record) (string, error) {
+	v, ok := header(r, types.HeaderContentType)
+	if !ok {
+		return contentTypeJSON, nil
+	}
+
+	media, _, err := mime.ParseMediaType(v)
+	if err != nil {
+		return "", fmt.Errorf("invalid content type %q: %w", v, err)
+	}
+
+	ct, ok := contentTypes[media]
+	if !ok {
+		return "", fmt.Errorf("unsupported content type %q", media)
+	}
+
+	return ct, nil
+}
+
+// resolve strips the schema registry header and checks that the referenced
+// schema exists and has the expected type.
+func (c *Client) resolve(value []byte, want registry.SchemaType) ([]byte, error) {
+	_, _, payload, err := c.lookup(value, want)
+
+	return payload, err
+}
+
+func (c *Client) lookup(value []byte, want registry.SchemaType) (int, registry.Schema, []byte, error) {
+	id, payload, err := registry.Unframe(value)
+	if err != nil {
+		return 0, registry.Schema{}, nil, err
+	}
+
+	if c.schemas == nil {
+		return 0, registry.Schema{}, nil, fmt.Errorf("payload references schema %d but no schema registry is configured", id)
+	}
+
+	schema, err := c.schemas.Schema(id)
+	if err != nil {
+		return 0, registry.Schema{}, nil, err
+	}
+
+	if schema.Type != want {
+		return 0, registry.Schema{}, nil, fmt.Errorf("schema %d is %s, expected %s", id, schema.Type, want)
+	}
+
+	return id, schema, payload, nil
+}
+
+func (c *Client) decodeProtobuf(value []byte) (models.Transaction, types.ErrorCategory, error) {
+	var ev events.TransactionEvent
+
+	if registry.IsFramed(value) {
+		payload, err := c.protoPayload(value, string(ev.ProtoReflect().Descriptor().FullName()))
+		if err != nil {
+			return models.Transaction{}, types.CategoryDecode, err
+		}
+
+		value = payload
+	}
+
+	if err := proto.Unmarshal(value, &ev); err != nil {
+		return models.Transaction{}, types.CategoryDecode, err
+	}
+
+	t, err := convertTransactionEvent(&ev)
+	if err != nil {
+		return models.Transaction{}, types.CategoryDecode, err
+	}
+
+	return validated(c.validator, t, convertTransactionV2ToModel)
+}
+
+func (c *Client) decodeAvro(value []byte) (models.Transaction, types.ErrorCategory, error) {
+	schema, payload, err := c.avroSchema(value)
+	if err != nil {
+		return models.Transaction{}, types.CategoryDecode, err
+	}
+
+	datum, err := schema.Decode(payload)
+	if err != nil {
+		return models.Transaction{}, types.CategoryDecode, err
+	}
+
+	record, ok := datum.(map[string]any)
+	if !ok {
+		return models.Transaction{}, types.CategoryDecode, fmt.Errorf("avro datum is %T, expected a record", datum)
+	}
+
+	t, err := convertAvroRecord(schema, record)
+	if err != nil {
+		return models.Transaction{}, types.CategoryDecode, err
+	}
+
+	return validated(c.validator, t, convertTransactionV2ToModel)
+}
+
+// protoPayload strips the message indexes of a framed payload. The registered
+// .proto schema isn't parsed, so only its first message is accepted and it is
+// decoded as the compiled want message.
+func (c *Client) protoPayload(value []byte, want string) ([]byte, error) {
+	id, _, payload, err := c.lookup(value, registry.Protobuf)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes, payload, err := registry.MessageIndexes(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(indexes) != 1 || indexes[0] != 0 {
+		return nil, fmt.Errorf("protobuf schema %d message %v is not supported, only the first message is read as %s", id, indexes, want)
+	}
+
+	return payload, nil
+}
+
+func (c *Client) avroSchema(value []byte) (*avro.Schema, []byte, error) {
+	id, def, payload, err := c.lookup(value, registry.Avro)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if cached, ok := c.avroSchemas.Load(id); ok {
+		return cached.(*avro.Schema), payload, nil
+	}
+
+	schema, err := avro.Parse(def.Definition)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid avro schema %d: %w", id, err)
+	}
+
+	c.avroSchemas.Store(id, schema)
+
+	return schema, payload, nil
+}
+
+func convertTransactionEvent(ev *events.TransactionEvent) (types.TransactionV2, error) {
+	t := types.TransactionV2{
+		EventID:     ev.GetEventId(),
+		Type:        ev.GetType(),
+		Amount:      types.Amount(ev.GetAmount()),
+		ReferenceID: ev.GetReferenceId(),
+		RoundID:     ev.GetRoundId(),
+		GameID:      ev.GetGameId(),
+		Provider:    ev.GetProvider(),
+		SessionID:   ev.GetSessionId(),
+		Channel:     ev.GetChannel(),
+		Currency:    ev.GetCurrency(),
+	}
+
+	if ev.GetUserId() != "" {
+		uid, err := uuid.Parse(ev.GetUserId())
+		if err != nil {
+			return types.TransactionV2{}, fmt.Errorf("invalid user id: %w", err)
+		}
+
+		t.UserID = uid
+	}
+
+	if ev.GetTimestamp() != 0 {
+		t.Timestamp = time.UnixMilli(ev.GetTimestamp()).UTC()
+	}
+
+	return t, nil
+}
+
+// convertAvroRecord maps the record onto the v2 JSON shape, so amounts and
+// timestamps are accepted in the same forms as in JSON events.
+func convertAvroRecord(schema *avro.Schema, record map[string]any) (types.TransactionV2, error) {
+	fields := map[string][]string{
+		"eventId":     {"event_id", "eventId"},
+		"userId":      {"user_id", "userId"},
+		"type":        {"type", "transaction_type"},
+		"amount":      {"amount"},
+		"timestamp":   {"timestamp", "transaction_date"},
+		"referenceId": {"reference_id", "referenceId"},
+		"roundId":     {"round_id", "roundId"},
+		"gameId":      {"game_id", "gameId"},
+		"provider":    {"provider"},
+		"sessionId":   {"session_id", "sessionId"},
+		"channel":     {"channel"},
+		"currency":    {"currency"},
+	}
+
+	doc := make(map[string]any, len(fields))
+	for target, names := range fields {
+		for _, name := range names {
+			if v, ok := record[name]; ok && v != nil {
+				doc[target] = v
+				break
+			}
+		}
+	}
+
+	if micros, ok := doc["timestamp"].(int64); ok && timestampMicros(schema) {
+		doc["timestamp"] = micros / int64(time.Millisecond/time.Microsecond)
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return types.TransactionV2{}, err
+	}
+
+	var t types.TransactionV2
+	if err := json.Unmarshal(data, &t); err != nil {
+		return types.TransactionV2{}, err
+	}
+
+	return t, nil
+}
+
+func timestampMicros(schema *avro.Schema) bool {
+	for _, f := range schema.Fields {
+		if f.Name != "timestamp" && f.Name != "transaction_date" {
+			continue
+		}
+
+		typ := f.Type
+		for _, b := range typ.Branches {
+			if b.Type != "null" {
+				typ = b
+			}
+		}
+
+		return typ.LogicalType == "timestamp-micros"
+	}
+
+	return false
+}
@@ -0,0 +1,214 @@
+package consumer
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/broker/kafka/consumer/mocks"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/broker/registry"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/broker/types"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/models"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/proto/events"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"google.golang.org/protobuf/proto"
+)
+
+const avroTransactionSchema = `{
+	"type": "record",
+	"name": "TransactionEvent",
+	"fields": [
+		{"name": "event_id", "type": ["null", "string"]},
+		{"name": "user_id", "type": "string"},
+		{"name": "type", "type": {"type": "enum", "name": "Type", "symbols": ["bet", "win"]}},
+		{"name": "amount", "type": "long"},
+		{"name": "timestamp", "type": {"type": "long", "logicalType": "timestamp-micros"}}
+	]
+}`
+
+func frame(id uint32, payload ...[]byte) []byte {
+	b := binary.BigEndian.AppendUint32([]byte{0}, id)
+	for _, p := range payload {
+		b = append(b, p...)
+	}
+
+	return b
+}
+
+func avroString(s string) []byte {
+	return append(binary.AppendVarint(nil, int64(len(s))), s...)
+}
+
+func TestContentType(t *testing.T) {
+	tests := []struct {
+		name     string
+		headers  []kgo.RecordHeader
+		expected string
+		isErr    bool
+	}{
+		{name: "no header defaults to JSON", expected: contentTypeJSON},
+		{name: "JSON with charset", headers: []kgo.RecordHeader{{Key: "content-type", Value: []byte("application/json; charset=utf-8")}}, expected: contentTypeJSON},
+		{name: "protobuf alias", headers: []kgo.RecordHeader{{Key: "Content-Type", Value: []byte("application/protobuf")}}, expected: contentTypeProtobuf},
+		{name: "avro", headers: []kgo.RecordHeader{{Key: "content-type", Value: []byte("application/avro")}}, expected: contentTypeAvro},
+		{name: "unsupported", headers: []kgo.RecordHeader{{Key: "content-type", Value: []byte("text/csv")}}, isErr: true},
+		{name: "malformed", headers: []kgo.RecordHeader{{Key: "content-type", Value: []byte("/;")}}, isErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := contentType(&kgo.Record{Headers: tt.headers})
+			if tt.isErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestClientDecodeBinaryRecord(t *testing.T) {
+	uid := uuid.MustParse("461805a5-d762-441b-91ac-961629f926e7")
+	ts := time.Date(2025, 1, 1, 15, 0, 0, 0, time.UTC)
+
+	event, err := proto.Marshal(&events.TransactionEvent{
+		EventId:   "e1",
+		UserId:    uid.String(),
+		Type:      "bet",
+		Amount:    100,
+		Timestamp: ts.UnixMilli(),
+	})
+	assert.NoError(t, err)
+
+	usdEvent, err := proto.Marshal(&events.TransactionEvent{
+		UserId:    uid.String(),
+		Type:      "bet",
+		Amount:    100,
+		Timestamp: ts.UnixMilli(),
+		Currency:  "USD",
+	})
+	assert.NoError(t, err)
+
+	invalidEvent, err := proto.Marshal(&events.TransactionEvent{UserId: uid.String(), Type: "bet", Timestamp: ts.UnixMilli()})
+	assert.NoError(t, err)
+
+	var avroEvent []byte
+	avroEvent = binary.AppendVarint(avroEvent, 1)
+	avroEvent = append(avroEvent, avroString("e1")...)
+	avroEvent = append(avroEvent, avroString(uid.String())...)
+	avroEvent = binary.AppendVarint(avroEvent, 1)
+	avroEvent = binary.AppendVarint(avroEvent, 100)
+	avroEvent = binary.AppendVarint(avroEvent, ts.UnixMicro())
+
+	schemas := mocks.NewMockSchemaRegistry(t)
+	schemas.On("Schema", 1).Return(registry.Schema{ID: 1, Type: registry.Protobuf, Definition: "package events; message TransactionEvent {} message Other {}"}, nil).Maybe()
+	schemas.On("Schema", 2).Return(registry.Schema{ID: 2, Type: registry.Avro, Definition: avroTransactionSchema}, nil).Maybe()
+	schemas.On("Schema", 3).Return(registry.Schema{ID: 3, Type: registry.JSON}, nil).Maybe()
+	schemas.On("Schema", 4).Return(registry.Schema{}, errors.New("schema 4: not found")).Maybe()
+
+	protobuf := []kgo.RecordHeader{{Key: "content-type", Value: []byte("application/x-protobuf")}}
+	avro := []kgo.RecordHeader{{Key: "content-type", Value: []byte("avro/binary")}}
+
+	tests := []struct {
+		name             string
+		registry         SchemaRegistry
+		record           *kgo.Record
+		expected         models.Transaction
+		expectedCategory types.ErrorCategory
+	}{
+		{
+			name:     "plain protobuf",
+			record:   &kgo.Record{Headers: protobuf, Value: event},
+			expected: models.Transaction{EventID: "e1", UserID: uid, Type: models.Bet, Amount: 100, Currency: "EUR", TransactionTime: ts},
+		},
+		{
+			name:     "framed protobuf",
+			registry: schemas,
+			record:   &kgo.Record{Headers: protobuf, Value: frame(1, []byte{0}, event)},
+			expected: models.Transaction{EventID: "e1", UserID: uid, Type: models.Bet, Amount: 100, Currency: "EUR", TransactionTime: ts},
+		},
+		{
+			name:     "protobuf with currency",
+			record:   &kgo.Record{Headers: protobuf, Value: usdEvent},
+			expected: models.Transaction{UserID: uid, Type: models.Bet, Amount: 100, Currency: "USD", TransactionTime: ts},
+		},
+		{
+			name:             "framed protobuf of another message",
+			registry:         schemas,
+			record:           &kgo.Record{Headers: protobuf, Value: frame(1, []byte{2, 2}, event)},
+			expectedCategory: types.CategoryDecode,
+		},
+		{
+			name:             "framed protobuf with an unknown message index",
+			registry:         schemas,
+			record:           &kgo.Record{Headers: protobuf, Value: frame(1, []byte{2, 4}, event)},
+			expectedCategory: types.CategoryDecode,
+		},
+		{
+			name:             "framed protobuf without a registry",
+			record:           &kgo.Record{Headers: protobuf, Value: frame(1, []byte{0}, event)},
+			expectedCategory: types.CategoryDecode,
+		},
+		{
+			name:             "protobuf referencing an avro schema",
+			registry:         schemas,
+			record:           &kgo.Record{Headers: protobuf, Value: frame(2, []byte{0}, event)},
+			expectedCategory: types.CategoryDecode,
+		},
+		{
+			name:             "protobuf failing validation",
+			record:           &kgo.Record{Headers: protobuf, Value: invalidEvent},
+			expectedCategory: types.CategoryValidation,
+		},
+		{
+			name:     "framed avro with micros timestamp",
+			registry: schemas,
+			record:   &kgo.Record{Headers: avro, Value: frame(2, avroEvent)},
+			expected: models.Transaction{EventID: "e1", UserID: uid, Type: models.Win, Amount: 100, Currency: "EUR", TransactionTime: ts},
+		},
+		{
+			name:             "unframed avro",
+			registry:         schemas,
+			record:           &kgo.Record{Headers: avro, Value: avroEvent},
+			expectedCategory: types.CategoryDecode,
+		},
+		{
+			name:             "avro with unknown schema",
+			registry:         schemas,
+			record:           &kgo.Record{Headers: avro, Value: frame(4, avroEvent)},
+			expectedCategory: types.CategoryDecode,
+		},
+		{
+			name:     "framed JSON",
+			registry: schemas,
+			record:   &kgo.Record{Value: frame(3, []byte(`{"userId":"461805a5-d762-441b-91ac-961629f926e7","type":"win","amount":"100","timestamp":"2025-01-01T15:00:00Z"}`))},
+			expected: models.Transaction{UserID: uid, Type: models.Win, Amount: 100, Currency: "EUR", TransactionTime: ts},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{validator: validator.New(), schemas: tt.registry}
+
+			got, category, err := c.decodeRecord(tt.record)
+
+			assert.Equal(t, tt.expectedCategory, category)
+			if tt.expectedCategory != "" {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, tt.expected.TransactionTime.Equal(got.TransactionTime))
+
+			got.TransactionTime = tt.expected.TransactionTime
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
@@ -2,17 +2,22 @@ package consumer
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"math/rand"
+	"strconv"
+	"sync"
 	"time"
 
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/breaker"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/broker/registry"
 	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/broker/types"
 	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/config"
 	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/models"
 	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/svcerr"
 
+	"github.com/go-playground/validator/v10"
 	"github.com/twmb/franz-go/pkg/kgo"
 )
 
@@ -21,11 +26,22 @@ type Validator interface {
 }
 
 type SaverService interface {
-	Create(ctx context.Context, transactions ...models.Transaction) error
+	Create(ctx context.Context, offsets []models.Offset, transactions ...models.Transaction) error
+	Offsets(ctx context.Context, group string) ([]models.Offset, error)
+	Ping(ctx context.Context) error
+}
+
+type SchemaRegistry interface {
+	Schema(id int) (registry.Schema, error)
 }
 
 type DLQProducer interface {
-	Produce(ctx context.Context, entries []types.FailedEntry)
+	Produce(ctx context.Context, entries []types.FailedEntry) error
+}
+
+type topicPartition struct {
+	topic     string
+	partition int32
 }
 
 type Client struct {
@@ -33,20 +49,57 @@ type Client struct {
 	validator   Validator
 	txSaver     SaverService
 	dlqProducer DLQProducer
+	breaker     *breaker.Breaker
+	schemas     SchemaRegistry
+	avroSchemas sync.Map
 
+	mu      sync.Mutex
+	workers map[topicPartition]*worker
+	delays  map[string]time.Duration
+
+	group                string
 	maxRecordsPoll       int
 	maxRetrySaveAttempts int
+	probeInterval        time.Duration
 }
 
-func NewWithClient(cli *kgo.Client, txSaver SaverService, validator Validator, producer DLQProducer, maxPolled, maxRetries int) *Client {
-	return &Client{
-		client:               cli,
+func NewWithOpts(opts []kgo.Opt, txSaver SaverService, validator Validator, producer DLQProducer, b *breaker.Breaker, schemas SchemaRegistry, tiers []types.RetryTier, group string, maxPolled, maxRetries int) (*Client, error) {
+	delays := make(map[string]time.Duration, len(tiers))
+	for _, tier := range tiers {
+		delays[tier.Topic] = tier.Delay
+	}
+
+	c := &Client{
 		validator:            validator,
 		txSaver:              txSaver,
 		dlqProducer:          producer,
+		breaker:              b,
+		schemas:              schemas,
+		workers:              make(map[topicPartition]*worker),
+		delays:               delays,
+		group:                group,
 		maxRecordsPoll:       maxPolled,
 		maxRetrySaveAttempts: maxRetries,
+		probeInterval:        time.Second,
+	}
+
+	cli, err := kgo.NewClient(append(opts,
+		kgo.ConsumerGroup(group),
+		kgo.DisableAutoCommit(),
+		kgo.BlockRebalanceOnPoll(),
+		kgo.AdjustFetchOffsetsFn(adjustOffsetsFn(txSaver, group)),
+		kgo.OnPartitionsAssigned(c.assigned),
+		kgo.OnPartitionsRevoked(c.revoked),
+		kgo.OnPartitionsLost(c.revoked),
+	)...)
+
+	if err != nil {
+		return nil, err
 	}
+
+	c.client = cli
+
+	return c, nil
 }
 
 func NewWithConfig(cfg config.KafkaConfig, txSaver SaverService, validator Validator, producer DLQProducer) (*Client, error) {
@@ -54,90 +107,168 @@ func NewWithConfig(cfg config.KafkaConfig, txSaver SaverService, validator Valid
 		return nil, err
 	}
 
-	cli, err := kgo.NewClient(
-		kgo.SeedBrokers(fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)),
-		kgo.ConsumerGroup(cfg.ConsumerConfig.ConsumerGroup),
-		kgo.ConsumeTopics(cfg.ConsumerConfig.Topic),
-		kgo.DisableAutoCommit(),
-	)
+	var schemas SchemaRegistry
+	if len(cfg.SchemaRegistry.Dir) > 0 {
+		f, err := registry.NewFile(cfg.SchemaRegistry.Dir)
+		if err != nil {
+			return nil, err
+		}
 
-	if err != nil {
-		return nil, err
+		schemas = f
+	}
+
+	tiers := types.RetryTiers(cfg.ConsumerConfig.Topic, cfg.ProducerConfig.RetryDelays)
+
+	topics := []string{cfg.ConsumerConfig.Topic}
+	for _, tier := range tiers {
+		topics = append(topics, tier.Topic)
 	}
 
-	return NewWithClient(
-		cli,
+	return NewWithOpts(
+		[]kgo.Opt{
+			kgo.SeedBrokers(fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)),
+			kgo.ConsumeTopics(topics...),
+		},
 		txSaver,
 		validator,
 		producer,
+		breaker.New(cfg.ConsumerConfig.BreakerCooldown),
+		schemas,
+		tiers,
+		cfg.ConsumerConfig.ConsumerGroup,
 		cfg.ConsumerConfig.MaxFetchedRecords,
 		cfg.ConsumerConfig.MaxRetries,
-	), nil
+	)
 }
 
 func (c *Client) Consume(ctx context.Context) error {
 	for {
-		select {
-		case <-ctx.Done():
+		fetches := c.client.PollRecords(ctx, c.maxRecordsPoll)
+		if fetches.IsClientClosed() || ctx.Err() != nil {
+			c.client.AllowRebalance()
 			c.client.Close()
 			return nil
-		default:
-			failedEntries, err := c.consume(ctx)
-			if err != nil {
-				log.Println("consume failed: ", err)
-				continue
+		}
+
+		fetches.EachError(func(topic string, partition int32, err error) {
+			log.Printf("fetch failed for %s[%d]: %v", topic, partition, err)
+		})
+
+		fetches.EachPartition(func(p kgo.FetchTopicPartition) {
+			w := c.worker(p.Topic, p.Partition)
+			if w == nil || len(p.Records) == 0 {
+				return
 			}
 
-			if len(failedEntries) > 0 {
-				c.dlqProducer.Produce(ctx, failedEntries)
+			w.enqueue(p.Records)
+		})
+
+		c.client.AllowRebalance()
+	}
+}
+
+func (c *Client) assigned(ctx context.Context, _ *kgo.Client, assigned map[string][]int32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for topic, partitions := range assigned {
+		for _, partition := range partitions {
+			w := newWorker(c, topic, partition)
+			c.workers[topicPartition{topic: topic, partition: partition}] = w
+
+			go w.run(ctx)
+		}
+	}
+}
+
+func (c *Client) revoked(_ context.Context, _ *kgo.Client, revoked map[string][]int32) {
+	c.mu.Lock()
+	stopped := make([]*worker, 0)
+	for topic, partitions := range revoked {
+		for _, partition := range partitions {
+			tp := topicPartition{topic: topic, partition: partition}
+			if w, ok := c.workers[tp]; ok {
+				stopped = append(stopped, w)
+				delete(c.workers, tp)
 			}
 		}
 	}
+	c.mu.Unlock()
+
+	for _, w := range stopped {
+		w.stop()
+	}
 }
 
-func (c *Client) consume(ctx context.Context) ([]types.FailedEntry, error) {
-	fetches := c.client.PollRecords(ctx, c.maxRecordsPoll)
+func (c *Client) worker(topic string, partition int32) *worker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-	failedEntries := make([]types.FailedEntry, 0)
-	transactions := make([]models.Transaction, 0, len(fetches))
+	return c.workers[topicPartition{topic: topic, partition: partition}]
+}
 
-	fetches.EachRecord(func(r *kgo.Record) {
-		var t types.Transaction
+func (c *Client) decode(records []*kgo.Record) ([]models.Transaction, []*kgo.Record, []types.FailedEntry) {
+	failedEntries := make([]types.FailedEntry, 0)
+	transactions := make([]models.Transaction, 0, len(records))
+	sources := make([]*kgo.Record, 0, len(records))
 
-		err := json.Unmarshal(r.Value, &t)
+	for _, r := range records {
+		t, category, err := c.decodeRecord(r)
 		if err != nil {
-			failedEntries = append(failedEntries, failedEntry(r, err))
-			return
+			failedEntries = append(failedEntries, failedEntry(r, category, err))
+			continue
 		}
 
-		if err = c.validator.Struct(&t); err != nil {
-			failedEntries = append(failedEntries, failedEntry(r, err))
-			return
-		}
+		transactions = append(transactions, t)
+		sources = append(sources, r)
+	}
 
-		transactions = append(transactions, convertTransactionToModel(t))
-	})
+	return transactions, sources, failedEntries
+}
 
-	if err := c.retry(func() error {
-		return c.txSaver.Create(ctx, transactions...)
-	}); err != nil {
-		log.Println("Failed to insert transaction in the database: ", err.Error())
+func (c *Client) publish(ctx context.Context, entries []types.FailedEntry) error {
+	if len(entries) == 0 {
+		return nil
 	}
 
-	if err := c.client.CommitRecords(ctx, fetches.Records()...); err != nil {
-		return nil, fmt.Errorf("faield to commit records: %w", err)
+	if err := c.dlqProducer.Produce(ctx, entries); err != nil {
+		return fmt.Errorf("failed to publish rejected records: %w", err)
 	}
 
-	return failedEntries, nil
+	return nil
 }
 
-func (c *Client) retry(f func() error) error {
+func (c *Client) ready(ctx context.Context) bool {
+	if !c.breaker.Allow() {
+		return false
+	}
+
+	if c.breaker.State() != breaker.HalfOpen {
+		return true
+	}
+
+	if err := c.txSaver.Ping(ctx); err != nil {
+		log.Println("repository is still unavailable: ", err)
+		c.breaker.Failure()
+
+		return false
+	}
+
+	c.breaker.Success()
+	log.Println("repository is available again, circuit breaker closed")
+
+	return true
+}
+
+// retry calls f until it succeeds, is rejected or runs out of attempts,
+// backing off in between. It gives up early once ctx is done.
+func (c *Client) retry(ctx context.Context, f func() error) error {
 	var err error
 
 	for i := range c.maxRetrySaveAttempts {
 		err = f()
-		if err == nil {
-			return nil
+		if err == nil || svcerr.IsRejected(err) {
+			return err
 		}
 
 		if i == c.maxRetrySaveAttempts-1 {
@@ -146,29 +277,115 @@ func (c *Client) retry(f func() error) error {
 
 		sleep := time.Second * time.Duration(1+i)
 		jitter := time.Duration(rand.Intn(500)) * time.Millisecond
-		time.Sleep(sleep + jitter)
+
+		timer := time.NewTimer(sleep + jitter)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w: %w", ctx.Err(), err)
+		case <-timer.C:
+		}
 	}
 
 	return err
 }
 
-func failedEntry(r *kgo.Record, err error) types.FailedEntry {
-	return types.FailedEntry{
-		Key:   string(r.Key),
-		Value: r.Value,
-		Err:   err,
+func nextOffsets(group string, records []*kgo.Record) []models.Offset {
+	latest := make(map[string]map[int32]int64)
+
+	for _, r := range records {
+		if _, ok := latest[r.Topic]; !ok {
+			latest[r.Topic] = make(map[int32]int64)
+		}
+
+		if offset, ok := latest[r.Topic][r.Partition]; !ok || r.Offset+1 > offset {
+			latest[r.Topic][r.Partition] = r.Offset + 1
+		}
+	}
+
+	offsets := make([]models.Offset, 0, len(latest))
+	for topic, partitions := range latest {
+		for partition, offset := range partitions {
+			offsets = append(offsets, models.Offset{
+				Group:     group,
+				Topic:     topic,
+				Partition: partition,
+				Offset:    offset,
+			})
+		}
 	}
+
+	return offsets
 }
 
-func convertTransactionToModel(tx types.Transaction) models.Transaction {
-	return models.Transaction{
-		UserID:          tx.UserID,
-		Type:            models.TransactionType(tx.TransactionType),
-		Amount:          tx.Amount,
-		TransactionTime: tx.TransactionDate,
+func adjustOffsetsFn(txSaver SaverService, group string) func(context.Context, map[string]map[int32]kgo.Offset) (map[string]map[int32]kgo.Offset, error) {
+	return func(ctx context.Context, assigned map[string]map[int32]kgo.Offset) (map[string]map[int32]kgo.Offset, error) {
+		stored, err := txSaver.Offsets(ctx, group)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load stored offsets: %w", err)
+		}
+
+		return applyStoredOffsets(assigned, stored), nil
 	}
 }
 
+func applyStoredOffsets(assigned map[string]map[int32]kgo.Offset, stored []models.Offset) map[string]map[int32]kgo.Offset {
+	for _, o := range stored {
+		partitions, ok := assigned[o.Topic]
+		if !ok {
+			continue
+		}
+
+		if _, ok := partitions[o.Partition]; !ok {
+			continue
+		}
+
+		partitions[o.Partition] = kgo.NewOffset().At(o.Offset).WithEpoch(-1)
+	}
+
+	return assigned
+}
+
+func failedEntry(r *kgo.Record, category types.ErrorCategory, err error) types.FailedEntry {
+	entry := types.FailedEntry{
+		Version:   types.FailedEntryVersion,
+		Key:       string(r.Key),
+		Value:     r.Value,
+		Topic:     r.Topic,
+		Partition: r.Partition,
+		Offset:    r.Offset,
+		Origin:    types.Origin{Topic: r.Topic, Partition: r.Partition, Offset: r.Offset},
+		Timestamp: r.Timestamp,
+		Category:  category,
+		FailedAt:  time.Now().UTC(),
+	}
+
+	for _, h := range r.Headers {
+		if !readOrigin(&entry.Origin, h) {
+			entry.Headers = append(entry.Headers, types.Header{Key: h.Key, Value: h.Value})
+		}
+	}
+
+	if err != nil {
+		entry.Reason = err.Error()
+	}
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		for _, fe := range validationErrs {
+			entry.FieldErrors = append(entry.FieldErrors, types.FieldError{
+				Field: fe.Namespace(),
+				Tag:   fe.Tag(),
+				Param: fe.Param(),
+				Value: fmt.Sprint(fe.Value()),
+			})
+		}
+	}
+
+	return entry
+}
+
 func validate(cfg config.KafkaConfig) error {
 	if cfg.ConsumerConfig.MaxRetries == 0 {
 		return fmt.Errorf("%w: max retries is zero", svcerr.ErrBadField)
@@ -188,3 +405,23 @@ func validate(cfg config.KafkaConfig) error {
 
 	return nil
 }
+
+// readOrigin takes the origin of a record that came through a retry tier from its header.
+func readOrigin(origin *types.Origin, h kgo.RecordHeader) bool {
+	switch h.Key {
+	case types.HeaderOriginTopic:
+		origin.Topic = string(h.Value)
+	case types.HeaderOriginPartition:
+		if p, err := strconv.ParseInt(string(h.Value), 10, 32); err == nil {
+			origin.Partition = int32(p)
+		}
+	case types.HeaderOriginOffset:
+		if o, err := strconv.ParseInt(string(h.Value), 10, 64); err == nil {
+			origin.Offset = o
+		}
+	default:
+		return false
+	}
+
+	return true
+}
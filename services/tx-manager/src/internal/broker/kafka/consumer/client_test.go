@@ -12,6 +12,7 @@ import (
 
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/go-connections/nat"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/breaker"
 	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/broker/kafka/consumer/mocks"
 	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/broker/types"
 	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/config"
@@ -150,7 +151,7 @@ func TestClientRetry(t *testing.T) {
 			}
 
 			attempts := 0
-			err := c.retry(func() error {
+			err := c.retry(context.Background(), func() error {
 				attempts++
 				if attempts <= tt.failTimes {
 					return errors.New("fail")
@@ -173,99 +174,213 @@ func TestClientRetry(t *testing.T) {
 	}
 }
 
+func TestClientRetryStopsOnCanceledContext(t *testing.T) {
+	c := &Client{
+		maxRetrySaveAttempts: 3,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	attempts := 0
+	err := c.retry(ctx, func() error {
+		attempts++
+		cancel()
+		return errors.New("fail")
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorContains(t, err, "fail")
+	assert.Equal(t, 1, attempts)
+}
+
 func TestFailedEntry(t *testing.T) {
+	now := time.Date(2025, 1, 1, 15, 0, 0, 0, time.UTC)
+
+	type event struct {
+		Amount int    `validate:"gt=0"`
+		Type   string `validate:"oneof=bet win"`
+	}
+
+	validationErr := validator.New().Struct(event{Amount: -1, Type: "bet"})
+
 	tests := []struct {
-		name   string
-		record *kgo.Record
-		err    error
-		want   types.FailedEntry
+		name     string
+		record   *kgo.Record
+		category types.ErrorCategory
+		err      error
+		want     types.FailedEntry
 	}{
 		{
-			name: "normal record",
+			name: "decode failure keeps record metadata",
 			record: &kgo.Record{
-				Key:   []byte("key1"),
-				Value: []byte("value1"),
+				Key:       []byte("key1"),
+				Value:     []byte("value1"),
+				Topic:     "casino_transactions",
+				Partition: 2,
+				Offset:    42,
+				Timestamp: now,
+				Headers:   []kgo.RecordHeader{{Key: "source", Value: []byte("provider-a")}, {Key: "trace", Value: []byte{0xff, 0x00}}},
 			},
-			err: errors.New("some error"),
+			category: types.CategoryDecode,
+			err:      errors.New("some error"),
 			want: types.FailedEntry{
-				Key:   "key1",
-				Value: []byte("value1"),
-				Err:   errors.New("some error"),
+				Version:   types.FailedEntryVersion,
+				Key:       "key1",
+				Value:     []byte("value1"),
+				Topic:     "casino_transactions",
+				Partition: 2,
+				Offset:    42,
+				Origin:    types.Origin{Topic: "casino_transactions", Partition: 2, Offset: 42},
+				Timestamp: now,
+				Headers:   []types.Header{{Key: "source", Value: []byte("provider-a")}, {Key: "trace", Value: []byte{0xff, 0x00}}},
+				Category:  types.CategoryDecode,
+				Reason:    "some error",
 			},
 		},
 		{
-			name: "nil error",
+			name: "record from a retry tier keeps its origin",
+			record: &kgo.Record{
+				Key:       []byte("key4"),
+				Value:     []byte("value4"),
+				Topic:     "casino_transactions-retry-5m",
+				Partition: 0,
+				Offset:    3,
+				Headers: []kgo.RecordHeader{
+					{Key: "source", Value: []byte("provider-a")},
+					{Key: types.HeaderOriginTopic, Value: []byte("casino_transactions")},
+					{Key: types.HeaderOriginPartition, Value: []byte("2")},
+					{Key: types.HeaderOriginOffset, Value: []byte("42")},
+				},
+			},
+			category: types.CategoryTransient,
+			err:      errors.New("some error"),
+			want: types.FailedEntry{
+				Version:  types.FailedEntryVersion,
+				Key:      "key4",
+				Value:    []byte("value4"),
+				Topic:    "casino_transactions-retry-5m",
+				Offset:   3,
+				Origin:   types.Origin{Topic: "casino_transactions", Partition: 2, Offset: 42},
+				Headers:  []types.Header{{Key: "source", Value: []byte("provider-a")}},
+				Category: types.CategoryTransient,
+				Reason:   "some error",
+			},
+		},
+		{
+			name: "validation failure carries field errors",
 			record: &kgo.Record{
 				Key:   []byte("key2"),
 				Value: []byte("value2"),
 			},
-			err: nil,
+			category: types.CategoryValidation,
+			err:      validationErr,
 			want: types.FailedEntry{
-				Key:   "key2",
-				Value: []byte("value2"),
-				Err:   nil,
+				Version:  types.FailedEntryVersion,
+				Key:      "key2",
+				Value:    []byte("value2"),
+				Category: types.CategoryValidation,
+				Reason:   validationErr.Error(),
+				FieldErrors: []types.FieldError{
+					{Field: "event.Amount", Tag: "gt", Param: "0", Value: "-1"},
+				},
+			},
+		},
+		{
+			name: "nil error",
+			record: &kgo.Record{
+				Key:   []byte("key3"),
+				Value: []byte("value3"),
+			},
+			category: types.CategoryPersistence,
+			err:      nil,
+			want: types.FailedEntry{
+				Version:  types.FailedEntryVersion,
+				Key:      "key3",
+				Value:    []byte("value3"),
+				Category: types.CategoryPersistence,
 			},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := &kgo.Record{
-				Key:   tt.record.Key,
-				Value: tt.record.Value,
-			}
-			got := failedEntry(r, tt.err)
+			got := failedEntry(tt.record, tt.category, tt.err)
 
-			assert.Equal(t, tt.want.Key, got.Key)
-			assert.Equal(t, tt.want.Value, got.Value)
-			assert.Equal(t, tt.want.Err, got.Err)
+			assert.False(t, got.FailedAt.IsZero())
+			got.FailedAt = time.Time{}
+
+			assert.Equal(t, tt.want, got)
 		})
 	}
 }
 
-func TestConvertTransactionToModel(t *testing.T) {
-	id := uuid.New()
-	now := time.Now()
+func TestFailedEntryJSON(t *testing.T) {
+	entry := failedEntry(&kgo.Record{Key: []byte("k"), Value: []byte("v")}, types.CategoryDecode, errors.New("unexpected end of JSON input"))
+
+	resp, err := json.Marshal(entry)
+	assert.NoError(t, err)
+
+	var got map[string]any
+	assert.NoError(t, json.Unmarshal(resp, &got))
+	assert.Equal(t, "unexpected end of JSON input", got["reason"])
+	assert.Equal(t, "decode", got["category"])
+	assert.Equal(t, float64(types.FailedEntryVersion), got["version"])
+}
+
+func TestNextOffsets(t *testing.T) {
+	records := []*kgo.Record{
+		{Topic: "t1", Partition: 0, Offset: 10},
+		{Topic: "t1", Partition: 0, Offset: 11},
+		{Topic: "t1", Partition: 1, Offset: 3},
+	}
+
+	got := nextOffsets("group", records)
+
+	assert.ElementsMatch(t, []models.Offset{
+		{Group: "group", Topic: "t1", Partition: 0, Offset: 12},
+		{Group: "group", Topic: "t1", Partition: 1, Offset: 4},
+	}, got)
+	assert.Empty(t, nextOffsets("group", nil))
+}
+
+func TestApplyStoredOffsets(t *testing.T) {
 	tests := []struct {
-		name string
-		tx   types.Transaction
-		want models.Transaction
+		name     string
+		assigned map[string]map[int32]kgo.Offset
+		stored   []models.Offset
+		want     map[string]map[int32]kgo.Offset
 	}{
 		{
-			name: "basic conversion",
-			tx: types.Transaction{
-				UserID:          id,
-				TransactionType: "bet",
-				Amount:          100,
-				TransactionDate: now,
+			name: "stored offset overrides assigned partition",
+			assigned: map[string]map[int32]kgo.Offset{
+				"t1": {0: kgo.NewOffset().At(5), 1: kgo.NewOffset().At(7)},
+			},
+			stored: []models.Offset{
+				{Group: "g", Topic: "t1", Partition: 0, Offset: 42},
 			},
-			want: models.Transaction{
-				UserID:          id,
-				Type:            models.TransactionType("bet"),
-				Amount:          100,
-				TransactionTime: now,
+			want: map[string]map[int32]kgo.Offset{
+				"t1": {0: kgo.NewOffset().At(42).WithEpoch(-1), 1: kgo.NewOffset().At(7)},
 			},
 		},
 		{
-			name: "zero values",
-			tx:   types.Transaction{},
-			want: models.Transaction{
-				UserID:          uuid.Nil,
-				Type:            models.TransactionType(""),
-				Amount:          0,
-				TransactionTime: time.Time{},
+			name: "offsets of partitions that weren't assigned are skipped",
+			assigned: map[string]map[int32]kgo.Offset{
+				"t1": {0: kgo.NewOffset().At(5)},
+			},
+			stored: []models.Offset{
+				{Group: "g", Topic: "t1", Partition: 2, Offset: 42},
+				{Group: "g", Topic: "t2", Partition: 0, Offset: 1},
+			},
+			want: map[string]map[int32]kgo.Offset{
+				"t1": {0: kgo.NewOffset().At(5)},
 			},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := convertTransactionToModel(tt.tx)
-
-			assert.Equal(t, tt.want.UserID, got.UserID)
-			assert.Equal(t, tt.want.Type, got.Type)
-			assert.Equal(t, tt.want.Amount, got.Amount)
-			assert.Equal(t, tt.want.TransactionTime, got.TransactionTime)
+			got := applyStoredOffsets(tt.assigned, tt.stored)
+			assert.Equal(t, tt.want, got)
 		})
 	}
 }
@@ -429,17 +544,18 @@ func TestClient_Consume_Integration(t *testing.T) {
 			v := mocks.NewMockValidator(t)
 			saver := mocks.NewMockSaverService(t)
 			dlq := mocks.NewMockDLQProducer(t)
-			kCli := newKafkaClient()
 
 			v.On("Struct", mock.Anything).Return(tt.validationErr)
-			saver.On("Create", mock.Anything, mock.Anything).Return(tt.saverErr)
+			saver.On("Offsets", mock.Anything, "test-gr").Return(nil, nil).Maybe()
+			saver.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(tt.saverErr)
 			if tt.expectedDLQ > 0 {
-				dlq.On("Produce", mock.Anything, mock.Anything)
+				dlq.On("Produce", mock.Anything, mock.Anything).Return(nil)
 			}
 
-			c := NewWithClient(kCli, saver, v, dlq, 10, 10)
+			c, err := NewWithOpts(kafkaOpts(), saver, v, dlq, breaker.New(time.Second), nil, nil, "test-gr", 10, 10)
+			assert.NoError(t, err)
 
-			produceMessages(t, kCli, testTopic, tt.messages...)
+			produceMessages(t, c.client, testTopic, tt.messages...)
 
 			go func() {
 				_ = c.Consume(ctx)
@@ -448,7 +564,7 @@ func TestClient_Consume_Integration(t *testing.T) {
 			time.Sleep(5 * time.Second)
 			cancel()
 
-			assert.Len(t, saver.Calls, tt.expectedTx)
+			assert.Len(t, createCalls(saver), tt.expectedTx)
 			assert.Len(t, dlq.Calls, tt.expectedDLQ)
 		})
 	}
@@ -535,6 +651,17 @@ func TestNewWithConfig(t *testing.T) {
 	}
 }
 
+func createCalls(saver *mocks.MockSaverService) []mock.Call {
+	calls := make([]mock.Call, 0)
+	for _, call := range saver.Calls {
+		if call.Method == "Create" {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
+
 func produceMessages(t *testing.T, client *kgo.Client, topic string, messages ...interface{}) {
 	records := make([]*kgo.Record, len(messages))
 	for i, msg := range messages {
@@ -549,17 +676,9 @@ func produceMessages(t *testing.T, client *kgo.Client, topic string, messages ..
 	assert.NoError(t, resp.FirstErr())
 }
 
-func newKafkaClient() *kgo.Client {
-	resp, err := kgo.NewClient(
+func kafkaOpts() []kgo.Opt {
+	return []kgo.Opt{
 		kgo.SeedBrokers(fmt.Sprintf("%s:%s", kafkaHost, kafkaPort)),
 		kgo.ConsumeTopics(testTopic),
-		kgo.ConsumerGroup("test-gr"),
-		kgo.DisableAutoCommit(),
-	)
-
-	if err != nil {
-		log.Fatal(err)
 	}
-
-	return resp
 }
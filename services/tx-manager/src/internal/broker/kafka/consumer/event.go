@@ -0,0 +1,172 @@
+package consumer
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/broker/registry"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/broker/types"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/models"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+type versionProbe struct {
+	SnakeVersion json.Number     `json:"schema_version"`
+	CamelVersion json.Number     `json:"schemaVersion"`
+	UserID       json.RawMessage `json:"userId"`
+}
+
+func (c *Client) decodeRecord(r *kgo.Record) (models.Transaction, types.ErrorCategory, error) {
+	ct, err := contentType(r)
+	if err != nil {
+		return models.Transaction{}, types.CategoryDecode, err
+	}
+
+	switch ct {
+	case contentTypeProtobuf:
+		return c.decodeProtobuf(r.Value)
+	case contentTypeAvro:
+		return c.decodeAvro(r.Value)
+	default:
+		return c.decodeJSON(r)
+	}
+}
+
+func (c *Client) decodeJSON(r *kgo.Record) (models.Transaction, types.ErrorCategory, error) {
+	value := r.Value
+	if registry.IsFramed(value) {
+		var err error
+		if value, err = c.resolve(value, registry.JSON); err != nil {
+			return models.Transaction{}, types.CategoryDecode, err
+		}
+	}
+
+	version, err := schemaVersion(r, value)
+	if err != nil {
+		return models.Transaction{}, types.CategoryDecode, err
+	}
+
+	switch version {
+	case types.SchemaV2:
+		return decodeAs(c.validator, value, convertTransactionV2ToModel)
+	default:
+		return decodeAs(c.validator, value, convertTransactionToModel)
+	}
+}
+
+func decodeAs[T any](v Validator, value []byte, convert func(T) models.Transaction) (models.Transaction, types.ErrorCategory, error) {
+	var t T
+
+	if err := json.Unmarshal(value, &t); err != nil {
+		return models.Transaction{}, types.CategoryDecode, err
+	}
+
+	return validated(v, t, convert)
+}
+
+func validated[T any](v Validator, t T, convert func(T) models.Transaction) (models.Transaction, types.ErrorCategory, error) {
+	if err := v.Struct(&t); err != nil {
+		return models.Transaction{}, types.CategoryValidation, err
+	}
+
+	return convert(t), "", nil
+}
+
+// schemaVersion takes the version from the record header, then from the
+// payload, and otherwise tells the shapes apart by the camelCase user id.
+func schemaVersion(r *kgo.Record, value []byte) (types.SchemaVersion, error) {
+	if v, ok := header(r, types.HeaderSchemaVersion); ok {
+		return parseSchemaVersion(v)
+	}
+
+	var probe versionProbe
+	if err := json.Unmarshal(value, &probe); err != nil {
+		return 0, err
+	}
+
+	switch {
+	case probe.SnakeVersion != "":
+		return parseSchemaVersion(probe.SnakeVersion.String())
+	case probe.CamelVersion != "":
+		return parseSchemaVersion(probe.CamelVersion.String())
+	case probe.UserID != nil:
+		return types.SchemaV2, nil
+	default:
+		return types.SchemaV1, nil
+	}
+}
+
+func header(r *kgo.Record, key string) (string, bool) {
+	for _, h := range r.Headers {
+		if strings.EqualFold(h.Key, key) {
+			return string(h.Value), true
+		}
+	}
+
+	return "", false
+}
+
+func parseSchemaVersion(s string) (types.SchemaVersion, error) {
+	v, err := strconv.Atoi(strings.TrimPrefix(strings.ToLower(s), "v"))
+	if err != nil {
+		return 0, fmt.Errorf("invalid schema version %q", s)
+	}
+
+	switch version := types.SchemaVersion(v); version {
+	case types.SchemaV1, types.SchemaV2:
+		return version, nil
+	default:
+		return 0, fmt.Errorf("unsupported schema version %d", v)
+	}
+}
+
+func convertTransactionToModel(tx types.Transaction) models.Transaction {
+	eventID := tx.EventID
+	if len(eventID) == 0 {
+		eventID = tx.TransactionID
+	}
+
+	return models.Transaction{
+		EventID:         eventID,
+		UserID:          tx.UserID,
+		Type:            models.TransactionType(tx.TransactionType),
+		Amount:          int64(tx.Amount),
+		Currency:        currency(tx.Currency),
+		TransactionTime: tx.TransactionDate,
+		ReferenceID:     tx.ReferenceID,
+		RoundID:         tx.RoundID,
+		GameID:          tx.GameID,
+		Provider:        tx.Provider,
+		SessionID:       tx.SessionID,
+		Channel:         tx.Channel,
+	}
+}
+
+func convertTransactionV2ToModel(tx types.TransactionV2) models.Transaction {
+	return models.Transaction{
+		EventID:         tx.EventID,
+		UserID:          tx.UserID,
+		Type:            models.TransactionType(tx.Type),
+		Amount:          int64(tx.Amount),
+		Currency:        currency(tx.Currency),
+		TransactionTime: tx.Timestamp,
+		ReferenceID:     tx.ReferenceID,
+		RoundID:         tx.RoundID,
+		GameID:          tx.GameID,
+		Provider:        tx.Provider,
+		SessionID:       tx.SessionID,
+		Channel:         tx.Channel,
+	}
+}
+
+// currency defaults events published before they carried a currency to the default one.
+func currency(code string) string {
+	if len(code) == 0 {
+		return models.DefaultCurrency
+	}
+
+	return code
+}
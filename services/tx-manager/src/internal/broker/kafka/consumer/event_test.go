@@ -0,0 +1,285 @@
+package consumer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/broker/types"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/models"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+func TestSchemaVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		record   *kgo.Record
+		expected types.SchemaVersion
+		isErr    bool
+	}{
+		{
+			name:     "header wins over payload",
+			record:   &kgo.Record{Headers: []kgo.RecordHeader{{Key: "schema-version", Value: []byte("2")}}, Value: []byte(`{"schema_version":1}`)},
+			expected: types.SchemaV2,
+		},
+		{
+			name:     "prefixed header",
+			record:   &kgo.Record{Headers: []kgo.RecordHeader{{Key: "schema-version", Value: []byte("v1")}}, Value: []byte(`{}`)},
+			expected: types.SchemaV1,
+		},
+		{
+			name:     "snake case field",
+			record:   &kgo.Record{Value: []byte(`{"schema_version":2,"user_id":"x"}`)},
+			expected: types.SchemaV2,
+		},
+		{
+			name:     "camel case field as string",
+			record:   &kgo.Record{Value: []byte(`{"schemaVersion":"1"}`)},
+			expected: types.SchemaV1,
+		},
+		{
+			name:     "documented shape without version",
+			record:   &kgo.Record{Value: []byte(`{"userId":"x","type":"bet"}`)},
+			expected: types.SchemaV2,
+		},
+		{
+			name:     "current shape without version",
+			record:   &kgo.Record{Value: []byte(`{"user_id":"x","transaction_type":"bet"}`)},
+			expected: types.SchemaV1,
+		},
+		{
+			name:   "unsupported version",
+			record: &kgo.Record{Headers: []kgo.RecordHeader{{Key: "schema-version", Value: []byte("3")}}},
+			isErr:  true,
+		},
+		{
+			name:   "malformed payload",
+			record: &kgo.Record{Value: []byte(`{`)},
+			isErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := schemaVersion(tt.record, tt.record.Value)
+			if tt.isErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestClientDecodeRecord(t *testing.T) {
+	c := &Client{validator: validator.New()}
+
+	uid := uuid.MustParse("461805a5-d762-441b-91ac-961629f926e7")
+	ts := time.Date(2025, 1, 1, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		value            string
+		expected         models.Transaction
+		expectedCategory types.ErrorCategory
+	}{
+		{
+			name:     "v1 payload",
+			value:    `{"user_id":"461805a5-d762-441b-91ac-961629f926e7","transaction_type":"bet","amount":100,"transaction_date":"2025-01-01T15:00:00Z"}`,
+			expected: models.Transaction{UserID: uid, Type: models.Bet, Amount: 100, Currency: "EUR", TransactionTime: ts},
+		},
+		{
+			name:     "v2 payload from the README",
+			value:    `{"userId":"461805a5-d762-441b-91ac-961629f926e7","type":"win","amount":"100","timestamp":"2025-01-01T15:00:00Z"}`,
+			expected: models.Transaction{UserID: uid, Type: models.Win, Amount: 100, Currency: "EUR", TransactionTime: ts},
+		},
+		{
+			name:     "v2 payload with epoch millis",
+			value:    `{"schemaVersion":2,"eventId":"e1","userId":"461805a5-d762-441b-91ac-961629f926e7","type":"bet","amount":100,"timestamp":1735743600000}`,
+			expected: models.Transaction{EventID: "e1", UserID: uid, Type: models.Bet, Amount: 100, Currency: "EUR", TransactionTime: ts},
+		},
+		{
+			name:     "v2 payload with extended type",
+			value:    `{"userId":"461805a5-d762-441b-91ac-961629f926e7","type":"bonus_conversion","amount":25,"timestamp":1735743600000}`,
+			expected: models.Transaction{UserID: uid, Type: models.BonusConversion, Amount: 25, Currency: "EUR", TransactionTime: ts},
+		},
+		{
+			name:     "v1 rollback with reference",
+			value:    `{"event_id":"r1","user_id":"461805a5-d762-441b-91ac-961629f926e7","transaction_type":"rollback","amount":100,"transaction_date":"2025-01-01T15:00:00Z","reference_id":"b1"}`,
+			expected: models.Transaction{EventID: "r1", UserID: uid, Type: models.Rollback, Amount: 100, Currency: "EUR", TransactionTime: ts, ReferenceID: "b1"},
+		},
+		{
+			name:     "v2 rollback with reference",
+			value:    `{"userId":"461805a5-d762-441b-91ac-961629f926e7","type":"rollback","amount":100,"timestamp":1735743600000,"referenceId":"b1"}`,
+			expected: models.Transaction{UserID: uid, Type: models.Rollback, Amount: 100, Currency: "EUR", TransactionTime: ts, ReferenceID: "b1"},
+		},
+		{
+			name:     "v2 payload with game context",
+			value:    `{"userId":"461805a5-d762-441b-91ac-961629f926e7","type":"bet","amount":100,"timestamp":1735743600000,"roundId":"r1","gameId":"g1","provider":"p1","sessionId":"s1","channel":"web"}`,
+			expected: models.Transaction{UserID: uid, Type: models.Bet, Amount: 100, Currency: "EUR", TransactionTime: ts, RoundID: "r1", GameID: "g1", Provider: "p1", SessionID: "s1", Channel: "web"},
+		},
+		{
+			name:     "v1 payload with game context",
+			value:    `{"user_id":"461805a5-d762-441b-91ac-961629f926e7","transaction_type":"win","amount":100,"transaction_date":"2025-01-01T15:00:00Z","round_id":"r1","game_id":"g1","provider":"p1","session_id":"s1","channel":"mobile"}`,
+			expected: models.Transaction{UserID: uid, Type: models.Win, Amount: 100, Currency: "EUR", TransactionTime: ts, RoundID: "r1", GameID: "g1", Provider: "p1", SessionID: "s1", Channel: "mobile"},
+		},
+		{
+			name:     "v2 payload with currency",
+			value:    `{"userId":"461805a5-d762-441b-91ac-961629f926e7","type":"bet","amount":5000000000,"currency":"JPY","timestamp":1735743600000}`,
+			expected: models.Transaction{UserID: uid, Type: models.Bet, Amount: 5000000000, Currency: "JPY", TransactionTime: ts},
+		},
+		{
+			name:             "v1 payload with unknown currency",
+			value:            `{"user_id":"461805a5-d762-441b-91ac-961629f926e7","transaction_type":"bet","amount":100,"currency":"XXY","transaction_date":"2025-01-01T15:00:00Z"}`,
+			expectedCategory: types.CategoryValidation,
+		},
+		{
+			name:             "v2 bet with zero amount",
+			value:            `{"userId":"461805a5-d762-441b-91ac-961629f926e7","type":"bet","amount":0,"timestamp":1735743600000}`,
+			expectedCategory: types.CategoryValidation,
+		},
+		{
+			name:             "v2 rollback without reference",
+			value:            `{"userId":"461805a5-d762-441b-91ac-961629f926e7","type":"rollback","amount":100,"timestamp":1735743600000}`,
+			expectedCategory: types.CategoryValidation,
+		},
+		{
+			name:             "v2 payload failing validation",
+			value:            `{"userId":"461805a5-d762-441b-91ac-961629f926e7","type":"cashback","amount":"100","timestamp":1735743600000}`,
+			expectedCategory: types.CategoryValidation,
+		},
+		{
+			name:             "v2 payload with malformed amount",
+			value:            `{"userId":"461805a5-d762-441b-91ac-961629f926e7","type":"bet","amount":"ten","timestamp":1735743600000}`,
+			expectedCategory: types.CategoryDecode,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, category, err := c.decodeRecord(&kgo.Record{Value: []byte(tt.value)})
+
+			assert.Equal(t, tt.expectedCategory, category)
+			if tt.expectedCategory != "" {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, tt.expected.TransactionTime.Equal(got.TransactionTime))
+
+			got.TransactionTime = tt.expected.TransactionTime
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestConvertTransactionToModel(t *testing.T) {
+	id := uuid.New()
+	now := time.Now()
+	tests := []struct {
+		name string
+		tx   types.Transaction
+		want models.Transaction
+	}{
+		{
+			name: "basic conversion",
+			tx: types.Transaction{
+				UserID:          id,
+				TransactionType: "bet",
+				Amount:          100,
+				TransactionDate: now,
+			},
+			want: models.Transaction{
+				UserID:          id,
+				Type:            models.TransactionType("bet"),
+				Amount:          100,
+				TransactionTime: now,
+			},
+		},
+		{
+			name: "event id is taken from transaction id",
+			tx: types.Transaction{
+				TransactionID:   "provider-tx-1",
+				UserID:          id,
+				TransactionType: "win",
+				Amount:          100,
+				TransactionDate: now,
+			},
+			want: models.Transaction{
+				EventID:         "provider-tx-1",
+				UserID:          id,
+				Type:            models.TransactionType("win"),
+				Amount:          100,
+				TransactionTime: now,
+			},
+		},
+		{
+			name: "event id takes precedence over transaction id",
+			tx: types.Transaction{
+				EventID:         "provider-event-1",
+				TransactionID:   "provider-tx-1",
+				UserID:          id,
+				TransactionType: "bet",
+				Amount:          100,
+				TransactionDate: now,
+			},
+			want: models.Transaction{
+				EventID:         "provider-event-1",
+				UserID:          id,
+				Type:            models.TransactionType("bet"),
+				Amount:          100,
+				TransactionTime: now,
+			},
+		},
+		{
+			name: "zero values",
+			tx:   types.Transaction{},
+			want: models.Transaction{
+				UserID:          uuid.Nil,
+				Type:            models.TransactionType(""),
+				Amount:          0,
+				TransactionTime: time.Time{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := convertTransactionToModel(tt.tx)
+
+			assert.Equal(t, tt.want.EventID, got.EventID)
+			assert.Equal(t, tt.want.UserID, got.UserID)
+			assert.Equal(t, tt.want.Type, got.Type)
+			assert.Equal(t, tt.want.Amount, got.Amount)
+			assert.Equal(t, tt.want.TransactionTime, got.TransactionTime)
+		})
+	}
+}
+
+func TestConvertTransactionV2ToModel(t *testing.T) {
+	id := uuid.New()
+	now := time.Now()
+
+	got := convertTransactionV2ToModel(types.TransactionV2{
+		EventID:   "provider-event-1",
+		UserID:    id,
+		Type:      "bet",
+		Amount:    100,
+		Timestamp: now,
+	})
+
+	assert.Equal(t, models.Transaction{
+		EventID:         "provider-event-1",
+		UserID:          id,
+		Type:            models.Bet,
+		Amount:          100,
+		Currency:        models.DefaultCurrency,
+		TransactionTime: now,
+	}, got)
+}
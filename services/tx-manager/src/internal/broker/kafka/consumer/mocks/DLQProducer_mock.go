@@ -39,9 +39,20 @@ func (_m *MockDLQProducer) EXPECT() *MockDLQProducer_Expecter {
 }
 
 // Produce provides a mock function for the type MockDLQProducer
-func (_mock *MockDLQProducer) Produce(ctx context.Context, entries []types.FailedEntry) {
-	_mock.Called(ctx, entries)
-	return
+func (_mock *MockDLQProducer) Produce(ctx context.Context, entries []types.FailedEntry) error {
+	ret := _mock.Called(ctx, entries)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Produce")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []types.FailedEntry) error); ok {
+		r0 = returnFunc(ctx, entries)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
 }
 
 // MockDLQProducer_Produce_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Produce'
@@ -74,12 +85,12 @@ func (_c *MockDLQProducer_Produce_Call) Run(run func(ctx context.Context, entrie
 	return _c
 }
 
-func (_c *MockDLQProducer_Produce_Call) Return() *MockDLQProducer_Produce_Call {
-	_c.Call.Return()
+func (_c *MockDLQProducer_Produce_Call) Return(err error) *MockDLQProducer_Produce_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockDLQProducer_Produce_Call) RunAndReturn(run func(ctx context.Context, entries []types.FailedEntry)) *MockDLQProducer_Produce_Call {
-	_c.Run(run)
+func (_c *MockDLQProducer_Produce_Call) RunAndReturn(run func(ctx context.Context, entries []types.FailedEntry) error) *MockDLQProducer_Produce_Call {
+	_c.Call.Return(run)
 	return _c
 }
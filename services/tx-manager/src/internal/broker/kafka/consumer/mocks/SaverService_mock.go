@@ -39,12 +39,12 @@ func (_m *MockSaverService) EXPECT() *MockSaverService_Expecter {
 }
 
 // Create provides a mock function for the type MockSaverService
-func (_mock *MockSaverService) Create(ctx context.Context, transactions ...models.Transaction) error {
+func (_mock *MockSaverService) Create(ctx context.Context, offsets []models.Offset, transactions ...models.Transaction) error {
 	var tmpRet mock.Arguments
 	if len(transactions) > 0 {
-		tmpRet = _mock.Called(ctx, transactions)
+		tmpRet = _mock.Called(ctx, offsets, transactions)
 	} else {
-		tmpRet = _mock.Called(ctx)
+		tmpRet = _mock.Called(ctx, offsets)
 	}
 	ret := tmpRet
 
@@ -53,8 +53,8 @@ func (_mock *MockSaverService) Create(ctx context.Context, transactions ...model
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, ...models.Transaction) error); ok {
-		r0 = returnFunc(ctx, transactions...)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []models.Offset, ...models.Transaction) error); ok {
+		r0 = returnFunc(ctx, offsets, transactions...)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -68,27 +68,33 @@ type MockSaverService_Create_Call struct {
 
 // Create is a helper method to define mock.On call
 //   - ctx context.Context
+//   - offsets []models.Offset
 //   - transactions ...models.Transaction
-func (_e *MockSaverService_Expecter) Create(ctx interface{}, transactions ...interface{}) *MockSaverService_Create_Call {
+func (_e *MockSaverService_Expecter) Create(ctx interface{}, offsets interface{}, transactions ...interface{}) *MockSaverService_Create_Call {
 	return &MockSaverService_Create_Call{Call: _e.mock.On("Create",
-		append([]interface{}{ctx}, transactions...)...)}
+		append([]interface{}{ctx, offsets}, transactions...)...)}
 }
 
-func (_c *MockSaverService_Create_Call) Run(run func(ctx context.Context, transactions ...models.Transaction)) *MockSaverService_Create_Call {
+func (_c *MockSaverService_Create_Call) Run(run func(ctx context.Context, offsets []models.Offset, transactions ...models.Transaction)) *MockSaverService_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 []models.Transaction
+		var arg1 []models.Offset
+		if args[1] != nil {
+			arg1 = args[1].([]models.Offset)
+		}
+		var arg2 []models.Transaction
 		var variadicArgs []models.Transaction
-		if len(args) > 1 {
-			variadicArgs = args[1].([]models.Transaction)
+		if len(args) > 2 {
+			variadicArgs = args[2].([]models.Transaction)
 		}
-		arg1 = variadicArgs
+		arg2 = variadicArgs
 		run(
 			arg0,
-			arg1...,
+			arg1,
+			arg2...,
 		)
 	})
 	return _c
@@ -99,7 +105,126 @@ func (_c *MockSaverService_Create_Call) Return(err error) *MockSaverService_Crea
 	return _c
 }
 
-func (_c *MockSaverService_Create_Call) RunAndReturn(run func(ctx context.Context, transactions ...models.Transaction) error) *MockSaverService_Create_Call {
+func (_c *MockSaverService_Create_Call) RunAndReturn(run func(ctx context.Context, offsets []models.Offset, transactions ...models.Transaction) error) *MockSaverService_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Offsets provides a mock function for the type MockSaverService
+func (_mock *MockSaverService) Offsets(ctx context.Context, group string) ([]models.Offset, error) {
+	ret := _mock.Called(ctx, group)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Offsets")
+	}
+
+	var r0 []models.Offset
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]models.Offset, error)); ok {
+		return returnFunc(ctx, group)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []models.Offset); ok {
+		r0 = returnFunc(ctx, group)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Offset)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, group)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSaverService_Offsets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Offsets'
+type MockSaverService_Offsets_Call struct {
+	*mock.Call
+}
+
+// Offsets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - group string
+func (_e *MockSaverService_Expecter) Offsets(ctx interface{}, group interface{}) *MockSaverService_Offsets_Call {
+	return &MockSaverService_Offsets_Call{Call: _e.mock.On("Offsets", ctx, group)}
+}
+
+func (_c *MockSaverService_Offsets_Call) Run(run func(ctx context.Context, group string)) *MockSaverService_Offsets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSaverService_Offsets_Call) Return(offsets []models.Offset, err error) *MockSaverService_Offsets_Call {
+	_c.Call.Return(offsets, err)
+	return _c
+}
+
+func (_c *MockSaverService_Offsets_Call) RunAndReturn(run func(ctx context.Context, group string) ([]models.Offset, error)) *MockSaverService_Offsets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Ping provides a mock function for the type MockSaverService
+func (_mock *MockSaverService) Ping(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ping")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSaverService_Ping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Ping'
+type MockSaverService_Ping_Call struct {
+	*mock.Call
+}
+
+// Ping is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSaverService_Expecter) Ping(ctx interface{}) *MockSaverService_Ping_Call {
+	return &MockSaverService_Ping_Call{Call: _e.mock.On("Ping", ctx)}
+}
+
+func (_c *MockSaverService_Ping_Call) Run(run func(ctx context.Context)) *MockSaverService_Ping_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSaverService_Ping_Call) Return(err error) *MockSaverService_Ping_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSaverService_Ping_Call) RunAndReturn(run func(ctx context.Context) error) *MockSaverService_Ping_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,321 @@
+package consumer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/broker/types"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/models"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/svcerr"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+type worker struct {
+	parent *Client
+
+	topic     string
+	partition int32
+
+	records chan []*kgo.Record
+	quit    chan struct{}
+	done    chan struct{}
+
+	// paused is set while the partition is paused and rewound, records
+	// polled meanwhile are dropped as they are fetched again on resume.
+	mu     sync.Mutex
+	paused bool
+
+	// published holds the offsets of rejected records already sent to the DLQ
+	// for a batch that was not saved yet, so a rewound batch doesn't send them again.
+	published map[int64]struct{}
+}
+
+func newWorker(parent *Client, topic string, partition int32) *worker {
+	return &worker{
+		parent:    parent,
+		topic:     topic,
+		partition: partition,
+		records:   make(chan []*kgo.Record, 5),
+		quit:      make(chan struct{}),
+		done:      make(chan struct{}),
+		published: make(map[int64]struct{}),
+	}
+}
+
+func (w *worker) run(ctx context.Context) {
+	defer close(w.done)
+
+	for {
+		select {
+		case <-w.quit:
+			return
+		case records := <-w.records:
+			if w.stopped() || !w.delay(ctx, records) {
+				return
+			}
+
+			if err := w.process(ctx, records); err != nil {
+				log.Printf("failed to process %s[%d]: %v", w.topic, w.partition, err)
+
+				w.pause(records[0])
+				recovered := w.waitForRecovery(ctx)
+				w.drain()
+				w.resume()
+
+				if !recovered {
+					return
+				}
+
+				continue
+			}
+
+			if len(w.records) == 0 {
+				w.resume()
+			}
+		}
+	}
+}
+
+// enqueue hands records to the worker without blocking the poll loop.
+// When the worker's buffer is full the partition is paused and rewound to
+// the records, and resumed once the worker has caught up.
+func (w *worker) enqueue(records []*kgo.Record) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.paused {
+		return
+	}
+
+	select {
+	case w.records <- records:
+	default:
+		w.rewind(records[0])
+	}
+}
+
+// stop waits for the worker to exit and drops its buffered batches,
+// which are fetched again by the partition's next owner.
+func (w *worker) stop() {
+	close(w.quit)
+	<-w.done
+	w.drain()
+	w.resume()
+}
+
+func (w *worker) stopped() bool {
+	select {
+	case <-w.quit:
+		return true
+	default:
+		return false
+	}
+}
+
+func (w *worker) drain() {
+	for {
+		select {
+		case <-w.records:
+		default:
+			return
+		}
+	}
+}
+
+func (w *worker) process(ctx context.Context, records []*kgo.Record) error {
+	c := w.parent
+
+	if !c.ready(ctx) {
+		return fmt.Errorf("circuit breaker is %s", c.breaker.State())
+	}
+
+	transactions, sources, failedEntries := c.decode(records)
+	offsets := nextOffsets(c.group, records)
+
+	if err := w.publish(ctx, failedEntries); err != nil {
+		return err
+	}
+
+	err := c.retry(ctx, func() error {
+		return c.txSaver.Create(ctx, offsets, transactions...)
+	})
+
+	if svcerr.IsRejected(err) {
+		// The records after the last healthy part, if all rejected, still
+		// have to move the offsets past them.
+		if err = w.bisect(ctx, transactions, sources, err); err == nil {
+			err = c.retry(ctx, func() error {
+				return c.txSaver.Create(ctx, offsets)
+			})
+		}
+	}
+
+	if err != nil {
+		c.breaker.Failure()
+		log.Printf("circuit breaker opened for %s[%d], consumption resumes once the repository is healthy", w.topic, w.partition)
+
+		return fmt.Errorf("failed to insert transactions: %w", err)
+	}
+
+	clear(w.published)
+
+	if err := c.client.CommitRecords(ctx, records...); err != nil {
+		log.Println("failed to commit records to kafka, offsets are kept in the database: ", err)
+	}
+
+	return nil
+}
+
+// bisect saves the parts of a rejected batch that the database accepts,
+// halving it until the rows that fail on their own are isolated. Those are
+// published as soon as they are found, so every healthy part is stored along
+// with the offsets it advances, as a whole batch would be.
+func (w *worker) bisect(ctx context.Context, transactions []models.Transaction, sources []*kgo.Record, cause error) error {
+	c := w.parent
+
+	if len(transactions) == 0 {
+		return cause
+	}
+
+	if len(transactions) == 1 {
+		category := types.CategoryPersistence
+		if svcerr.IsTransient(cause) {
+			category = types.CategoryTransient
+		}
+
+		return w.publish(ctx, []types.FailedEntry{failedEntry(sources[0], category, cause)})
+	}
+
+	mid := len(transactions) / 2
+
+	for _, half := range [][2]int{{0, mid}, {mid, len(transactions)}} {
+		txs, srcs := transactions[half[0]:half[1]], sources[half[0]:half[1]]
+		offsets := nextOffsets(c.group, srcs)
+
+		err := c.retry(ctx, func() error {
+			return c.txSaver.Create(ctx, offsets, txs...)
+		})
+		if err == nil {
+			continue
+		}
+
+		if !svcerr.IsRejected(err) {
+			return err
+		}
+
+		if err := w.bisect(ctx, txs, srcs, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// publish sends the rejected records of the batch that were not sent yet.
+func (w *worker) publish(ctx context.Context, entries []types.FailedEntry) error {
+	pending := make([]types.FailedEntry, 0, len(entries))
+	for _, entry := range entries {
+		if _, ok := w.published[entry.Offset]; !ok {
+			pending = append(pending, entry)
+		}
+	}
+
+	if err := w.parent.publish(ctx, pending); err != nil {
+		return err
+	}
+
+	for _, entry := range pending {
+		w.published[entry.Offset] = struct{}{}
+	}
+
+	return nil
+}
+
+// delay holds a batch from a retry topic until its last record is due,
+// the partition is paused by enqueue once the buffer behind it is full.
+func (w *worker) delay(ctx context.Context, records []*kgo.Record) bool {
+	c := w.parent
+
+	d, ok := c.delays[w.topic]
+	if !ok {
+		return true
+	}
+
+	wait := time.Until(records[len(records)-1].Timestamp.Add(d))
+	if wait <= 0 {
+		return true
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-w.quit:
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// pause rewinds the partition to a failed batch. The batch is older than
+// anything buffered or rewound by enqueue, so it always takes precedence.
+func (w *worker) pause(first *kgo.Record) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.rewind(first)
+}
+
+func (w *worker) rewind(first *kgo.Record) {
+	c := w.parent
+
+	w.paused = true
+	c.client.PauseFetchPartitions(map[string][]int32{w.topic: {w.partition}})
+	c.client.SetOffsets(map[string]map[int32]kgo.EpochOffset{
+		w.topic: {
+			w.partition: {
+				Epoch:  first.LeaderEpoch,
+				Offset: first.Offset,
+			},
+		},
+	})
+}
+
+func (w *worker) resume() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.paused {
+		return
+	}
+
+	w.paused = false
+	w.parent.client.ResumeFetchPartitions(map[string][]int32{w.topic: {w.partition}})
+}
+
+func (w *worker) waitForRecovery(ctx context.Context) bool {
+	c := w.parent
+
+	ticker := time.NewTicker(c.probeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-w.quit:
+			return false
+		case <-w.records:
+		case <-ticker.C:
+			if c.ready(ctx) {
+				return true
+			}
+		}
+	}
+}
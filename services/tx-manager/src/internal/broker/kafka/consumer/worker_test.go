@@ -0,0 +1,315 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/breaker"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/broker/kafka/consumer/mocks"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/broker/types"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/models"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/svcerr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+func TestClientAssignedRevoked(t *testing.T) {
+	tests := []struct {
+		name            string
+		assigned        map[string][]int32
+		revoked         map[string][]int32
+		expectedWorkers []topicPartition
+	}{
+		{
+			name:     "worker is started for every assigned partition",
+			assigned: map[string][]int32{"t1": {0, 1, 2}},
+			revoked:  map[string][]int32{},
+			expectedWorkers: []topicPartition{
+				{topic: "t1", partition: 0},
+				{topic: "t1", partition: 1},
+				{topic: "t1", partition: 2},
+			},
+		},
+		{
+			name:     "revoked partitions stop their workers",
+			assigned: map[string][]int32{"t1": {0, 1, 2}},
+			revoked:  map[string][]int32{"t1": {0, 2}},
+			expectedWorkers: []topicPartition{
+				{topic: "t1", partition: 1},
+			},
+		},
+		{
+			name:            "revoking unknown partitions is a no-op",
+			assigned:        map[string][]int32{},
+			revoked:         map[string][]int32{"t1": {0}},
+			expectedWorkers: []topicPartition{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{workers: make(map[topicPartition]*worker)}
+
+			c.assigned(context.Background(), nil, tt.assigned)
+			c.revoked(context.Background(), nil, tt.revoked)
+
+			got := make([]topicPartition, 0, len(c.workers))
+			for tp := range c.workers {
+				got = append(got, tp)
+			}
+
+			assert.ElementsMatch(t, tt.expectedWorkers, got)
+
+			c.revoked(context.Background(), nil, tt.assigned)
+			assert.Empty(t, c.workers)
+		})
+	}
+}
+
+func TestWorkerEnqueuePausesFullPartition(t *testing.T) {
+	c := newTestClient(t, nil, nil)
+	w := newWorker(c, "t1", 0)
+
+	for i := range cap(w.records) {
+		w.enqueue([]*kgo.Record{validRecord(int64(i))})
+	}
+
+	enqueued := make(chan struct{})
+	go func() {
+		w.enqueue([]*kgo.Record{validRecord(int64(cap(w.records)))})
+		w.enqueue([]*kgo.Record{validRecord(int64(cap(w.records) + 1))})
+		close(enqueued)
+	}()
+
+	select {
+	case <-enqueued:
+	case <-time.After(time.Second):
+		t.Fatal("enqueue is blocked by a full worker")
+	}
+
+	assert.Len(t, w.records, cap(w.records))
+	assert.Equal(t, map[string][]int32{"t1": {0}}, c.client.PauseFetchPartitions(nil))
+
+	w.drain()
+	w.resume()
+
+	assert.Empty(t, c.client.PauseFetchPartitions(nil))
+
+	w.enqueue([]*kgo.Record{validRecord(0)})
+	assert.Len(t, w.records, 1)
+}
+
+func TestWorkerStopDropsBufferedBatches(t *testing.T) {
+	w := newWorker(&Client{}, "t1", 0)
+	close(w.done)
+
+	w.enqueue([]*kgo.Record{{}})
+	w.enqueue([]*kgo.Record{{}})
+	w.stop()
+
+	assert.Empty(t, w.records)
+}
+
+func TestWorkerProcessWithOpenBreaker(t *testing.T) {
+	saver := mocks.NewMockSaverService(t)
+
+	b := breaker.New(time.Minute)
+	b.Failure()
+
+	c := &Client{
+		txSaver:              saver,
+		breaker:              b,
+		maxRetrySaveAttempts: 1,
+	}
+
+	w := newWorker(c, "t1", 0)
+	err := w.process(context.Background(), []*kgo.Record{{Topic: "t1", Value: []byte("{}")}})
+
+	assert.ErrorContains(t, err, "circuit breaker is open")
+	saver.AssertNotCalled(t, "Create")
+}
+
+func newTestClient(t *testing.T, saver SaverService, dlq DLQProducer) *Client {
+	cli, err := kgo.NewClient(kgo.SeedBrokers("127.0.0.1:1"))
+	assert.NoError(t, err)
+	t.Cleanup(cli.Close)
+
+	v := mocks.NewMockValidator(t)
+	v.On("Struct", mock.Anything).Return(nil).Maybe()
+
+	return &Client{
+		client:               cli,
+		validator:            v,
+		txSaver:              saver,
+		dlqProducer:          dlq,
+		breaker:              breaker.New(time.Minute),
+		group:                "g1",
+		maxRetrySaveAttempts: 1,
+	}
+}
+
+func validRecord(offset int64) *kgo.Record {
+	return recordWithAmount(offset, 100)
+}
+
+func recordWithAmount(offset int64, amount int) *kgo.Record {
+	return &kgo.Record{
+		Topic:  "t1",
+		Offset: offset,
+		Value:  []byte(fmt.Sprintf(`{"user_id":"461805a5-d762-441b-91ac-961629f926e7","transaction_type":"bet","amount":%d,"transaction_date":"2025-01-01T15:00:00Z"}`, amount)),
+	}
+}
+
+func amounts(amounts ...int64) interface{} {
+	return mock.MatchedBy(func(txs []models.Transaction) bool {
+		if len(txs) != len(amounts) {
+			return false
+		}
+
+		for i, tx := range txs {
+			if tx.Amount != amounts[i] {
+				return false
+			}
+		}
+
+		return true
+	})
+}
+
+func upTo(offset int64) []models.Offset {
+	return []models.Offset{{Group: "g1", Topic: "t1", Partition: 0, Offset: offset}}
+}
+
+func TestWorkerProcessBisectsRejectedBatch(t *testing.T) {
+	saver := mocks.NewMockSaverService(t)
+	dlq := mocks.NewMockDLQProducer(t)
+
+	offsets := upTo(5)
+	overflow := fmt.Errorf("%w: integer out of range", svcerr.ErrRejected)
+	violation := fmt.Errorf("%w: violates check constraint", svcerr.ErrRejected)
+
+	// [3] reaches the DLQ before [4] moves the offsets past it
+	first := dlq.On("Produce", mock.Anything, mock.MatchedBy(func(entries []types.FailedEntry) bool {
+		return len(entries) == 1 && entries[0].Offset == 2 && entries[0].Category == types.CategoryPersistence && entries[0].Reason == overflow.Error()
+	})).Return(nil).Once()
+	dlq.On("Produce", mock.Anything, mock.MatchedBy(func(entries []types.FailedEntry) bool {
+		return len(entries) == 1 && entries[0].Offset == 4 && entries[0].Category == types.CategoryPersistence && entries[0].Reason == violation.Error()
+	})).Return(nil).Once()
+
+	// [1 2 3 4 5] -> [1 2] ok, [3 4 5] -> [3] rejected, [4 5] -> [4] ok, [5] rejected
+	saver.On("Create", mock.Anything, offsets, amounts(1, 2, 3, 4, 5)).Return(overflow).Once()
+	saver.On("Create", mock.Anything, upTo(2), amounts(1, 2)).Return(nil).Once()
+	saver.On("Create", mock.Anything, upTo(5), amounts(3, 4, 5)).Return(overflow).Once()
+	saver.On("Create", mock.Anything, upTo(3), amounts(3)).Return(overflow).Once()
+	saver.On("Create", mock.Anything, upTo(5), amounts(4, 5)).Return(violation).Once()
+	saver.On("Create", mock.Anything, upTo(4), amounts(4)).Return(nil).Once().NotBefore(first)
+	saver.On("Create", mock.Anything, upTo(5), amounts(5)).Return(violation).Once()
+	saver.On("Create", mock.Anything, offsets).Return(nil).Once()
+
+	c := newTestClient(t, saver, dlq)
+
+	records := make([]*kgo.Record, 0, 5)
+	for i := range 5 {
+		records = append(records, recordWithAmount(int64(i), i+1))
+	}
+
+	err := newWorker(c, "t1", 0).process(context.Background(), records)
+
+	assert.NoError(t, err)
+	assert.Equal(t, breaker.Closed, c.breaker.State())
+}
+
+func TestWorkerProcessBisectStopsOnUnavailableDatabase(t *testing.T) {
+	saver := mocks.NewMockSaverService(t)
+	dlq := mocks.NewMockDLQProducer(t)
+
+	offsets := []models.Offset{{Group: "g1", Topic: "t1", Partition: 0, Offset: 2}}
+
+	saver.On("Create", mock.Anything, offsets, amounts(1, 2)).Return(fmt.Errorf("%w: integer out of range", svcerr.ErrRejected)).Once()
+	saver.On("Create", mock.Anything, upTo(1), amounts(1)).Return(errors.New("connection refused")).Once()
+
+	c := newTestClient(t, saver, dlq)
+
+	err := newWorker(c, "t1", 0).process(context.Background(), []*kgo.Record{recordWithAmount(0, 1), recordWithAmount(1, 2)})
+
+	assert.ErrorContains(t, err, "connection refused")
+	assert.Equal(t, breaker.Open, c.breaker.State())
+	dlq.AssertNotCalled(t, "Produce")
+}
+
+func TestWorkerProcessKeepsOffsetsWhenDLQFails(t *testing.T) {
+	saver := mocks.NewMockSaverService(t)
+	dlq := mocks.NewMockDLQProducer(t)
+
+	dlq.On("Produce", mock.Anything, mock.Anything).Return(errors.New("not enough replicas"))
+
+	c := newTestClient(t, saver, dlq)
+
+	err := newWorker(c, "t1", 0).process(context.Background(), []*kgo.Record{
+		validRecord(0),
+		{Topic: "t1", Offset: 1, Value: []byte("{")},
+	})
+
+	assert.ErrorContains(t, err, "not enough replicas")
+	saver.AssertNotCalled(t, "Create")
+}
+
+func TestWorkerProcessDoesNotRepublishAfterSaveFailure(t *testing.T) {
+	saver := mocks.NewMockSaverService(t)
+	dlq := mocks.NewMockDLQProducer(t)
+
+	offsets := []models.Offset{{Group: "g1", Topic: "t1", Partition: 0, Offset: 2}}
+
+	dlq.On("Produce", mock.Anything, mock.MatchedBy(func(entries []types.FailedEntry) bool {
+		return len(entries) == 1 && entries[0].Offset == 1 && entries[0].Category == types.CategoryDecode
+	})).Return(nil).Once()
+	saver.On("Create", mock.Anything, offsets, amounts(100)).Return(errors.New("connection refused")).Once()
+	saver.On("Create", mock.Anything, offsets, amounts(100)).Return(nil).Once()
+
+	c := newTestClient(t, saver, dlq)
+	w := newWorker(c, "t1", 0)
+
+	records := []*kgo.Record{
+		validRecord(0),
+		{Topic: "t1", Offset: 1, Value: []byte("{")},
+	}
+
+	err := w.process(context.Background(), records)
+	assert.ErrorContains(t, err, "connection refused")
+
+	c.breaker.Success()
+
+	err = w.process(context.Background(), records)
+	assert.NoError(t, err)
+	assert.Empty(t, w.published)
+}
+
+func TestWorkerDelay(t *testing.T) {
+	c := newTestClient(t, nil, nil)
+	c.delays = map[string]time.Duration{"t1-retry-30s": 30 * time.Second}
+
+	t.Run("records from the main topic are not delayed", func(t *testing.T) {
+		w := newWorker(c, "t1", 0)
+		assert.True(t, w.delay(context.Background(), []*kgo.Record{{Timestamp: time.Now()}}))
+	})
+
+	t.Run("due records are not delayed", func(t *testing.T) {
+		w := newWorker(c, "t1-retry-30s", 0)
+		assert.True(t, w.delay(context.Background(), []*kgo.Record{{Timestamp: time.Now().Add(-time.Minute)}}))
+	})
+
+	t.Run("pending records wait until due", func(t *testing.T) {
+		w := newWorker(c, "t1-retry-30s", 0)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		assert.False(t, w.delay(ctx, []*kgo.Record{{Timestamp: time.Now()}}))
+		assert.Empty(t, c.client.PauseFetchPartitions(nil))
+	})
+}
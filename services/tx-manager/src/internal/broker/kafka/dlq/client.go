@@ -4,7 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
+	"strconv"
 
 	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/broker/types"
 	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/config"
@@ -17,12 +17,14 @@ type Client struct {
 	client *kgo.Client
 
 	topic string
+	tiers []types.RetryTier
 }
 
-func NewWithClient(client *kgo.Client, topic string) *Client {
+func NewWithClient(client *kgo.Client, topic string, tiers []types.RetryTier) *Client {
 	return &Client{
 		client: client,
 		topic:  topic,
+		tiers:  tiers,
 	}
 }
 
@@ -33,36 +35,101 @@ func NewWithConfig(cfg config.KafkaConfig) (*Client, error) {
 
 	cli, err := kgo.NewClient(
 		kgo.SeedBrokers(fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)),
-		kgo.ConsumeTopics(cfg.ProducerConfig.Topic),
+		kgo.DefaultProduceTopic(cfg.ProducerConfig.Topic),
+		kgo.RequiredAcks(kgo.AllISRAcks()),
+		kgo.RecordRetries(cfg.ProducerConfig.MaxRetries),
+		kgo.RecordDeliveryTimeout(cfg.ProducerConfig.DeliveryTimeout),
 	)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return NewWithClient(cli, cfg.ProducerConfig.Topic), nil
+	return NewWithClient(cli, cfg.ProducerConfig.Topic, types.RetryTiers(cfg.ConsumerConfig.Topic, cfg.ProducerConfig.RetryDelays)), nil
 }
 
-func (c *Client) Produce(ctx context.Context, entries []types.FailedEntry) {
-	for _, entry := range entries {
+func (c *Client) Produce(ctx context.Context, entries []types.FailedEntry) error {
+	records := make([]*kgo.Record, 0, len(entries))
 
-		resp, err := json.Marshal(entry)
+	for _, entry := range entries {
+		r, err := c.record(entry)
 		if err != nil {
-			log.Printf("failed to marshal entry: %v", err)
+			return fmt.Errorf("failed to marshal entry from %s[%d]@%d: %w", entry.Topic, entry.Partition, entry.Offset, err)
 		}
 
-		c.client.Produce(ctx, &kgo.Record{
-			Key:   []byte(entry.Key),
-			Value: resp,
-			Topic: c.topic,
-		}, nil)
+		records = append(records, r)
+	}
+
+	if err := c.client.ProduceSync(ctx, records...).FirstErr(); err != nil {
+		return fmt.Errorf("failed to produce rejected records: %w", err)
 	}
+
+	return nil
 }
 
 func (c *Client) Close() {
 	c.client.Close()
 }
 
+func (c *Client) record(entry types.FailedEntry) (*kgo.Record, error) {
+	if tier, ok := c.nextTier(entry); ok {
+		headers := make([]kgo.RecordHeader, 0, len(entry.Headers)+3)
+		for _, h := range entry.Headers {
+			headers = append(headers, kgo.RecordHeader{Key: h.Key, Value: h.Value})
+		}
+
+		headers = append(headers,
+			kgo.RecordHeader{Key: types.HeaderOriginTopic, Value: []byte(entry.Origin.Topic)},
+			kgo.RecordHeader{Key: types.HeaderOriginPartition, Value: []byte(strconv.FormatInt(int64(entry.Origin.Partition), 10))},
+			kgo.RecordHeader{Key: types.HeaderOriginOffset, Value: []byte(strconv.FormatInt(entry.Origin.Offset, 10))},
+		)
+
+		return &kgo.Record{
+			Key:     []byte(entry.Key),
+			Value:   entry.Value,
+			Topic:   tier.Topic,
+			Headers: headers,
+		}, nil
+	}
+
+	resp, err := json.Marshal(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	return &kgo.Record{
+		Key:   []byte(entry.Key),
+		Value: resp,
+		Topic: c.topic,
+		Headers: []kgo.RecordHeader{
+			{Key: "version", Value: []byte(strconv.Itoa(entry.Version))},
+			{Key: "category", Value: []byte(entry.Category)},
+		},
+	}, nil
+}
+
+// nextTier picks the retry topic that follows the one the entry was consumed
+// from. Only transient failures are retried: decoding, validating or storing
+// the same payload again would otherwise fail the same way.
+func (c *Client) nextTier(entry types.FailedEntry) (types.RetryTier, bool) {
+	if entry.Category != types.CategoryTransient {
+		return types.RetryTier{}, false
+	}
+
+	next := 0
+	for i, tier := range c.tiers {
+		if tier.Topic == entry.Topic {
+			next = i + 1
+		}
+	}
+
+	if next >= len(c.tiers) {
+		return types.RetryTier{}, false
+	}
+
+	return c.tiers[next], true
+}
+
 func validate(cfg config.KafkaConfig) error {
 	if len(cfg.Host) == 0 || (cfg.Port < 0 || cfg.Port > 65535) {
 		return fmt.Errorf("%w: invalid host or port", svcerr.ErrBadField)
@@ -72,5 +139,11 @@ func validate(cfg config.KafkaConfig) error {
 		return fmt.Errorf("%w: empty topic", svcerr.ErrBadField)
 	}
 
+	for _, d := range cfg.ProducerConfig.RetryDelays {
+		if d <= 0 {
+			return fmt.Errorf("%w: retry delay must be positive", svcerr.ErrBadField)
+		}
+	}
+
 	return nil
 }
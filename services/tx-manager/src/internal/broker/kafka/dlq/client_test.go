@@ -13,6 +13,7 @@ import (
 	"github.com/docker/go-connections/nat"
 	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/broker/types"
 	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/config"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/svcerr"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/testcontainers/testcontainers-go"
@@ -117,6 +118,107 @@ func TestMain(m *testing.M) {
 	os.Exit(m.Run())
 }
 
+func TestClientRecord(t *testing.T) {
+	c := NewWithClient(nil, "casino_dlq", types.RetryTiers("casino_transactions", []time.Duration{30 * time.Second, 5 * time.Minute}))
+
+	entry := types.FailedEntry{
+		Version: types.FailedEntryVersion,
+		Key:     "key1",
+		Value:   []byte(`{"amount":100}`),
+		Origin:  types.Origin{Topic: "casino_transactions", Partition: 1, Offset: 7},
+		Headers: []types.Header{{Key: "source", Value: []byte("provider-a")}},
+	}
+
+	tests := []struct {
+		name          string
+		topic         string
+		category      types.ErrorCategory
+		expectedTopic string
+		isEnvelope    bool
+	}{
+		{
+			name:          "validation failure goes straight to the DLQ",
+			topic:         "casino_transactions",
+			category:      types.CategoryValidation,
+			expectedTopic: "casino_dlq",
+			isEnvelope:    true,
+		},
+		{
+			name:          "persistence failure goes straight to the DLQ",
+			topic:         "casino_transactions",
+			category:      types.CategoryPersistence,
+			expectedTopic: "casino_dlq",
+			isEnvelope:    true,
+		},
+		{
+			name:          "transient failure goes to the first retry tier",
+			topic:         "casino_transactions",
+			category:      types.CategoryTransient,
+			expectedTopic: "casino_transactions-retry-30s",
+		},
+		{
+			name:          "transient failure moves to the next retry tier",
+			topic:         "casino_transactions-retry-30s",
+			category:      types.CategoryTransient,
+			expectedTopic: "casino_transactions-retry-5m",
+		},
+		{
+			name:          "transient failure after the last tier goes to the DLQ",
+			topic:         "casino_transactions-retry-5m",
+			category:      types.CategoryTransient,
+			expectedTopic: "casino_dlq",
+			isEnvelope:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := entry
+			e.Topic = tt.topic
+			e.Category = tt.category
+
+			r, err := c.record(e)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedTopic, r.Topic)
+			assert.Equal(t, []byte("key1"), r.Key)
+
+			if !tt.isEnvelope {
+				assert.Equal(t, e.Value, r.Value)
+				assert.Equal(t, []kgo.RecordHeader{
+					{Key: "source", Value: []byte("provider-a")},
+					{Key: types.HeaderOriginTopic, Value: []byte("casino_transactions")},
+					{Key: types.HeaderOriginPartition, Value: []byte("1")},
+					{Key: types.HeaderOriginOffset, Value: []byte("7")},
+				}, r.Headers)
+				return
+			}
+
+			var got types.FailedEntry
+			assert.NoError(t, json.Unmarshal(r.Value, &got))
+			assert.Equal(t, e, got)
+			assert.Contains(t, r.Headers, kgo.RecordHeader{Key: "category", Value: []byte(tt.category)})
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cfg := config.KafkaConfig{
+		Host: "localhost",
+		Port: 9092,
+		ProducerConfig: config.ProducerConfig{
+			Topic:       "casino_dlq",
+			RetryDelays: []time.Duration{30 * time.Second},
+		},
+	}
+	assert.NoError(t, validate(cfg))
+
+	cfg.ProducerConfig.RetryDelays = []time.Duration{0}
+	assert.True(t, svcerr.IsBadRequest(validate(cfg)))
+
+	cfg.ProducerConfig.Topic = ""
+	assert.True(t, svcerr.IsBadRequest(validate(cfg)))
+}
+
 func TestNewWithConfig(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -180,15 +282,15 @@ func TestProducerIntegration(t *testing.T) {
 		{
 			name: "single message",
 			entries: []types.FailedEntry{
-				{Key: "k1", Value: []byte("v1")},
+				{Version: types.FailedEntryVersion, Key: "k1", Value: []byte("v1"), Category: types.CategoryDecode},
 			},
 			wantN: 1,
 		},
 		{
 			name: "multiple messages",
 			entries: []types.FailedEntry{
-				{Key: "kA", Value: []byte("123")},
-				{Key: "kB", Value: []byte("456")},
+				{Version: types.FailedEntryVersion, Key: "kA", Value: []byte("123"), Category: types.CategoryValidation},
+				{Version: types.FailedEntryVersion, Key: "kB", Value: []byte("456"), Category: types.CategoryValidation},
 			},
 			wantN: 2,
 		},
@@ -201,8 +303,8 @@ func TestProducerIntegration(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			client := NewWithClient(cli, testTopic)
-			client.Produce(ctx, tt.entries)
+			client := NewWithClient(cli, testTopic, nil)
+			assert.NoError(t, client.Produce(ctx, tt.entries))
 
 			msgs := consumeN(ctx, cli, tt.wantN, 5*time.Second)
 
@@ -224,6 +326,20 @@ func TestProducerIntegration(t *testing.T) {
 	}
 }
 
+func TestProduceReportsFailedDelivery(t *testing.T) {
+	unreachable, err := kgo.NewClient(
+		kgo.SeedBrokers("127.0.0.1:1"),
+		kgo.RecordDeliveryTimeout(time.Second),
+	)
+	assert.NoError(t, err)
+	defer unreachable.Close()
+
+	client := NewWithClient(unreachable, testTopic, nil)
+	err = client.Produce(context.Background(), []types.FailedEntry{{Version: types.FailedEntryVersion, Key: "k1", Value: []byte("v1")}})
+
+	assert.ErrorContains(t, err, "failed to produce rejected records")
+}
+
 func consumeN(ctx context.Context, client *kgo.Client, n int, timeout time.Duration) []*kgo.Record {
 	deadline := time.Now().Add(timeout)
 	result := make([]*kgo.Record, 0, n)
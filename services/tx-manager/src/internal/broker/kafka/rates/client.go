@@ -0,0 +1,76 @@
+package rates
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/config"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/models"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/service/fx"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+type Store interface {
+	Set(rates ...models.Rate)
+}
+
+// Client reads FX rate updates, JSON in the format of fx.ParseJSON, into a Store.
+type Client struct {
+	client *kgo.Client
+	store  Store
+}
+
+func NewWithClient(client *kgo.Client, store Store) *Client {
+	return &Client{
+		client: client,
+		store:  store,
+	}
+}
+
+// NewWithConfig reads topic from the beginning without a consumer group,
+// so every instance holds all rates ever published.
+func NewWithConfig(cfg config.KafkaConfig, topic string, store Store) (*Client, error) {
+	cli, err := kgo.NewClient(
+		kgo.SeedBrokers(fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)),
+		kgo.ConsumeTopics(topic),
+		kgo.ConsumeResetOffset(kgo.NewOffset().AtStart()),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWithClient(cli, store), nil
+}
+
+func (c *Client) Consume(ctx context.Context) {
+	for {
+		fetches := c.client.PollFetches(ctx)
+		if fetches.IsClientClosed() || ctx.Err() != nil {
+			c.client.Close()
+			return
+		}
+
+		fetches.EachError(func(topic string, partition int32, err error) {
+			log.Printf("fetch failed for %s[%d]: %v", topic, partition, err)
+		})
+
+		fetches.EachRecord(func(r *kgo.Record) {
+			if err := c.apply(r); err != nil {
+				log.Printf("skipping rate update %s[%d]@%d: %v", r.Topic, r.Partition, r.Offset, err)
+			}
+		})
+	}
+}
+
+func (c *Client) apply(r *kgo.Record) error {
+	rates, err := fx.ParseJSON(r.Value)
+	if err != nil {
+		return err
+	}
+
+	c.store.Set(rates...)
+
+	return nil
+}
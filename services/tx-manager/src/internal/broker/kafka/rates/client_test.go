@@ -0,0 +1,24 @@
+package rates
+
+import (
+	"testing"
+	"time"
+
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/service/fx"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+func TestClientApply(t *testing.T) {
+	store := fx.New()
+	c := NewWithClient(nil, store)
+
+	require.NoError(t, c.apply(&kgo.Record{Value: []byte(`{"date": "2025-01-02", "currency": "USD", "rate": "1.04"}`)}))
+	assert.Error(t, c.apply(&kgo.Record{Value: []byte(`{"date": "2025-01-03", "currency": "USD", "rate": "-1"}`)}))
+
+	resp, err := store.Convert(10000, "EUR", "USD", time.Date(2025, time.January, 3, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, int64(10400), resp.Amount)
+}
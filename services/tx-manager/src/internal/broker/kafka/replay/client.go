@@ -0,0 +1,233 @@
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/broker/types"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/config"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/svcerr"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+type Options struct {
+	Filter      Filter
+	Transform   Transform
+	DryRun      bool
+	IdleTimeout time.Duration
+}
+
+type Stats struct {
+	Read     int
+	Matched  int
+	Replayed int
+	Failed   int
+}
+
+type Client struct {
+	client *kgo.Client
+	out    io.Writer
+
+	topic string
+	opts  Options
+}
+
+func NewWithClient(client *kgo.Client, topic string, opts Options, out io.Writer) *Client {
+	return &Client{
+		client: client,
+		out:    out,
+		topic:  topic,
+		opts:   opts,
+	}
+}
+
+func NewWithConfig(cfg config.KafkaConfig, opts Options, out io.Writer) (*Client, error) {
+	if err := validate(cfg, opts); err != nil {
+		return nil, err
+	}
+
+	cli, err := kgo.NewClient(
+		kgo.SeedBrokers(fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)),
+		kgo.ConsumeTopics(cfg.ProducerConfig.Topic),
+		kgo.ConsumeResetOffset(kgo.NewOffset().AtStart()),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWithClient(cli, cfg.ConsumerConfig.Topic, opts, out), nil
+}
+
+func (c *Client) Run(ctx context.Context) (Stats, error) {
+	var stats Stats
+
+	for {
+		pollCtx, cancel := context.WithTimeout(ctx, c.opts.IdleTimeout)
+		fetches := c.client.PollFetches(pollCtx)
+		cancel()
+
+		if ctx.Err() != nil {
+			return stats, ctx.Err()
+		}
+
+		if fetches.IsClientClosed() {
+			return stats, kgo.ErrClientClosed
+		}
+
+		fetches.EachError(func(topic string, partition int32, err error) {
+			if !errors.Is(err, context.DeadlineExceeded) {
+				log.Printf("failed to fetch from %s/%d: %v", topic, partition, err)
+			}
+		})
+
+		if fetches.NumRecords() == 0 {
+			if errors.Is(pollCtx.Err(), context.DeadlineExceeded) {
+				return stats, nil
+			}
+
+			continue
+		}
+
+		fetches.EachRecord(func(r *kgo.Record) {
+			c.handle(ctx, r, &stats)
+		})
+	}
+}
+
+func (c *Client) Close() {
+	c.client.Close()
+}
+
+func (c *Client) handle(ctx context.Context, r *kgo.Record, stats *Stats) {
+	stats.Read++
+
+	entry, rec, err := c.prepare(r)
+	if err != nil {
+		stats.Failed++
+		fmt.Fprintf(c.out, "failed to read %s/%d@%d: %v\n", r.Topic, r.Partition, r.Offset, err)
+		return
+	}
+
+	if rec == nil {
+		return
+	}
+
+	stats.Matched++
+
+	if c.opts.DryRun {
+		fmt.Fprintf(c.out, "would replay %s to %s: key=%q category=%s reason=%q value=%s\n",
+			origin(entry), c.topic, entry.Key, entry.Category, entry.Reason, rec.Value)
+		return
+	}
+
+	if err := c.client.ProduceSync(ctx, rec).FirstErr(); err != nil {
+		stats.Failed++
+		fmt.Fprintf(c.out, "failed to replay %s: %v\n", origin(entry), err)
+		return
+	}
+
+	stats.Replayed++
+	fmt.Fprintf(c.out, "replayed %s to %s: key=%q\n", origin(entry), c.topic, entry.Key)
+}
+
+func (c *Client) prepare(r *kgo.Record) (types.FailedEntry, *kgo.Record, error) {
+	entry, err := decodeEntry(r)
+	if err != nil {
+		return entry, nil, err
+	}
+
+	if !c.opts.Filter.Match(entry) {
+		return entry, nil, nil
+	}
+
+	if err := c.opts.Transform.Check(entry); err != nil {
+		return entry, nil, fmt.Errorf("failed to transform %s: %w", origin(entry), err)
+	}
+
+	value, err := c.opts.Transform.Apply(entry.Value)
+	if err != nil {
+		return entry, nil, fmt.Errorf("failed to transform %s: %w", origin(entry), err)
+	}
+
+	headers := make([]kgo.RecordHeader, 0, len(entry.Headers))
+	for _, h := range c.opts.Transform.Headers(entry.Headers) {
+		headers = append(headers, kgo.RecordHeader{Key: h.Key, Value: h.Value})
+	}
+
+	return entry, &kgo.Record{
+		Key:     []byte(entry.Key),
+		Value:   value,
+		Topic:   c.topic,
+		Headers: headers,
+	}, nil
+}
+
+// decodeEntry reads the current envelope and the unversioned entries written before it,
+// which carry only the key and value. Their failure time is taken from the DLQ record.
+func decodeEntry(r *kgo.Record) (types.FailedEntry, error) {
+	var probe struct {
+		Version int `json:"version"`
+	}
+
+	if err := json.Unmarshal(r.Value, &probe); err != nil {
+		return types.FailedEntry{}, fmt.Errorf("failed to decode DLQ entry: %w", err)
+	}
+
+	switch probe.Version {
+	case types.FailedEntryVersion:
+		var entry types.FailedEntry
+		if err := json.Unmarshal(r.Value, &entry); err != nil {
+			return entry, fmt.Errorf("failed to decode DLQ entry: %w", err)
+		}
+
+		return entry, nil
+	case 0:
+		var legacy struct {
+			Key   string `json:"key"`
+			Value []byte `json:"value"`
+		}
+
+		if err := json.Unmarshal(r.Value, &legacy); err != nil {
+			return types.FailedEntry{}, fmt.Errorf("failed to decode unversioned DLQ entry: %w", err)
+		}
+
+		if legacy.Value == nil {
+			return types.FailedEntry{}, errors.New("unversioned DLQ entry has no value")
+		}
+
+		return types.FailedEntry{Key: legacy.Key, Value: legacy.Value, FailedAt: r.Timestamp}, nil
+	default:
+		return types.FailedEntry{}, fmt.Errorf("unsupported DLQ entry version %d", probe.Version)
+	}
+}
+
+// origin is where the entry's event was first consumed, or the record it failed on for entries without one.
+func origin(entry types.FailedEntry) string {
+	if len(entry.Origin.Topic) > 0 {
+		return fmt.Sprintf("%s/%d@%d", entry.Origin.Topic, entry.Origin.Partition, entry.Origin.Offset)
+	}
+
+	return fmt.Sprintf("%s/%d@%d", entry.Topic, entry.Partition, entry.Offset)
+}
+
+func validate(cfg config.KafkaConfig, opts Options) error {
+	if len(cfg.Host) == 0 || (cfg.Port < 0 || cfg.Port > 65535) {
+		return fmt.Errorf("%w: invalid host or port", svcerr.ErrBadField)
+	}
+
+	if len(cfg.ProducerConfig.Topic) == 0 || len(cfg.ConsumerConfig.Topic) == 0 {
+		return fmt.Errorf("%w: empty topic", svcerr.ErrBadField)
+	}
+
+	if opts.IdleTimeout <= 0 {
+		return fmt.Errorf("%w: idle timeout must be positive", svcerr.ErrBadField)
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+package replay
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/broker/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+const ingestionTopic = "casino_transactions"
+
+func dlqRecord(t *testing.T, entry types.FailedEntry) *kgo.Record {
+	t.Helper()
+
+	resp, err := json.Marshal(entry)
+	assert.NoError(t, err)
+
+	return &kgo.Record{Topic: "casino_dlq", Key: []byte(entry.Key), Value: resp}
+}
+
+func TestClientPrepare(t *testing.T) {
+	now := time.Date(2025, 1, 1, 15, 0, 0, 0, time.UTC)
+
+	entry := types.FailedEntry{
+		Version:   types.FailedEntryVersion,
+		Key:       "key1",
+		Value:     []byte(`{"userId":"461805a5-d762-441b-91ac-961629f926e7"}`),
+		Topic:     ingestionTopic,
+		Partition: 1,
+		Offset:    10,
+		Headers:   []types.Header{{Key: "source", Value: []byte("provider-a")}, {Key: "trace", Value: []byte{0xff, 0x00}}},
+		Category:  types.CategoryValidation,
+		Reason:    "missing user_id",
+		FailedAt:  now,
+	}
+
+	tr, err := NewTransform(map[string]string{"userId": "user_id"}, nil)
+	assert.NoError(t, err)
+
+	t.Run("matching entry is transformed and addressed to the ingestion topic", func(t *testing.T) {
+		c := NewWithClient(nil, ingestionTopic, Options{Transform: tr}, nil)
+
+		got, rec, err := c.prepare(dlqRecord(t, entry))
+		assert.NoError(t, err)
+		assert.Equal(t, entry.Key, got.Key)
+		assert.Equal(t, &kgo.Record{
+			Key:     []byte("key1"),
+			Value:   []byte(`{"user_id":"461805a5-d762-441b-91ac-961629f926e7"}`),
+			Topic:   ingestionTopic,
+			Headers: []kgo.RecordHeader{{Key: "source", Value: []byte("provider-a")}, {Key: "trace", Value: []byte{0xff, 0x00}}},
+		}, rec)
+	})
+
+	t.Run("binary entry is not transformed", func(t *testing.T) {
+		c := NewWithClient(nil, ingestionTopic, Options{Transform: tr}, nil)
+
+		e := entry
+		e.Value = []byte{0x0a, 0x02, 0x75, 0x31}
+		e.Headers = []types.Header{{Key: types.HeaderContentType, Value: []byte("application/x-protobuf")}}
+
+		_, rec, err := c.prepare(dlqRecord(t, e))
+		assert.ErrorContains(t, err, "only JSON payloads can be transformed")
+		assert.Nil(t, rec)
+	})
+
+	t.Run("schema version header is dropped with the transform", func(t *testing.T) {
+		c := NewWithClient(nil, ingestionTopic, Options{Transform: tr}, nil)
+
+		e := entry
+		e.Headers = []types.Header{{Key: types.HeaderSchemaVersion, Value: []byte("2")}}
+
+		_, rec, err := c.prepare(dlqRecord(t, e))
+		assert.NoError(t, err)
+		assert.Empty(t, rec.Headers)
+	})
+
+	t.Run("unversioned entry written before the envelope", func(t *testing.T) {
+		c := NewWithClient(nil, ingestionTopic, Options{Transform: tr, Filter: Filter{From: now.Add(-time.Hour)}}, nil)
+
+		legacy := &kgo.Record{
+			Key:       []byte("key1"),
+			Value:     []byte(`{"key":"key1","value":"eyJ1c2VySWQiOiI0NjE4MDVhNS1kNzYyLTQ0MWItOTFhYy05NjE2MjlmOTI2ZTcifQ==","reason":{}}`),
+			Timestamp: now,
+		}
+
+		got, rec, err := c.prepare(legacy)
+		assert.NoError(t, err)
+		assert.Equal(t, types.FailedEntry{Key: "key1", Value: entry.Value, FailedAt: now}, got)
+		assert.Equal(t, &kgo.Record{
+			Key:     []byte("key1"),
+			Value:   []byte(`{"user_id":"461805a5-d762-441b-91ac-961629f926e7"}`),
+			Topic:   ingestionTopic,
+			Headers: []kgo.RecordHeader{},
+		}, rec)
+	})
+
+	t.Run("unversioned entry without a value", func(t *testing.T) {
+		c := NewWithClient(nil, ingestionTopic, Options{}, nil)
+
+		_, rec, err := c.prepare(&kgo.Record{Value: []byte(`{"key":"key1"}`)})
+		assert.Error(t, err)
+		assert.Nil(t, rec)
+	})
+
+	t.Run("filtered out entry yields no record", func(t *testing.T) {
+		c := NewWithClient(nil, ingestionTopic, Options{Filter: Filter{Key: "key2"}}, nil)
+
+		_, rec, err := c.prepare(dlqRecord(t, entry))
+		assert.NoError(t, err)
+		assert.Nil(t, rec)
+	})
+
+	t.Run("unknown envelope version", func(t *testing.T) {
+		c := NewWithClient(nil, ingestionTopic, Options{}, nil)
+
+		e := entry
+		e.Version = types.FailedEntryVersion + 1
+
+		_, rec, err := c.prepare(dlqRecord(t, e))
+		assert.Error(t, err)
+		assert.Nil(t, rec)
+	})
+
+	t.Run("not an envelope", func(t *testing.T) {
+		c := NewWithClient(nil, ingestionTopic, Options{}, nil)
+
+		_, rec, err := c.prepare(&kgo.Record{Value: []byte("garbage")})
+		assert.Error(t, err)
+		assert.Nil(t, rec)
+	})
+}
+
+func TestClientHandleDryRun(t *testing.T) {
+	var out bytes.Buffer
+
+	c := NewWithClient(nil, ingestionTopic, Options{
+		Filter: Filter{Categories: []types.ErrorCategory{types.CategoryDecode}},
+		DryRun: true,
+	}, &out)
+
+	var stats Stats
+	entries := []types.FailedEntry{
+		{Version: types.FailedEntryVersion, Key: "key1", Value: []byte(`{}`), Topic: ingestionTopic, Offset: 1, Category: types.CategoryDecode, Reason: "bad json"},
+		{Version: types.FailedEntryVersion, Key: "key2", Value: []byte(`{}`), Topic: ingestionTopic, Offset: 2, Category: types.CategoryValidation},
+	}
+
+	for _, e := range entries {
+		c.handle(context.Background(), dlqRecord(t, e), &stats)
+	}
+
+	assert.Equal(t, Stats{Read: 2, Matched: 1}, stats)
+	assert.Equal(t, "would replay casino_transactions/0@1 to casino_transactions: key=\"key1\" category=decode reason=\"bad json\" value={}\n", out.String())
+}
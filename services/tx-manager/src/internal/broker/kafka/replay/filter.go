@@ -0,0 +1,35 @@
+package replay
+
+import (
+	"slices"
+	"time"
+
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/broker/types"
+)
+
+type Filter struct {
+	From       time.Time
+	To         time.Time
+	Categories []types.ErrorCategory
+	Key        string
+}
+
+func (f Filter) Match(entry types.FailedEntry) bool {
+	if !f.From.IsZero() && entry.FailedAt.Before(f.From) {
+		return false
+	}
+
+	if !f.To.IsZero() && !entry.FailedAt.Before(f.To) {
+		return false
+	}
+
+	if len(f.Categories) > 0 && !slices.Contains(f.Categories, entry.Category) {
+		return false
+	}
+
+	if f.Key != "" && entry.Key != f.Key {
+		return false
+	}
+
+	return true
+}
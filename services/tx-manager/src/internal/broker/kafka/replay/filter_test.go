@@ -0,0 +1,78 @@
+package replay
+
+import (
+	"testing"
+	"time"
+
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/broker/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterMatch(t *testing.T) {
+	now := time.Date(2025, 1, 1, 15, 0, 0, 0, time.UTC)
+
+	entry := types.FailedEntry{
+		Key:      "key1",
+		Category: types.CategoryValidation,
+		FailedAt: now,
+	}
+
+	tests := []struct {
+		name     string
+		filter   Filter
+		expected bool
+	}{
+		{
+			name:     "empty filter matches everything",
+			filter:   Filter{},
+			expected: true,
+		},
+		{
+			name:     "inside time range",
+			filter:   Filter{From: now.Add(-time.Hour), To: now.Add(time.Hour)},
+			expected: true,
+		},
+		{
+			name:     "from is inclusive",
+			filter:   Filter{From: now},
+			expected: true,
+		},
+		{
+			name:     "to is exclusive",
+			filter:   Filter{To: now},
+			expected: false,
+		},
+		{
+			name:     "before time range",
+			filter:   Filter{From: now.Add(time.Minute)},
+			expected: false,
+		},
+		{
+			name:     "matching category",
+			filter:   Filter{Categories: []types.ErrorCategory{types.CategoryDecode, types.CategoryValidation}},
+			expected: true,
+		},
+		{
+			name:     "other category",
+			filter:   Filter{Categories: []types.ErrorCategory{types.CategoryDecode}},
+			expected: false,
+		},
+		{
+			name:     "matching key",
+			filter:   Filter{Key: "key1"},
+			expected: true,
+		},
+		{
+			name:     "other key",
+			filter:   Filter{Key: "key2"},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.filter.Match(entry))
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/broker/registry"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/broker/types"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/svcerr"
+)
+
+const jsonContentType = "application/json"
+
+type Transform struct {
+	Mapping map[string]string
+	Patch   map[string]any
+}
+
+func NewTransform(mapping map[string]string, patch []byte) (Transform, error) {
+	t := Transform{Mapping: mapping}
+
+	if len(patch) == 0 {
+		return t, nil
+	}
+
+	if err := decodeObject(patch, &t.Patch); err != nil {
+		return Transform{}, fmt.Errorf("%w: patch must be a JSON object: %v", svcerr.ErrBadField, err)
+	}
+
+	return t, nil
+}
+
+func (t Transform) Empty() bool {
+	return len(t.Mapping) == 0 && t.Patch == nil
+}
+
+// Check rejects payloads the transform can't rewrite: only plain JSON objects can be,
+// binary (Protobuf, Avro) and schema registry framed payloads would be corrupted.
+func (t Transform) Check(entry types.FailedEntry) error {
+	if t.Empty() {
+		return nil
+	}
+
+	for _, h := range entry.Headers {
+		if h.Key != types.HeaderContentType {
+			continue
+		}
+
+		media, _, err := mime.ParseMediaType(string(h.Value))
+		if err != nil || media != jsonContentType {
+			return fmt.Errorf("only JSON payloads can be transformed, payload is %q", h.Value)
+		}
+	}
+
+	if registry.IsFramed(entry.Value) {
+		return errors.New("only JSON payloads can be transformed, payload is framed for the schema registry")
+	}
+
+	return nil
+}
+
+// Headers drops the schema version, renames may turn one schema into the other so the consumer
+// infers it from the rewritten payload, and resets the content type to plain JSON.
+func (t Transform) Headers(headers []types.Header) []types.Header {
+	if t.Empty() {
+		return headers
+	}
+
+	resp := make([]types.Header, 0, len(headers))
+	for _, h := range headers {
+		switch h.Key {
+		case types.HeaderSchemaVersion:
+			continue
+		case types.HeaderContentType:
+			h.Value = []byte(jsonContentType)
+		}
+
+		resp = append(resp, h)
+	}
+
+	return resp
+}
+
+func (t Transform) Apply(value []byte) ([]byte, error) {
+	if t.Empty() {
+		return value, nil
+	}
+
+	var doc map[string]any
+	if err := decodeObject(value, &doc); err != nil {
+		return nil, fmt.Errorf("payload is not a JSON object: %w", err)
+	}
+
+	for from, to := range t.Mapping {
+		v, ok := doc[from]
+		if !ok {
+			continue
+		}
+
+		delete(doc, from)
+		doc[to] = v
+	}
+
+	if t.Patch != nil {
+		doc = mergePatch(doc, t.Patch)
+	}
+
+	return json.Marshal(doc)
+}
+
+// decodeObject keeps numbers as json.Number, so int64 amounts and ids
+// survive re-encoding instead of being rounded to a float64.
+func decodeObject(data []byte, v *map[string]any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+
+	if dec.More() {
+		return errors.New("unexpected data after the object")
+	}
+
+	return nil
+}
+
+// mergePatch applies an RFC 7396 JSON merge patch: null removes a field,
+// objects are merged recursively and any other value replaces the target.
+func mergePatch(target, patch map[string]any) map[string]any {
+	if target == nil {
+		target = make(map[string]any, len(patch))
+	}
+
+	for k, v := range patch {
+		if v == nil {
+			delete(target, k)
+			continue
+		}
+
+		p, ok := v.(map[string]any)
+		if !ok {
+			target[k] = v
+			continue
+		}
+
+		t, _ := target[k].(map[string]any)
+		target[k] = mergePatch(t, p)
+	}
+
+	return target
+}
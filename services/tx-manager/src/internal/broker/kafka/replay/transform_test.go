@@ -0,0 +1,161 @@
+package replay
+
+import (
+	"testing"
+
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/broker/types"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/svcerr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransformApply(t *testing.T) {
+	tests := []struct {
+		name     string
+		mapping  map[string]string
+		patch    string
+		value    string
+		expected string
+		isErr    bool
+	}{
+		{
+			name:     "no transform keeps payload untouched",
+			value:    `not json`,
+			expected: `not json`,
+		},
+		{
+			name:     "field mapping renames keys",
+			mapping:  map[string]string{"userId": "user_id", "missing": "other"},
+			value:    `{"userId":"461805a5-d762-441b-91ac-961629f926e7","amount":100}`,
+			expected: `{"amount":100,"user_id":"461805a5-d762-441b-91ac-961629f926e7"}`,
+		},
+		{
+			name:     "merge patch replaces, removes and merges",
+			patch:    `{"transaction_type":"win","debug":null,"meta":{"fixed":true}}`,
+			value:    `{"transaction_type":"Win","debug":"x","meta":{"source":"a"}}`,
+			expected: `{"meta":{"fixed":true,"source":"a"},"transaction_type":"win"}`,
+		},
+		{
+			name:     "mapping is applied before the patch",
+			mapping:  map[string]string{"type": "transaction_type"},
+			patch:    `{"transaction_type":"bet"}`,
+			value:    `{"type":"BET"}`,
+			expected: `{"transaction_type":"bet"}`,
+		},
+		{
+			name:     "large numbers are kept exact",
+			mapping:  map[string]string{"amt": "amount"},
+			patch:    `{"event_id":9007199254740993}`,
+			value:    `{"amt":9223372036854775807,"event_id":1}`,
+			expected: `{"amount":9223372036854775807,"event_id":9007199254740993}`,
+		},
+		{
+			name:    "payload is not a JSON object",
+			mapping: map[string]string{"a": "b"},
+			value:   `[1,2]`,
+			isErr:   true,
+		},
+		{
+			name:    "trailing data after the payload",
+			mapping: map[string]string{"a": "b"},
+			value:   `{"a":1} {"a":2}`,
+			isErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr, err := NewTransform(tt.mapping, []byte(tt.patch))
+			assert.NoError(t, err)
+
+			got, err := tr.Apply([]byte(tt.value))
+			if tt.isErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, string(got))
+		})
+	}
+}
+
+func TestNewTransformInvalidPatch(t *testing.T) {
+	_, err := NewTransform(nil, []byte(`[{"op":"remove","path":"/a"}]`))
+	assert.True(t, svcerr.IsBadRequest(err))
+}
+
+func TestTransformCheck(t *testing.T) {
+	tr, err := NewTransform(map[string]string{"userId": "user_id"}, nil)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		transform Transform
+		entry     types.FailedEntry
+		isErr     bool
+	}{
+		{
+			name:      "JSON without a content type",
+			transform: tr,
+			entry:     types.FailedEntry{Value: []byte(`{"userId":"u1"}`)},
+		},
+		{
+			name:      "JSON with a charset",
+			transform: tr,
+			entry: types.FailedEntry{
+				Value:   []byte(`{"userId":"u1"}`),
+				Headers: []types.Header{{Key: types.HeaderContentType, Value: []byte("application/json; charset=utf-8")}},
+			},
+		},
+		{
+			name:      "protobuf payload",
+			transform: tr,
+			entry: types.FailedEntry{
+				Value:   []byte{0x0a, 0x02, 0x75, 0x31},
+				Headers: []types.Header{{Key: types.HeaderContentType, Value: []byte("application/x-protobuf")}},
+			},
+			isErr: true,
+		},
+		{
+			name:      "schema registry framed payload",
+			transform: tr,
+			entry:     types.FailedEntry{Value: append([]byte{0, 0, 0, 0, 1}, `{"userId":"u1"}`...)},
+			isErr:     true,
+		},
+		{
+			name:  "binary payload without a transform",
+			entry: types.FailedEntry{Value: []byte{0x0a}, Headers: []types.Header{{Key: types.HeaderContentType, Value: []byte("avro/binary")}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.transform.Check(tt.entry)
+			if tt.isErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestTransformHeaders(t *testing.T) {
+	headers := []types.Header{
+		{Key: "source", Value: []byte("provider-a")},
+		{Key: types.HeaderSchemaVersion, Value: []byte("2")},
+		{Key: types.HeaderContentType, Value: []byte("application/json; charset=utf-8")},
+	}
+
+	tr, err := NewTransform(map[string]string{"userId": "user_id"}, nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []types.Header{
+		{Key: "source", Value: []byte("provider-a")},
+		{Key: types.HeaderContentType, Value: []byte("application/json")},
+	}, tr.Headers(headers))
+
+	assert.Equal(t, headers, Transform{}.Headers(headers))
+}
@@ -0,0 +1,135 @@
+package registry
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/svcerr"
+)
+
+type SchemaType string
+
+const (
+	Avro     SchemaType = "AVRO"
+	Protobuf SchemaType = "PROTOBUF"
+	JSON     SchemaType = "JSON"
+)
+
+const (
+	magicByte    = 0
+	headerLength = 5
+)
+
+var extensions = map[string]SchemaType{
+	".avsc":  Avro,
+	".proto": Protobuf,
+	".json":  JSON,
+}
+
+type Schema struct {
+	ID         int
+	Type       SchemaType
+	Definition string
+}
+
+// File is a schema registry backed by a directory of <id>.avsc, <id>.proto
+// and <id>.json files, so framed payloads can be decoded without a running
+// registry.
+type File struct {
+	schemas map[int]Schema
+}
+
+func NewFile(dir string) (*File, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema directory: %w", err)
+	}
+
+	f := &File{schemas: make(map[int]Schema)}
+
+	for _, e := range entries {
+		ext := filepath.Ext(e.Name())
+
+		typ, ok := extensions[ext]
+		if e.IsDir() || !ok {
+			continue
+		}
+
+		id, err := strconv.Atoi(strings.TrimSuffix(e.Name(), ext))
+		if err != nil || id < 0 {
+			return nil, fmt.Errorf("%w: schema file %s is not named after its id", svcerr.ErrBadField, e.Name())
+		}
+
+		if _, ok := f.schemas[id]; ok {
+			return nil, fmt.Errorf("%w: schema %d is defined twice", svcerr.ErrBadField, id)
+		}
+
+		definition, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read schema %s: %w", e.Name(), err)
+		}
+
+		f.schemas[id] = Schema{ID: id, Type: typ, Definition: string(definition)}
+	}
+
+	return f, nil
+}
+
+func (f *File) Schema(id int) (Schema, error) {
+	s, ok := f.schemas[id]
+	if !ok {
+		return Schema{}, fmt.Errorf("%w: schema %d", svcerr.ErrNotFound, id)
+	}
+
+	return s, nil
+}
+
+// IsFramed reports whether the payload starts with the Confluent wire format
+// header: a zero magic byte followed by a big-endian schema id.
+func IsFramed(data []byte) bool {
+	return len(data) >= headerLength && data[0] == magicByte
+}
+
+func Unframe(data []byte) (int, []byte, error) {
+	if !IsFramed(data) {
+		return 0, nil, errors.New("payload is not in the schema registry wire format")
+	}
+
+	return int(binary.BigEndian.Uint32(data[1:headerLength])), data[headerLength:], nil
+}
+
+// MessageIndexes strips the protobuf message index path that follows the
+// wire format header. A single zero byte is shorthand for the first message.
+func MessageIndexes(data []byte) ([]int, []byte, error) {
+	count, n := binary.Varint(data)
+	if n <= 0 {
+		return nil, nil, errors.New("malformed protobuf message indexes")
+	}
+	data = data[n:]
+
+	if count == 0 {
+		return []int{0}, data, nil
+	}
+
+	if count < 0 || count > int64(len(data)) {
+		return nil, nil, fmt.Errorf("invalid protobuf message index count %d", count)
+	}
+
+	indexes := make([]int, 0, count)
+	for range count {
+		i, n := binary.Varint(data)
+		if n <= 0 {
+			return nil, nil, errors.New("malformed protobuf message indexes")
+		}
+
+		indexes = append(indexes, int(i))
+		data = data[n:]
+	}
+
+	return indexes, data, nil
+}
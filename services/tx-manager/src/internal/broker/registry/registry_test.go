@@ -0,0 +1,131 @@
+package registry
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/svcerr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeSchemas(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+
+	return dir
+}
+
+func TestNewFile(t *testing.T) {
+	t.Run("schemas are loaded by id and extension", func(t *testing.T) {
+		dir := writeSchemas(t, map[string]string{
+			"1.avsc":    `"string"`,
+			"2.proto":   `syntax = "proto3";`,
+			"3.json":    `{"type": "object"}`,
+			"README.md": "ignored",
+		})
+
+		f, err := NewFile(dir)
+		assert.NoError(t, err)
+
+		s, err := f.Schema(1)
+		assert.NoError(t, err)
+		assert.Equal(t, Schema{ID: 1, Type: Avro, Definition: `"string"`}, s)
+
+		s, err = f.Schema(2)
+		assert.NoError(t, err)
+		assert.Equal(t, Protobuf, s.Type)
+
+		s, err = f.Schema(3)
+		assert.NoError(t, err)
+		assert.Equal(t, JSON, s.Type)
+
+		_, err = f.Schema(4)
+		assert.True(t, svcerr.IsNotFound(err))
+	})
+
+	t.Run("schema file must be named after its id", func(t *testing.T) {
+		_, err := NewFile(writeSchemas(t, map[string]string{"transaction.avsc": `"string"`}))
+		assert.True(t, svcerr.IsBadRequest(err))
+	})
+
+	t.Run("id can't be defined twice", func(t *testing.T) {
+		_, err := NewFile(writeSchemas(t, map[string]string{"1.avsc": `"string"`, "1.proto": ``}))
+		assert.True(t, svcerr.IsBadRequest(err))
+	})
+
+	t.Run("missing directory", func(t *testing.T) {
+		_, err := NewFile(filepath.Join(t.TempDir(), "missing"))
+		assert.Error(t, err)
+	})
+}
+
+func TestUnframe(t *testing.T) {
+	id, payload, err := Unframe([]byte{0, 0, 0, 1, 2, 'x'})
+	assert.NoError(t, err)
+	assert.Equal(t, 258, id)
+	assert.Equal(t, []byte("x"), payload)
+
+	assert.False(t, IsFramed([]byte(`{"user_id":"x"}`)))
+	assert.False(t, IsFramed([]byte{0, 0, 1}))
+
+	_, _, err = Unframe([]byte(`{}`))
+	assert.Error(t, err)
+}
+
+func TestMessageIndexes(t *testing.T) {
+	tests := []struct {
+		name            string
+		data            []byte
+		expected        []int
+		expectedPayload []byte
+		isErr           bool
+	}{
+		{
+			name:            "first message shorthand",
+			data:            []byte{0, 'x'},
+			expected:        []int{0},
+			expectedPayload: []byte("x"),
+		},
+		{
+			name:            "nested message path",
+			data:            []byte{4, 2, 0, 'x'},
+			expected:        []int{1, 0},
+			expectedPayload: []byte("x"),
+		},
+		{
+			name:  "truncated",
+			data:  []byte{4, 2},
+			isErr: true,
+		},
+		{
+			name:  "count beyond the data",
+			data:  []byte{0x80, 0x80, 0x80, 0x80, 0x10, 0},
+			isErr: true,
+		},
+		{
+			name:  "empty",
+			data:  nil,
+			isErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, payload, err := MessageIndexes(tt.data)
+			if tt.isErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+			assert.Equal(t, tt.expectedPayload, payload)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type RetryTier struct {
+	Topic string
+	Delay time.Duration
+}
+
+func RetryTiers(topic string, delays []time.Duration) []RetryTier {
+	tiers := make([]RetryTier, 0, len(delays))
+	for _, d := range delays {
+		tiers = append(tiers, RetryTier{
+			Topic: fmt.Sprintf("%s-retry-%s", topic, formatDelay(d)),
+			Delay: d,
+		})
+	}
+
+	return tiers
+}
+
+func formatDelay(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+
+	return s
+}
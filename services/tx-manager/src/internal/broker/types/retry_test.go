@@ -0,0 +1,24 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryTiers(t *testing.T) {
+	tiers := RetryTiers("casino_transactions", []time.Duration{
+		30 * time.Second,
+		5 * time.Minute,
+		90 * time.Minute,
+		2 * time.Hour,
+	})
+
+	assert.Equal(t, []RetryTier{
+		{Topic: "casino_transactions-retry-30s", Delay: 30 * time.Second},
+		{Topic: "casino_transactions-retry-5m", Delay: 5 * time.Minute},
+		{Topic: "casino_transactions-retry-1h30m", Delay: 90 * time.Minute},
+		{Topic: "casino_transactions-retry-2h", Delay: 2 * time.Hour},
+	}, tiers)
+}
@@ -0,0 +1,94 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Amount is in minor units of the currency and accepts both 100 and "100".
+type Amount int64
+
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	raw := string(bytes.Trim(data, `"`))
+
+	v, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return fmt.Errorf("amount %s is not an integer", data)
+	}
+
+	*a = Amount(v)
+
+	return nil
+}
+
+// parseTimestamp accepts RFC3339 strings and epoch milliseconds, either as a
+// number or as a numeric string.
+func parseTimestamp(data []byte) (time.Time, error) {
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return time.Time{}, nil
+	}
+
+	raw := string(bytes.Trim(data, `"`))
+
+	if millis, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return time.UnixMilli(millis).UTC(), nil
+	}
+
+	ts, err := time.Parse(time.RFC3339Nano, raw)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("timestamp %s is neither RFC3339 nor epoch millis", data)
+	}
+
+	return ts, nil
+}
+
+func (t *Transaction) UnmarshalJSON(data []byte) error {
+	type plain Transaction
+
+	aux := struct {
+		*plain
+		TransactionDate json.RawMessage `json:"transaction_date"`
+	}{plain: (*plain)(t)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	ts, err := parseTimestamp(aux.TransactionDate)
+	if err != nil {
+		return err
+	}
+
+	t.TransactionDate = ts
+
+	return nil
+}
+
+func (t *TransactionV2) UnmarshalJSON(data []byte) error {
+	type plain TransactionV2
+
+	aux := struct {
+		*plain
+		Timestamp json.RawMessage `json:"timestamp"`
+	}{plain: (*plain)(t)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	ts, err := parseTimestamp(aux.Timestamp)
+	if err != nil {
+		return err
+	}
+
+	t.Timestamp = ts
+
+	return nil
+}
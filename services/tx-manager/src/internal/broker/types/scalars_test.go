@@ -0,0 +1,147 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAmountUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected Amount
+		isErr    bool
+	}{
+		{name: "number", data: `100`, expected: 100},
+		{name: "numeric string", data: `"100"`, expected: 100},
+		{name: "null", data: `null`, expected: 0},
+		{name: "fraction", data: `10.5`, isErr: true},
+		{name: "not a number", data: `"ten"`, isErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Amount
+
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if tt.isErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestTransactionUnmarshalJSON(t *testing.T) {
+	uid := uuid.MustParse("461805a5-d762-441b-91ac-961629f926e7")
+	ts := time.Date(2025, 1, 1, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		data     string
+		expected Transaction
+		isErr    bool
+	}{
+		{
+			name:     "RFC3339 transaction date",
+			data:     `{"event_id":"e1","user_id":"461805a5-d762-441b-91ac-961629f926e7","transaction_type":"bet","amount":100,"transaction_date":"2025-01-01T15:00:00Z"}`,
+			expected: Transaction{EventID: "e1", UserID: uid, TransactionType: "bet", Amount: 100, TransactionDate: ts},
+		},
+		{
+			name:     "epoch millis transaction date",
+			data:     `{"user_id":"461805a5-d762-441b-91ac-961629f926e7","transaction_type":"win","amount":"50","transaction_date":1735743600000}`,
+			expected: Transaction{UserID: uid, TransactionType: "win", Amount: 50, TransactionDate: ts},
+		},
+		{
+			name:     "missing transaction date is left for validation",
+			data:     `{"user_id":"461805a5-d762-441b-91ac-961629f926e7","transaction_type":"win","amount":50}`,
+			expected: Transaction{UserID: uid, TransactionType: "win", Amount: 50},
+		},
+		{
+			name:  "malformed transaction date",
+			data:  `{"user_id":"461805a5-d762-441b-91ac-961629f926e7","transaction_type":"win","amount":50,"transaction_date":"yesterday"}`,
+			isErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Transaction
+
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if tt.isErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, tt.expected.TransactionDate.Equal(got.TransactionDate))
+
+			got.TransactionDate = tt.expected.TransactionDate
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestTransactionV2UnmarshalJSON(t *testing.T) {
+	uid := uuid.MustParse("461805a5-d762-441b-91ac-961629f926e7")
+	ts := time.Date(2025, 1, 1, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		data     string
+		expected TransactionV2
+		isErr    bool
+	}{
+		{
+			name:     "RFC3339 timestamp and string amount",
+			data:     `{"eventId":"e1","userId":"461805a5-d762-441b-91ac-961629f926e7","type":"bet","amount":"100","timestamp":"2025-01-01T15:00:00Z"}`,
+			expected: TransactionV2{EventID: "e1", UserID: uid, Type: "bet", Amount: 100, Timestamp: ts},
+		},
+		{
+			name:     "epoch millis timestamp and numeric amount",
+			data:     `{"userId":"461805a5-d762-441b-91ac-961629f926e7","type":"win","amount":50,"timestamp":1735743600000}`,
+			expected: TransactionV2{UserID: uid, Type: "win", Amount: 50, Timestamp: ts},
+		},
+		{
+			name:     "epoch millis as string",
+			data:     `{"userId":"461805a5-d762-441b-91ac-961629f926e7","type":"win","amount":50,"timestamp":"1735743600000"}`,
+			expected: TransactionV2{UserID: uid, Type: "win", Amount: 50, Timestamp: ts},
+		},
+		{
+			name:     "missing timestamp is left for validation",
+			data:     `{"userId":"461805a5-d762-441b-91ac-961629f926e7","type":"win","amount":50}`,
+			expected: TransactionV2{UserID: uid, Type: "win", Amount: 50},
+		},
+		{
+			name:  "malformed timestamp",
+			data:  `{"userId":"461805a5-d762-441b-91ac-961629f926e7","type":"win","amount":50,"timestamp":"yesterday"}`,
+			isErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got TransactionV2
+
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if tt.isErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, tt.expected.Timestamp.Equal(got.Timestamp))
+
+			got.Timestamp = tt.expected.Timestamp
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
@@ -6,15 +6,101 @@ import (
 	"github.com/google/uuid"
 )
 
-type FailedEntry struct {
+const FailedEntryVersion = 1
+
+// HeaderContentType tells how the payload is encoded, JSON when it is missing.
+// HeaderSchemaVersion picks the JSON schema instead of inferring it from the payload.
+const (
+	HeaderContentType   = "content-type"
+	HeaderSchemaVersion = "schema-version"
+)
+
+// Records sent to a retry tier carry where their event was first consumed in these headers.
+const (
+	HeaderOriginTopic     = "origin-topic"
+	HeaderOriginPartition = "origin-partition"
+	HeaderOriginOffset    = "origin-offset"
+)
+
+type ErrorCategory string
+
+const (
+	CategoryDecode      ErrorCategory = "decode"
+	CategoryValidation  ErrorCategory = "validation"
+	CategoryPersistence ErrorCategory = "persistence"
+	// CategoryTransient is a database rejection that may pass on a later attempt.
+	CategoryTransient ErrorCategory = "transient"
+)
+
+type Header struct {
 	Key   string `json:"key"`
 	Value []byte `json:"value"`
-	Err   error  `json:"reason"`
 }
 
+type FieldError struct {
+	Field string `json:"field"`
+	Tag   string `json:"tag"`
+	Param string `json:"param,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// Origin is where an event was first consumed, before it went through any retry tier.
+type Origin struct {
+	Topic     string `json:"topic"`
+	Partition int32  `json:"partition"`
+	Offset    int64  `json:"offset"`
+}
+
+type FailedEntry struct {
+	Version     int           `json:"version"`
+	Key         string        `json:"key"`
+	Value       []byte        `json:"value"`
+	Topic       string        `json:"topic"`
+	Partition   int32         `json:"partition"`
+	Offset      int64         `json:"offset"`
+	Origin      Origin        `json:"origin"`
+	Headers     []Header      `json:"headers,omitempty"`
+	Timestamp   time.Time     `json:"timestamp"`
+	Category    ErrorCategory `json:"category"`
+	Reason      string        `json:"reason"`
+	FieldErrors []FieldError  `json:"field_errors,omitempty"`
+	FailedAt    time.Time     `json:"failed_at"`
+}
+
+type SchemaVersion int
+
+const (
+	SchemaV1 SchemaVersion = 1
+	SchemaV2 SchemaVersion = 2
+)
+
 type Transaction struct {
+	EventID         string    `json:"event_id" validate:"omitempty,max=128"`
+	TransactionID   string    `json:"transaction_id" validate:"omitempty,max=128"`
 	UserID          uuid.UUID `json:"user_id" validate:"required,uuid"`
-	TransactionType string    `json:"transaction_type" validate:"required,oneof=bet win"`
-	Amount          int       `json:"amount" validate:"required,gt=0"`
+	TransactionType string    `json:"transaction_type" validate:"required,oneof=bet win deposit withdrawal refund rollback bonus_credit bonus_conversion jackpot_win"`
+	Amount          Amount    `json:"amount" validate:"required,gt=0"`
 	TransactionDate time.Time `json:"transaction_date" validate:"required"`
+	ReferenceID     string    `json:"reference_id" validate:"required_if=TransactionType rollback,max=128"`
+	RoundID         string    `json:"round_id" validate:"max=128"`
+	GameID          string    `json:"game_id" validate:"max=128"`
+	Provider        string    `json:"provider" validate:"max=64"`
+	SessionID       string    `json:"session_id" validate:"max=128"`
+	Channel         string    `json:"channel" validate:"max=32"`
+	Currency        string    `json:"currency" validate:"omitempty,iso4217"`
+}
+
+type TransactionV2 struct {
+	EventID     string    `json:"eventId" validate:"omitempty,max=128"`
+	UserID      uuid.UUID `json:"userId" validate:"required,uuid"`
+	Type        string    `json:"type" validate:"required,oneof=bet win deposit withdrawal refund rollback bonus_credit bonus_conversion jackpot_win"`
+	Amount      Amount    `json:"amount" validate:"required,gt=0"`
+	Timestamp   time.Time `json:"timestamp" validate:"required"`
+	ReferenceID string    `json:"referenceId" validate:"required_if=Type rollback,max=128"`
+	RoundID     string    `json:"roundId" validate:"max=128"`
+	GameID      string    `json:"gameId" validate:"max=128"`
+	Provider    string    `json:"provider" validate:"max=64"`
+	SessionID   string    `json:"sessionId" validate:"max=128"`
+	Channel     string    `json:"channel" validate:"max=32"`
+	Currency    string    `json:"currency" validate:"omitempty,iso4217"`
 }
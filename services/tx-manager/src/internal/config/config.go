@@ -1,23 +1,37 @@
 package config
 
-import "github.com/caarlos0/env/v11"
+import (
+	"fmt"
+	"time"
+
+	"github.com/caarlos0/env/v11"
+)
 
 type ConsumerConfig struct {
-	Topic             string `env:"TOPIC,required"`
-	ConsumerGroup     string `env:"GROUP,required"`
-	MaxFetchedRecords int    `env:"MAX_RECORDS_FETCHED,required"`
-	MaxRetries        int    `env:"MAX_RETRIES,required"`
+	Topic             string        `env:"TOPIC,required"`
+	ConsumerGroup     string        `env:"GROUP,required"`
+	MaxFetchedRecords int           `env:"MAX_RECORDS_FETCHED,required"`
+	MaxRetries        int           `env:"MAX_RETRIES,required"`
+	BreakerCooldown   time.Duration `env:"BREAKER_COOLDOWN" envDefault:"30s"`
 }
 
 type ProducerConfig struct {
-	Topic string `env:"TOPIC,required"`
+	Topic           string          `env:"TOPIC,required"`
+	RetryDelays     []time.Duration `env:"RETRY_DELAYS" envSeparator:"," envDefault:"30s,5m"`
+	MaxRetries      int             `env:"MAX_RETRIES" envDefault:"10"`
+	DeliveryTimeout time.Duration   `env:"DELIVERY_TIMEOUT" envDefault:"30s"`
+}
+
+type SchemaRegistryConfig struct {
+	Dir string `env:"DIR"`
 }
 
 type KafkaConfig struct {
-	Host           string         `env:"HOST,required"`
-	Port           int            `env:"PORT" envDefault:"9092"`
-	ConsumerConfig ConsumerConfig `envPrefix:"CONSUMER_"`
-	ProducerConfig ProducerConfig `envPrefix:"PRODUCER_"`
+	Host           string               `env:"HOST,required"`
+	Port           int                  `env:"PORT" envDefault:"9092"`
+	ConsumerConfig ConsumerConfig       `envPrefix:"CONSUMER_"`
+	ProducerConfig ProducerConfig       `envPrefix:"PRODUCER_"`
+	SchemaRegistry SchemaRegistryConfig `envPrefix:"SCHEMA_REGISTRY_"`
 }
 
 type DatabaseConfig struct {
@@ -33,10 +47,34 @@ type GrpcConfig struct {
 	Port int `env:"PORT,required"`
 }
 
+type RoundsConfig struct {
+	OrphanTimeout time.Duration `env:"ORPHAN_TIMEOUT" envDefault:"1h"`
+}
+
+type BalancesConfig struct {
+	SnapshotInterval time.Duration `env:"SNAPSHOT_INTERVAL" envDefault:"1h"`
+	MaxHistoryPoints int           `env:"MAX_HISTORY_POINTS" envDefault:"1000"`
+}
+
+type TransactionsConfig struct {
+	MaxBatchSize int `env:"MAX_BATCH_SIZE" envDefault:"100"`
+}
+
+// FXConfig sets where FX rates come from: a .csv or .json file loaded on start
+// and a topic of rate updates read on top of it. Both are optional.
+type FXConfig struct {
+	RatesFile string `env:"RATES_FILE"`
+	Topic     string `env:"TOPIC"`
+}
+
 type Config struct {
-	Kafka    KafkaConfig    `envPrefix:"BROKER_"`
-	Database DatabaseConfig `envPrefix:"DATABASE_"`
-	Grpc     GrpcConfig     `envPrefix:"GRPC_"`
+	Kafka        KafkaConfig        `envPrefix:"BROKER_"`
+	Database     DatabaseConfig     `envPrefix:"DATABASE_"`
+	Grpc         GrpcConfig         `envPrefix:"GRPC_"`
+	Rounds       RoundsConfig       `envPrefix:"ROUNDS_"`
+	Balances     BalancesConfig     `envPrefix:"BALANCES_"`
+	Transactions TransactionsConfig `envPrefix:"TRANSACTIONS_"`
+	FX           FXConfig           `envPrefix:"FX_"`
 }
 
 func New() (*Config, error) {
@@ -45,5 +83,35 @@ func New() (*Config, error) {
 		return nil, err
 	}
 
+	if cfg.Balances.SnapshotInterval <= 0 {
+		return nil, fmt.Errorf("BALANCES_SNAPSHOT_INTERVAL must be positive, got %s", cfg.Balances.SnapshotInterval)
+	}
+
+	if cfg.Balances.MaxHistoryPoints <= 0 {
+		return nil, fmt.Errorf("BALANCES_MAX_HISTORY_POINTS must be positive, got %d", cfg.Balances.MaxHistoryPoints)
+	}
+
+	if cfg.Transactions.MaxBatchSize <= 0 {
+		return nil, fmt.Errorf("TRANSACTIONS_MAX_BATCH_SIZE must be positive, got %d", cfg.Transactions.MaxBatchSize)
+	}
+
+	return cfg, nil
+}
+
+func NewDatabaseConfig() (*DatabaseConfig, error) {
+	cfg := &DatabaseConfig{}
+	if err := env.ParseWithOptions(cfg, env.Options{Prefix: "DATABASE_"}); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+func NewKafkaConfig() (*KafkaConfig, error) {
+	cfg := &KafkaConfig{}
+	if err := env.ParseWithOptions(cfg, env.Options{Prefix: "BROKER_"}); err != nil {
+		return nil, err
+	}
+
 	return cfg, nil
 }
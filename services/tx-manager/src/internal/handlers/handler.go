@@ -3,7 +3,9 @@ package handlers
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
+	"time"
 
 	hErr "github.com/e1esm/casino-transaction-system/tx-manager/src/internal/handlers/errors"
 	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/handlers/validators"
@@ -13,19 +15,43 @@ import (
 	"github.com/google/uuid"
 )
 
+// maxTransactionsLimit caps a page of transactions.
+const maxTransactionsLimit = 1000
+
 type TransactionService interface {
-	GetByID(ctx context.Context, id uuid.UUID) (*models.Transaction, error)
-	GetAll(ctx context.Context, filters models.TransactionFilter, orderBy string, limit, offset int64) ([]models.Transaction, int64, error)
+	GetChain(ctx context.Context, id uuid.UUID) ([]models.Transaction, error)
+	BatchGet(ctx context.Context, ids []uuid.UUID, reportingCurrency string) ([]models.Transaction, []uuid.UUID, error)
+	GetAll(ctx context.Context, filters models.TransactionFilter, sort []models.Sort, limit, offset int64, reportingCurrency string) ([]models.Transaction, error)
+	GetPage(ctx context.Context, filters models.TransactionFilter, after *models.Cursor, limit int64, reportingCurrency string) ([]models.Transaction, *models.Cursor, error)
+	Count(ctx context.Context, filters models.TransactionFilter, mode models.CountMode) (models.Total, error)
+}
+
+type RoundService interface {
+	GetRound(ctx context.Context, provider, id string) (*models.Round, error)
+	ListOpenRounds(ctx context.Context, filters models.RoundFilter, orphanedOnly bool, limit, offset int64) ([]models.Round, error)
+}
+
+type BalanceService interface {
+	GetUserBalance(ctx context.Context, userID uuid.UUID, currency, reportingCurrency string) (*models.Balance, error)
+	GetBalanceAt(ctx context.Context, userID uuid.UUID, currency string, at time.Time, reportingCurrency string) (*models.BalancePoint, error)
+	GetBalanceHistory(ctx context.Context, userID uuid.UUID, currency string, from, to time.Time, step time.Duration, reportingCurrency string) ([]models.BalancePoint, error)
+}
+
+type LedgerService interface {
+	ListPostings(ctx context.Context, account, currency string, limit, offset int64, reportingCurrency string) ([]models.Posting, models.AccountBalance, error)
 }
 
 type Handler struct {
 	proto.UnimplementedTransactionManagerServer
 
-	txSvc TransactionService
+	txSvc      TransactionService
+	roundSvc   RoundService
+	balanceSvc BalanceService
+	ledgerSvc  LedgerService
 }
 
-func New(txSvc TransactionService) *Handler {
-	return &Handler{txSvc: txSvc}
+func New(txSvc TransactionService, roundSvc RoundService, balanceSvc BalanceService, ledgerSvc LedgerService) *Handler {
+	return &Handler{txSvc: txSvc, roundSvc: roundSvc, balanceSvc: balanceSvc, ledgerSvc: ledgerSvc}
 }
 
 func (h *Handler) GetTransactionByID(ctx context.Context, req *proto.GetTransactionByIDRequest) (*proto.GetTransactionByIDResponse, error) {
@@ -34,7 +60,8 @@ func (h *Handler) GetTransactionByID(ctx context.Context, req *proto.GetTransact
 		return nil, hErr.CastInvalidRequest(err)
 	}
 
-	resp, err := h.txSvc.GetByID(ctx, id)
+	// The chain holds the transaction itself, so it is read in a single query.
+	chain, err := h.txSvc.GetChain(ctx, id)
 	if err != nil {
 		prErr, isInternal := hErr.ParseSvcErrToProto(err)
 		if isInternal {
@@ -43,8 +70,53 @@ func (h *Handler) GetTransactionByID(ctx context.Context, req *proto.GetTransact
 		return nil, prErr
 	}
 
+	var resp *proto.Transaction
+	for _, t := range chain {
+		if t.ID == id {
+			resp = convertTransactionModelToProto(t)
+		}
+	}
+
 	return &proto.GetTransactionByIDResponse{
-		Transaction: convertTransactionModelToProto(*resp),
+		Transaction: resp,
+		Chain:       convertTransactionsModelToProto(chain),
+	}, nil
+}
+
+func (h *Handler) BatchGetTransactions(ctx context.Context, req *proto.BatchGetTransactionsRequest) (*proto.BatchGetTransactionsResponse, error) {
+	ids := make([]uuid.UUID, 0, len(req.Ids))
+	for _, raw := range req.Ids {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, hErr.CastInvalidRequest(fmt.Errorf("failed to parse id %q: %w", raw, err))
+		}
+
+		ids = append(ids, id)
+	}
+
+	reportingCurrency, err := parseReportingCurrency(req.ReportingCurrency)
+	if err != nil {
+		return nil, hErr.CastInvalidRequest(err)
+	}
+
+	found, missing, err := h.txSvc.BatchGet(ctx, ids, reportingCurrency)
+	if err != nil {
+		prErr, isInternal := hErr.ParseSvcErrToProto(err)
+		if isInternal {
+			log.Println(err.Error())
+		}
+
+		return nil, prErr
+	}
+
+	missingIDs := make([]string, 0, len(missing))
+	for _, id := range missing {
+		missingIDs = append(missingIDs, id.String())
+	}
+
+	return &proto.BatchGetTransactionsResponse{
+		Transactions: convertTransactionsModelToProto(found),
+		MissingIds:   missingIDs,
 	}, nil
 }
 
@@ -54,11 +126,64 @@ func (h *Handler) GetTransactionByFilters(ctx context.Context, req *proto.GetTra
 		return nil, hErr.CastInvalidRequest(err)
 	}
 
+	parsedFilters.Expr, err = convertProtoFilterExprToModel(req.Filter)
+	if err != nil {
+		return nil, hErr.CastInvalidRequest(err)
+	}
+
 	if !validators.ValidateGreaterOrEqualTo(1, req.Limit) || !validators.ValidateGreaterOrEqualTo(0, req.Offset) {
 		return nil, hErr.CastInvalidRequest(errors.New("invalid offset or limit"))
 	}
 
-	resp, n, err := h.txSvc.GetAll(ctx, parsedFilters, req.OrderBy, req.Limit, req.Offset)
+	if req.Limit > maxTransactionsLimit {
+		return nil, hErr.CastInvalidRequest(fmt.Errorf("limit is capped at %d", maxTransactionsLimit))
+	}
+
+	reportingCurrency, err := parseReportingCurrency(req.ReportingCurrency)
+	if err != nil {
+		return nil, hErr.CastInvalidRequest(err)
+	}
+
+	var (
+		resp []models.Transaction
+		next *models.Cursor
+		sort []models.Sort
+	)
+
+	if len(req.OrderBy) == 0 && req.Offset == 0 {
+		var after *models.Cursor
+		if len(req.PageToken) > 0 {
+			cursor, err := models.ParseCursor(req.PageToken)
+			if err != nil {
+				return nil, hErr.CastInvalidRequest(err)
+			}
+
+			after = &cursor
+		}
+
+		resp, next, err = h.txSvc.GetPage(ctx, parsedFilters, after, req.Limit, reportingCurrency)
+	} else {
+		if len(req.PageToken) > 0 {
+			return nil, hErr.CastInvalidRequest(errors.New("page token can't be combined with orderBy or offset"))
+		}
+
+		sort, err = convertProtoSortToModel(req.OrderBy)
+		if err != nil {
+			return nil, hErr.CastInvalidRequest(err)
+		}
+
+		resp, err = h.txSvc.GetAll(ctx, parsedFilters, sort, req.Limit, req.Offset, reportingCurrency)
+	}
+	if err != nil {
+		prErr, isInternal := hErr.ParseSvcErrToProto(err)
+		if isInternal {
+			log.Println(err.Error())
+		}
+
+		return nil, prErr
+	}
+
+	total, err := h.txSvc.Count(ctx, parsedFilters, convertProtoCountModeToModel(req.CountMode))
 	if err != nil {
 		prErr, isInternal := hErr.ParseSvcErrToProto(err)
 		if isInternal {
@@ -68,8 +193,201 @@ func (h *Handler) GetTransactionByFilters(ctx context.Context, req *proto.GetTra
 		return nil, prErr
 	}
 
+	var nextPageToken string
+	if next != nil {
+		nextPageToken = next.Encode()
+	}
+
 	return &proto.GetTransactionByFiltersResponse{
-		Transaction: convertTransactionsModelToProto(resp),
-		Total:       n,
+		Transaction:   convertTransactionsModelToProto(resp),
+		Total:         total.Count,
+		NextPageToken: nextPageToken,
+		TotalExact:    total.Exact,
+	}, nil
+}
+
+func (h *Handler) GetRound(ctx context.Context, req *proto.GetRoundRequest) (*proto.GetRoundResponse, error) {
+	if len(req.Id) == 0 {
+		return nil, hErr.CastInvalidRequest(errors.New("round id is required"))
+	}
+
+	resp, err := h.roundSvc.GetRound(ctx, req.Provider, req.Id)
+	if err != nil {
+		prErr, isInternal := hErr.ParseSvcErrToProto(err)
+		if isInternal {
+			log.Println(err.Error())
+		}
+		return nil, prErr
+	}
+
+	return &proto.GetRoundResponse{
+		Round: convertRoundModelToProto(*resp),
+	}, nil
+}
+
+func (h *Handler) ListOpenRounds(ctx context.Context, req *proto.ListOpenRoundsRequest) (*proto.ListOpenRoundsResponse, error) {
+	filters, err := convertProtoRoundFiltersToModel(req)
+	if err != nil {
+		return nil, hErr.CastInvalidRequest(err)
+	}
+
+	if !validators.ValidateGreaterOrEqualTo(1, req.Limit) || !validators.ValidateGreaterOrEqualTo(0, req.Offset) {
+		return nil, hErr.CastInvalidRequest(errors.New("invalid offset or limit"))
+	}
+
+	resp, err := h.roundSvc.ListOpenRounds(ctx, filters, req.OrphanedOnly, req.Limit, req.Offset)
+	if err != nil {
+		prErr, isInternal := hErr.ParseSvcErrToProto(err)
+		if isInternal {
+			log.Println(err.Error())
+		}
+
+		return nil, prErr
+	}
+
+	rounds := make([]*proto.Round, 0, len(resp))
+	for _, round := range resp {
+		rounds = append(rounds, convertRoundModelToProto(round))
+	}
+
+	return &proto.ListOpenRoundsResponse{
+		Rounds: rounds,
+	}, nil
+}
+
+func (h *Handler) GetUserBalance(ctx context.Context, req *proto.GetUserBalanceRequest) (*proto.GetUserBalanceResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, hErr.CastInvalidRequest(err)
+	}
+
+	currency, err := parseCurrency(req.Currency)
+	if err != nil {
+		return nil, hErr.CastInvalidRequest(err)
+	}
+
+	reportingCurrency, err := parseReportingCurrency(req.ReportingCurrency)
+	if err != nil {
+		return nil, hErr.CastInvalidRequest(err)
+	}
+
+	resp, err := h.balanceSvc.GetUserBalance(ctx, userID, currency, reportingCurrency)
+	if err != nil {
+		prErr, isInternal := hErr.ParseSvcErrToProto(err)
+		if isInternal {
+			log.Println(err.Error())
+		}
+		return nil, prErr
+	}
+
+	return &proto.GetUserBalanceResponse{
+		Balance: convertBalanceModelToProto(*resp),
+	}, nil
+}
+
+func (h *Handler) GetBalanceAt(ctx context.Context, req *proto.GetBalanceAtRequest) (*proto.GetBalanceAtResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, hErr.CastInvalidRequest(err)
+	}
+
+	currency, err := parseCurrency(req.Currency)
+	if err != nil {
+		return nil, hErr.CastInvalidRequest(err)
+	}
+
+	reportingCurrency, err := parseReportingCurrency(req.ReportingCurrency)
+	if err != nil {
+		return nil, hErr.CastInvalidRequest(err)
+	}
+
+	resp, err := h.balanceSvc.GetBalanceAt(ctx, userID, currency, time.Unix(req.Timestamp, 0).UTC(), reportingCurrency)
+	if err != nil {
+		prErr, isInternal := hErr.ParseSvcErrToProto(err)
+		if isInternal {
+			log.Println(err.Error())
+		}
+		return nil, prErr
+	}
+
+	return &proto.GetBalanceAtResponse{
+		Balance: convertBalancePointModelToProto(*resp),
+	}, nil
+}
+
+func (h *Handler) GetBalanceHistory(ctx context.Context, req *proto.GetBalanceHistoryRequest) (*proto.GetBalanceHistoryResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, hErr.CastInvalidRequest(err)
+	}
+
+	if !validators.ValidateGreaterOrEqualTo(1, req.StepSeconds) {
+		return nil, hErr.CastInvalidRequest(errors.New("invalid step"))
+	}
+
+	currency, err := parseCurrency(req.Currency)
+	if err != nil {
+		return nil, hErr.CastInvalidRequest(err)
+	}
+
+	reportingCurrency, err := parseReportingCurrency(req.ReportingCurrency)
+	if err != nil {
+		return nil, hErr.CastInvalidRequest(err)
+	}
+
+	resp, err := h.balanceSvc.GetBalanceHistory(ctx, userID, currency,
+		time.Unix(req.From, 0).UTC(), time.Unix(req.To, 0).UTC(), time.Duration(req.StepSeconds)*time.Second, reportingCurrency)
+	if err != nil {
+		prErr, isInternal := hErr.ParseSvcErrToProto(err)
+		if isInternal {
+			log.Println(err.Error())
+		}
+		return nil, prErr
+	}
+
+	points := make([]*proto.BalancePoint, 0, len(resp))
+	for _, p := range resp {
+		points = append(points, convertBalancePointModelToProto(p))
+	}
+
+	return &proto.GetBalanceHistoryResponse{
+		Points: points,
+	}, nil
+}
+
+func (h *Handler) ListPostings(ctx context.Context, req *proto.ListPostingsRequest) (*proto.ListPostingsResponse, error) {
+	if !validators.ValidateGreaterOrEqualTo(1, req.Limit) || !validators.ValidateGreaterOrEqualTo(0, req.Offset) {
+		return nil, hErr.CastInvalidRequest(errors.New("invalid offset or limit"))
+	}
+
+	currency, err := parseCurrency(req.Currency)
+	if err != nil {
+		return nil, hErr.CastInvalidRequest(err)
+	}
+
+	reportingCurrency, err := parseReportingCurrency(req.ReportingCurrency)
+	if err != nil {
+		return nil, hErr.CastInvalidRequest(err)
+	}
+
+	resp, balance, err := h.ledgerSvc.ListPostings(ctx, req.Account, currency, req.Limit, req.Offset, reportingCurrency)
+	if err != nil {
+		prErr, isInternal := hErr.ParseSvcErrToProto(err)
+		if isInternal {
+			log.Println(err.Error())
+		}
+
+		return nil, prErr
+	}
+
+	postings := make([]*proto.Posting, 0, len(resp))
+	for _, p := range resp {
+		postings = append(postings, convertPostingModelToProto(p))
+	}
+
+	return &proto.ListPostingsResponse{
+		Postings:         postings,
+		Balance:          balance.Balance,
+		ReportingBalance: convertConversionModelToProto(balance.Reporting),
 	}, nil
 }
@@ -3,6 +3,8 @@ package handlers
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math/big"
 	"testing"
 	"time"
 
@@ -19,6 +21,7 @@ import (
 
 func TestGetTransactionByID(t *testing.T) {
 	id := uuid.New()
+	originalID := uuid.New()
 
 	tests := []struct {
 		name               string
@@ -49,7 +52,12 @@ func TestGetTransactionByID(t *testing.T) {
 			},
 			expectedResp: &proto.GetTransactionByIDResponse{
 				Transaction: &proto.Transaction{
-					Id: id.String(),
+					Id:         id.String(),
+					OriginalId: originalID.String(),
+				},
+				Chain: []*proto.Transaction{
+					{Id: originalID.String(), Status: proto.Status_Voided},
+					{Id: id.String(), OriginalId: originalID.String(), Status: proto.Status_Completed},
 				},
 			},
 			expectedStatusCode: codes.OK,
@@ -59,17 +67,23 @@ func TestGetTransactionByID(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			cliMock := mocks.NewMockTransactionService(t)
-			h := New(cliMock)
+			h := New(cliMock, nil, nil, nil)
 
 			switch {
 			case test.expectedStatusCode == codes.InvalidArgument:
 			case test.expectedStatusCode == codes.NotFound:
-				cliMock.On("GetByID", context.Background(), uuid.MustParse(test.req.GetId())).Return(nil, svcerr.ErrNotFound)
+				cliMock.On("GetChain", context.Background(), uuid.MustParse(test.req.GetId())).Return(nil, svcerr.ErrNotFound)
 			case test.expectedStatusCode == codes.OK:
 				returnedTx := &models.Transaction{
-					ID: id,
+					ID:         id,
+					OriginalID: &originalID,
+					Status:     models.Completed,
+				}
+				chain := []models.Transaction{
+					{ID: originalID, Status: models.Voided},
+					*returnedTx,
 				}
-				cliMock.On("GetByID", context.Background(), uuid.MustParse(test.req.GetId())).Return(returnedTx, nil)
+				cliMock.On("GetChain", context.Background(), id).Return(chain, nil)
 			}
 
 			resp, err := h.GetTransactionByID(context.Background(), test.req)
@@ -77,6 +91,12 @@ func TestGetTransactionByID(t *testing.T) {
 				assert.Nil(t, nil)
 			} else {
 				assert.Equal(t, test.expectedResp.Transaction.Id, resp.Transaction.Id, test.name)
+				assert.Equal(t, test.expectedResp.Transaction.OriginalId, resp.Transaction.OriginalId)
+				assert.Len(t, resp.Chain, len(test.expectedResp.Chain))
+				for i := range resp.Chain {
+					assert.Equal(t, test.expectedResp.Chain[i].Id, resp.Chain[i].Id)
+					assert.Equal(t, test.expectedResp.Chain[i].Status, resp.Chain[i].Status)
+				}
 			}
 
 			assert.Equal(t, test.expectedStatusCode, status.Code(err))
@@ -86,6 +106,74 @@ func TestGetTransactionByID(t *testing.T) {
 	}
 }
 
+func TestHandler_BatchGetTransactions(t *testing.T) {
+	ctx := context.Background()
+	id1, id2 := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name        string
+		req         *proto.BatchGetTransactionsRequest
+		mockSetup   func(txSvc *mocks.MockTransactionService)
+		wantCode    codes.Code
+		wantIDs     []string
+		wantMissing []string
+	}{
+		{
+			name: "found and missing",
+			req:  &proto.BatchGetTransactionsRequest{Ids: []string{id1.String(), id2.String()}},
+			mockSetup: func(txSvc *mocks.MockTransactionService) {
+				txSvc.On("BatchGet", ctx, []uuid.UUID{id1, id2}, "").
+					Return([]models.Transaction{{ID: id1, Type: models.Bet, Amount: 100}}, []uuid.UUID{id2}, nil)
+			},
+			wantCode:    codes.OK,
+			wantIDs:     []string{id1.String()},
+			wantMissing: []string{id2.String()},
+		},
+		{
+			name:      "invalid id",
+			req:       &proto.BatchGetTransactionsRequest{Ids: []string{id1.String(), "NOT-A-UUID"}},
+			mockSetup: func(txSvc *mocks.MockTransactionService) {},
+			wantCode:  codes.InvalidArgument,
+		},
+		{
+			name:      "invalid reporting currency",
+			req:       &proto.BatchGetTransactionsRequest{Ids: []string{id1.String()}, ReportingCurrency: "eur"},
+			mockSetup: func(txSvc *mocks.MockTransactionService) {},
+			wantCode:  codes.InvalidArgument,
+		},
+		{
+			name: "over the batch size",
+			req:  &proto.BatchGetTransactionsRequest{Ids: []string{id1.String(), id2.String()}},
+			mockSetup: func(txSvc *mocks.MockTransactionService) {
+				txSvc.On("BatchGet", ctx, []uuid.UUID{id1, id2}, "").
+					Return(nil, nil, fmt.Errorf("%w: batch is limited to 1 ids", svcerr.ErrBadField))
+			},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txSvc := mocks.NewMockTransactionService(t)
+			tt.mockSetup(txSvc)
+
+			resp, err := New(txSvc, nil, nil, nil).BatchGetTransactions(ctx, tt.req)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			if err != nil {
+				return
+			}
+
+			ids := make([]string, 0, len(resp.Transactions))
+			for _, tr := range resp.Transactions {
+				ids = append(ids, tr.Id)
+			}
+
+			assert.Equal(t, tt.wantIDs, ids)
+			assert.Equal(t, tt.wantMissing, resp.MissingIds)
+		})
+	}
+}
+
 func TestHandler_GetTransactionByFilters(t *testing.T) {
 	ctx := context.Background()
 	now := time.Now()
@@ -122,6 +210,7 @@ func TestHandler_GetTransactionByFilters(t *testing.T) {
 		mockSetup     func(txSvc *mocks.MockTransactionService)
 		wantErr       bool
 		wantTotal     int64
+		wantExact     bool
 		wantTxCount   int
 		expectedErrFn func(error) bool
 	}{
@@ -131,25 +220,66 @@ func TestHandler_GetTransactionByFilters(t *testing.T) {
 				Filters: &proto.Filters{
 					Type: proto.TransactionType_Bet,
 				},
-				OrderBy: "amount",
-				Limit:   10,
-				Offset:  0,
+				OrderBy: []*proto.SortField{
+					{Field: proto.SortKey_SortAmount, Direction: proto.SortDirection_Descending},
+					{Field: proto.SortKey_SortTime},
+				},
+				Limit:     10,
+				Offset:    0,
+				CountMode: proto.CountMode_CountExact,
 			},
 			mockSetup: func(txSvc *mocks.MockTransactionService) {
-				txSvc.On("GetAll", mock.Anything, mock.Anything, "amount", int64(10), int64(0)).
-					Return([]models.Transaction{tx1, tx2}, int64(2), nil)
+				txSvc.On("GetAll", mock.Anything, mock.Anything, []models.Sort{{Field: models.SortByAmount, Desc: true}, {Field: models.SortByTime}}, int64(10), int64(0), "").
+					Return([]models.Transaction{tx1, tx2}, nil)
+				txSvc.On("Count", mock.Anything, mock.Anything, models.CountExact).
+					Return(models.Total{Count: 57, Exact: true}, nil)
 			},
 			wantErr:     false,
-			wantTotal:   2,
+			wantTotal:   57,
+			wantExact:   true,
 			wantTxCount: 2,
 		},
+		{
+			name: "estimated count by default",
+			req: &proto.GetTransactionByFiltersRequest{
+				Filters: &proto.Filters{},
+				Limit:   10,
+			},
+			mockSetup: func(txSvc *mocks.MockTransactionService) {
+				txSvc.On("GetPage", mock.Anything, mock.Anything, (*models.Cursor)(nil), int64(10), "").
+					Return([]models.Transaction{tx1}, nil, nil)
+				txSvc.On("Count", mock.Anything, mock.Anything, models.CountEstimated).
+					Return(models.Total{Count: 1200000}, nil)
+			},
+			wantErr:     false,
+			wantTotal:   1200000,
+			wantExact:   false,
+			wantTxCount: 1,
+		},
+		{
+			name: "count fails -> mapped error",
+			req: &proto.GetTransactionByFiltersRequest{
+				Filters: &proto.Filters{},
+				Limit:   10,
+			},
+			mockSetup: func(txSvc *mocks.MockTransactionService) {
+				txSvc.On("GetPage", mock.Anything, mock.Anything, (*models.Cursor)(nil), int64(10), "").
+					Return([]models.Transaction{tx1}, nil, nil)
+				txSvc.On("Count", mock.Anything, mock.Anything, models.CountEstimated).
+					Return(models.Total{}, errors.New("statement timeout"))
+			},
+			wantErr: true,
+			expectedErrFn: func(err error) bool {
+				return status.Code(err) == codes.Internal
+			},
+		},
 		{
 			name: "invalid filters returns CastInvalidRequest",
 			req: &proto.GetTransactionByFiltersRequest{
 				Filters: &proto.Filters{
 					UserId: "invalid-uuid",
 				},
-				OrderBy: "amount",
+				OrderBy: []*proto.SortField{{Field: proto.SortKey_SortAmount}},
 				Limit:   10,
 				Offset:  0,
 			},
@@ -157,6 +287,39 @@ func TestHandler_GetTransactionByFilters(t *testing.T) {
 			wantErr:       true,
 			expectedErrFn: invalidArgFunc,
 		},
+		{
+			name: "invalid filter expression returns CastInvalidRequest",
+			req: &proto.GetTransactionByFiltersRequest{
+				Filters: &proto.Filters{},
+				Filter:  &proto.FilterExpr{Node: &proto.FilterExpr_And{And: &proto.FilterList{}}},
+				Limit:   10,
+			},
+			mockSetup:     func(txSvc *mocks.MockTransactionService) {},
+			wantErr:       true,
+			expectedErrFn: invalidArgFunc,
+		},
+		{
+			name: "unknown sort field returns CastInvalidRequest",
+			req: &proto.GetTransactionByFiltersRequest{
+				Filters: &proto.Filters{},
+				OrderBy: []*proto.SortField{{Field: proto.SortKey_UnknownSortKey}},
+				Limit:   10,
+			},
+			mockSetup:     func(txSvc *mocks.MockTransactionService) {},
+			wantErr:       true,
+			expectedErrFn: invalidArgFunc,
+		},
+		{
+			name: "unknown sort direction returns CastInvalidRequest",
+			req: &proto.GetTransactionByFiltersRequest{
+				Filters: &proto.Filters{},
+				OrderBy: []*proto.SortField{{Field: proto.SortKey_SortAmount, Direction: proto.SortDirection(7)}},
+				Limit:   10,
+			},
+			mockSetup:     func(txSvc *mocks.MockTransactionService) {},
+			wantErr:       true,
+			expectedErrFn: invalidArgFunc,
+		},
 		{
 			name: "invalid limit/offset returns CastInvalidRequest",
 			req: &proto.GetTransactionByFiltersRequest{
@@ -168,16 +331,60 @@ func TestHandler_GetTransactionByFilters(t *testing.T) {
 			wantErr:       true,
 			expectedErrFn: invalidArgFunc,
 		},
+		{
+			name: "limit over the cap returns CastInvalidRequest",
+			req: &proto.GetTransactionByFiltersRequest{
+				Filters: &proto.Filters{},
+				Limit:   1001,
+			},
+			mockSetup:     func(txSvc *mocks.MockTransactionService) {},
+			wantErr:       true,
+			expectedErrFn: invalidArgFunc,
+		},
+		{
+			name: "sorted listing service error -> mapped error",
+			req: &proto.GetTransactionByFiltersRequest{
+				Filters: &proto.Filters{},
+				OrderBy: []*proto.SortField{{Field: proto.SortKey_SortAmount}},
+				Limit:   10,
+			},
+			mockSetup: func(txSvc *mocks.MockTransactionService) {
+				txSvc.On("GetAll", mock.Anything, mock.Anything, []models.Sort{{Field: models.SortByAmount}}, int64(10), int64(0), "").
+					Return(nil, errors.New("internal service error"))
+			},
+			wantErr: true,
+			expectedErrFn: func(err error) bool {
+				st, ok := status.FromError(err)
+				return ok && st.Code() == codes.Internal
+			},
+		},
 		{
 			name: "service returns error -> mapped error",
+			req: &proto.GetTransactionByFiltersRequest{
+				Filters: &proto.Filters{},
+				Limit:   10,
+				Offset:  10,
+			},
+			mockSetup: func(txSvc *mocks.MockTransactionService) {
+				txSvc.On("GetAll", mock.Anything, mock.Anything, []models.Sort{}, int64(10), int64(10), "").
+					Return(nil, errors.New("internal service error"))
+			},
+			wantErr: true,
+			expectedErrFn: func(err error) bool {
+				st, ok := status.FromError(err)
+				return ok && st.Code() == codes.Internal
+			},
+		},
+		{
+			name: "page service returns error -> mapped error",
 			req: &proto.GetTransactionByFiltersRequest{
 				Filters: &proto.Filters{},
 				Limit:   10,
 				Offset:  0,
 			},
 			mockSetup: func(txSvc *mocks.MockTransactionService) {
-				txSvc.On("GetAll", mock.Anything, mock.Anything, "", int64(10), int64(0)).
-					Return(nil, int64(0), errors.New("internal service error"))
+				txSvc.On("GetPage", mock.Anything, mock.Anything, (*models.Cursor)(nil), int64(10), "").
+					Return(nil, nil, errors.New("internal service error"))
 			},
 			wantErr: true,
 			expectedErrFn: func(err error) bool {
@@ -208,6 +415,7 @@ func TestHandler_GetTransactionByFilters(t *testing.T) {
 				assert.NoError(t, err)
 				assert.NotNil(t, resp)
 				assert.Equal(t, tt.wantTotal, resp.Total)
+				assert.Equal(t, tt.wantExact, resp.TotalExact)
 				assert.Len(t, resp.Transaction, tt.wantTxCount)
 			}
 
@@ -215,3 +423,438 @@ func TestHandler_GetTransactionByFilters(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_GetTransactionByFiltersKeyset(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	tx := models.Transaction{ID: uuid.New(), UserID: uuid.New(), Type: models.Bet, Amount: 100, TransactionTime: now}
+	next := models.CursorOf(tx)
+	after := models.Cursor{Time: now.Add(time.Minute), ID: uuid.New()}
+
+	txSvc := mocks.NewMockTransactionService(t)
+	txSvc.On("GetPage", ctx, models.TransactionFilter{}, (*models.Cursor)(nil), int64(1), "").Return([]models.Transaction{tx}, &next, nil).Once()
+	txSvc.On("GetPage", ctx, models.TransactionFilter{}, mock.MatchedBy(func(c *models.Cursor) bool {
+		return c != nil && c.ID == after.ID && c.Time.Equal(after.Time)
+	}), int64(1), "").Return(nil, nil, nil).Once()
+	txSvc.On("Count", ctx, models.TransactionFilter{}, models.CountEstimated).Return(models.Total{Count: 1}, nil).Twice()
+
+	h := New(txSvc, nil, nil, nil)
+
+	resp, err := h.GetTransactionByFilters(ctx, &proto.GetTransactionByFiltersRequest{Limit: 1})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Transaction, 1)
+	assert.Equal(t, next.Encode(), resp.NextPageToken)
+
+	resp, err = h.GetTransactionByFilters(ctx, &proto.GetTransactionByFiltersRequest{Limit: 1, PageToken: after.Encode()})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Transaction)
+	assert.Empty(t, resp.NextPageToken)
+
+	_, err = h.GetTransactionByFilters(ctx, &proto.GetTransactionByFiltersRequest{Limit: 1, PageToken: "garbage"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = h.GetTransactionByFilters(ctx, &proto.GetTransactionByFiltersRequest{Limit: 1, Offset: 10, PageToken: next.Encode()})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestHandler_GetTransactionByFiltersOffsetContinuesFirstPage(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	// Listed in the keyset order, which the repository also uses for offset pages without a sort:
+	// transactions sharing a timestamp are ordered by id descending.
+	listing := []models.Transaction{
+		{ID: uuid.MustParse("00000000-0000-0000-0000-000000000001"), TransactionTime: now.Add(2 * time.Minute)},
+		{ID: uuid.MustParse("00000000-0000-0000-0000-000000000003"), TransactionTime: now.Add(time.Minute)},
+		{ID: uuid.MustParse("00000000-0000-0000-0000-000000000002"), TransactionTime: now.Add(time.Minute)},
+		{ID: uuid.MustParse("00000000-0000-0000-0000-000000000005"), TransactionTime: now},
+		{ID: uuid.MustParse("00000000-0000-0000-0000-000000000004"), TransactionTime: now},
+	}
+	next := models.CursorOf(listing[1])
+
+	txSvc := mocks.NewMockTransactionService(t)
+	txSvc.On("GetPage", ctx, models.TransactionFilter{}, (*models.Cursor)(nil), int64(2), "").Return(listing[:2], &next, nil).Once()
+	txSvc.On("GetAll", ctx, models.TransactionFilter{}, []models.Sort{}, int64(2), int64(2), "").Return(listing[2:4], nil).Once()
+	txSvc.On("Count", ctx, models.TransactionFilter{}, models.CountEstimated).Return(models.Total{Count: 5}, nil).Twice()
+
+	h := New(txSvc, nil, nil, nil)
+
+	first, err := h.GetTransactionByFilters(ctx, &proto.GetTransactionByFiltersRequest{Limit: 2})
+	assert.NoError(t, err)
+
+	second, err := h.GetTransactionByFilters(ctx, &proto.GetTransactionByFiltersRequest{Limit: 2, Offset: 2})
+	assert.NoError(t, err)
+
+	got := make([]string, 0, 4)
+	for _, tx := range append(first.Transaction, second.Transaction...) {
+		got = append(got, tx.Id)
+	}
+
+	want := make([]string, 0, 4)
+	for _, tx := range listing[:4] {
+		want = append(want, tx.ID.String())
+	}
+
+	assert.Equal(t, want, got)
+}
+
+func TestHandler_GetTransactionByFiltersReportingCurrency(t *testing.T) {
+	ctx := context.Background()
+	at := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+	rateDate := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	tx := models.Transaction{
+		ID: uuid.New(), UserID: uuid.New(), Type: models.Bet, Amount: 10400, Currency: "USD", TransactionTime: at,
+		Reporting: &models.Conversion{Currency: "EUR", Amount: 10000, Rate: big.NewRat(100, 104), RateDate: rateDate},
+	}
+
+	txSvc := mocks.NewMockTransactionService(t)
+	txSvc.On("GetPage", ctx, mock.Anything, (*models.Cursor)(nil), int64(10), "EUR").Return([]models.Transaction{tx}, nil, nil).Once()
+	txSvc.On("Count", ctx, mock.Anything, models.CountEstimated).Return(models.Total{Count: 1}, nil).Once()
+
+	h := New(txSvc, nil, nil, nil)
+
+	resp, err := h.GetTransactionByFilters(ctx, &proto.GetTransactionByFiltersRequest{Limit: 10, ReportingCurrency: "EUR"})
+	assert.NoError(t, err)
+	assert.Equal(t, &proto.Conversion{
+		Currency:         "EUR",
+		Amount:           10000,
+		CurrencyExponent: 2,
+		Rate:             "0.9615384615",
+		RateDate:         rateDate.Unix(),
+	}, resp.Transaction[0].Reporting)
+
+	txSvc.On("GetPage", ctx, mock.Anything, (*models.Cursor)(nil), int64(10), "EUR").
+		Return(nil, nil, fmt.Errorf("%w: no USD rate", svcerr.ErrNotFound)).Once()
+
+	_, err = h.GetTransactionByFilters(ctx, &proto.GetTransactionByFiltersRequest{Limit: 10, ReportingCurrency: "EUR"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = h.GetTransactionByFilters(ctx, &proto.GetTransactionByFiltersRequest{Limit: 10, ReportingCurrency: "eur"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestHandler_GetRound(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	tests := []struct {
+		name      string
+		req       *proto.GetRoundRequest
+		mockSetup func(roundSvc *mocks.MockRoundService)
+		wantCode  codes.Code
+	}{
+		{
+			name:      "missing id",
+			req:       &proto.GetRoundRequest{},
+			mockSetup: func(roundSvc *mocks.MockRoundService) {},
+			wantCode:  codes.InvalidArgument,
+		},
+		{
+			name: "not found",
+			req:  &proto.GetRoundRequest{Id: "round-1", Provider: "pragmatic"},
+			mockSetup: func(roundSvc *mocks.MockRoundService) {
+				roundSvc.On("GetRound", ctx, "pragmatic", "round-1").Return(nil, svcerr.ErrNotFound)
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "found",
+			req:  &proto.GetRoundRequest{Id: "round-1", Provider: "pragmatic"},
+			mockSetup: func(roundSvc *mocks.MockRoundService) {
+				roundSvc.On("GetRound", ctx, "pragmatic", "round-1").Return(&models.Round{ID: "round-1", UserID: userID, Status: models.RoundOpen, Orphaned: true}, nil)
+			},
+			wantCode: codes.OK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roundSvc := mocks.NewMockRoundService(t)
+			tt.mockSetup(roundSvc)
+
+			resp, err := New(nil, roundSvc, nil, nil).GetRound(ctx, tt.req)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+
+			if tt.wantCode == codes.OK {
+				assert.Equal(t, "round-1", resp.Round.Id)
+				assert.Equal(t, userID.String(), resp.Round.UserId)
+				assert.Equal(t, proto.RoundStatus_Open, resp.Round.Status)
+				assert.True(t, resp.Round.Orphaned)
+			}
+		})
+	}
+}
+
+func TestHandler_ListOpenRounds(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	tests := []struct {
+		name      string
+		req       *proto.ListOpenRoundsRequest
+		mockSetup func(roundSvc *mocks.MockRoundService)
+		wantCode  codes.Code
+		wantLen   int
+	}{
+		{
+			name:      "invalid user id",
+			req:       &proto.ListOpenRoundsRequest{UserId: "invalid", Limit: 10},
+			mockSetup: func(roundSvc *mocks.MockRoundService) {},
+			wantCode:  codes.InvalidArgument,
+		},
+		{
+			name:      "invalid limit",
+			req:       &proto.ListOpenRoundsRequest{Limit: 0},
+			mockSetup: func(roundSvc *mocks.MockRoundService) {},
+			wantCode:  codes.InvalidArgument,
+		},
+		{
+			name: "orphaned rounds of a user",
+			req:  &proto.ListOpenRoundsRequest{UserId: userID.String(), OrphanedOnly: true, Limit: 10},
+			mockSetup: func(roundSvc *mocks.MockRoundService) {
+				roundSvc.On("ListOpenRounds", ctx, models.RoundFilter{UserID: &userID}, true, int64(10), int64(0)).
+					Return([]models.Round{{ID: "round-1", UserID: userID}, {ID: "round-2", UserID: userID}}, nil)
+			},
+			wantCode: codes.OK,
+			wantLen:  2,
+		},
+		{
+			name: "service error",
+			req:  &proto.ListOpenRoundsRequest{Limit: 10},
+			mockSetup: func(roundSvc *mocks.MockRoundService) {
+				roundSvc.On("ListOpenRounds", ctx, models.RoundFilter{}, false, int64(10), int64(0)).
+					Return(nil, errors.New("internal service error"))
+			},
+			wantCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roundSvc := mocks.NewMockRoundService(t)
+			tt.mockSetup(roundSvc)
+
+			resp, err := New(nil, roundSvc, nil, nil).ListOpenRounds(ctx, tt.req)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+
+			if tt.wantCode == codes.OK {
+				assert.Len(t, resp.Rounds, tt.wantLen)
+			}
+		})
+	}
+}
+
+func TestHandler_GetUserBalance(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		req       *proto.GetUserBalanceRequest
+		mockSetup func(balanceSvc *mocks.MockBalanceService)
+		wantCode  codes.Code
+	}{
+		{
+			name:      "invalid user id",
+			req:       &proto.GetUserBalanceRequest{UserId: "invalid"},
+			mockSetup: func(balanceSvc *mocks.MockBalanceService) {},
+			wantCode:  codes.InvalidArgument,
+		},
+		{
+			name: "user without transactions",
+			req:  &proto.GetUserBalanceRequest{UserId: userID.String()},
+			mockSetup: func(balanceSvc *mocks.MockBalanceService) {
+				balanceSvc.On("GetUserBalance", ctx, userID, "EUR", "").Return(nil, svcerr.ErrNotFound)
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "found",
+			req:  &proto.GetUserBalanceRequest{UserId: userID.String()},
+			mockSetup: func(balanceSvc *mocks.MockBalanceService) {
+				balanceSvc.On("GetUserBalance", ctx, userID, "EUR", "").
+					Return(&models.Balance{UserID: userID, Balance: 750, CreditTotal: 1050, DebitTotal: 300, TransactionCount: 3, LastTransactionTime: now}, nil)
+			},
+			wantCode: codes.OK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			balanceSvc := mocks.NewMockBalanceService(t)
+			tt.mockSetup(balanceSvc)
+
+			resp, err := New(nil, nil, balanceSvc, nil).GetUserBalance(ctx, tt.req)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+
+			if tt.wantCode == codes.OK {
+				assert.Equal(t, userID.String(), resp.Balance.UserId)
+				assert.Equal(t, int64(750), resp.Balance.Balance)
+				assert.Equal(t, int64(1050), resp.Balance.CreditTotal)
+				assert.Equal(t, int64(300), resp.Balance.DebitTotal)
+				assert.Equal(t, int64(3), resp.Balance.TransactionCount)
+				assert.Equal(t, now.Unix(), resp.Balance.LastTransactionTime)
+			}
+		})
+	}
+}
+
+func TestHandler_GetBalanceAt(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	at := time.Date(2025, 1, 1, 14, 3, 0, 0, time.UTC)
+
+	balanceSvc := mocks.NewMockBalanceService(t)
+	balanceSvc.On("GetBalanceAt", ctx, userID, "EUR", at, "").Return(&models.BalancePoint{At: at, Balance: 120, TransactionCount: 4}, nil)
+
+	h := New(nil, nil, balanceSvc, nil)
+
+	resp, err := h.GetBalanceAt(ctx, &proto.GetBalanceAtRequest{UserId: userID.String(), Timestamp: at.Unix()})
+	assert.NoError(t, err)
+	assert.Equal(t, at.Unix(), resp.Balance.Timestamp)
+	assert.Equal(t, int64(120), resp.Balance.Balance)
+	assert.Equal(t, int64(4), resp.Balance.TransactionCount)
+
+	_, err = h.GetBalanceAt(ctx, &proto.GetBalanceAtRequest{UserId: "invalid"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestHandler_GetBalanceAtReportingCurrency(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	at := time.Date(2025, 1, 1, 14, 3, 0, 0, time.UTC)
+
+	balanceSvc := mocks.NewMockBalanceService(t)
+	balanceSvc.On("GetBalanceAt", ctx, userID, "JPY", at, "EUR").Return(&models.BalancePoint{
+		At: at, Currency: "JPY", Balance: 1625,
+		Reporting: &models.Conversion{Currency: "EUR", Amount: 1000, Rate: big.NewRat(2, 325), RateDate: at.Truncate(24 * time.Hour)},
+	}, nil)
+
+	resp, err := New(nil, nil, balanceSvc, nil).GetBalanceAt(ctx, &proto.GetBalanceAtRequest{
+		UserId:            userID.String(),
+		Timestamp:         at.Unix(),
+		Currency:          "JPY",
+		ReportingCurrency: "EUR",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1000), resp.Balance.Reporting.Amount)
+	assert.Equal(t, "0.0061538462", resp.Balance.Reporting.Rate)
+}
+
+func TestHandler_GetBalanceHistory(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(2 * time.Hour)
+
+	tests := []struct {
+		name      string
+		req       *proto.GetBalanceHistoryRequest
+		mockSetup func(balanceSvc *mocks.MockBalanceService)
+		wantCode  codes.Code
+		wantLen   int
+	}{
+		{
+			name:      "invalid user id",
+			req:       &proto.GetBalanceHistoryRequest{UserId: "invalid", From: from.Unix(), To: to.Unix(), StepSeconds: 3600},
+			mockSetup: func(balanceSvc *mocks.MockBalanceService) {},
+			wantCode:  codes.InvalidArgument,
+		},
+		{
+			name:      "missing step",
+			req:       &proto.GetBalanceHistoryRequest{UserId: userID.String(), From: from.Unix(), To: to.Unix()},
+			mockSetup: func(balanceSvc *mocks.MockBalanceService) {},
+			wantCode:  codes.InvalidArgument,
+		},
+		{
+			name: "too many points",
+			req:  &proto.GetBalanceHistoryRequest{UserId: userID.String(), From: from.Unix(), To: to.Unix(), StepSeconds: 1},
+			mockSetup: func(balanceSvc *mocks.MockBalanceService) {
+				balanceSvc.On("GetBalanceHistory", ctx, userID, "EUR", from, to, time.Second, "").Return(nil, svcerr.ErrBadField)
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "hourly history",
+			req:  &proto.GetBalanceHistoryRequest{UserId: userID.String(), From: from.Unix(), To: to.Unix(), StepSeconds: 3600},
+			mockSetup: func(balanceSvc *mocks.MockBalanceService) {
+				balanceSvc.On("GetBalanceHistory", ctx, userID, "EUR", from, to, time.Hour, "").Return([]models.BalancePoint{
+					{At: from, Balance: 100},
+					{At: from.Add(time.Hour), Balance: 70},
+					{At: to, Balance: 120},
+				}, nil)
+			},
+			wantCode: codes.OK,
+			wantLen:  3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			balanceSvc := mocks.NewMockBalanceService(t)
+			tt.mockSetup(balanceSvc)
+
+			resp, err := New(nil, nil, balanceSvc, nil).GetBalanceHistory(ctx, tt.req)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+
+			if tt.wantCode == codes.OK {
+				assert.Len(t, resp.Points, tt.wantLen)
+			}
+		})
+	}
+}
+
+func TestHandler_ListPostings(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		req       *proto.ListPostingsRequest
+		mockSetup func(ledgerSvc *mocks.MockLedgerService)
+		wantCode  codes.Code
+		wantLen   int
+	}{
+		{
+			name:      "invalid limit",
+			req:       &proto.ListPostingsRequest{Account: models.HouseAccount},
+			mockSetup: func(ledgerSvc *mocks.MockLedgerService) {},
+			wantCode:  codes.InvalidArgument,
+		},
+		{
+			name: "unknown account",
+			req:  &proto.ListPostingsRequest{Account: "casino", Limit: 10},
+			mockSetup: func(ledgerSvc *mocks.MockLedgerService) {
+				ledgerSvc.On("ListPostings", ctx, "casino", "EUR", int64(10), int64(0), "").Return(nil, models.AccountBalance{}, svcerr.ErrBadField)
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "house postings",
+			req:  &proto.ListPostingsRequest{Account: models.HouseAccount, Limit: 10},
+			mockSetup: func(ledgerSvc *mocks.MockLedgerService) {
+				ledgerSvc.On("ListPostings", ctx, models.HouseAccount, "EUR", int64(10), int64(0), "").
+					Return([]models.Posting{{ID: uuid.New(), TransactionID: uuid.New(), Account: models.HouseAccount, Amount: 100}}, models.AccountBalance{Balance: 100}, nil)
+			},
+			wantCode: codes.OK,
+			wantLen:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ledgerSvc := mocks.NewMockLedgerService(t)
+			tt.mockSetup(ledgerSvc)
+
+			resp, err := New(nil, nil, nil, ledgerSvc).ListPostings(ctx, tt.req)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+
+			if tt.wantCode == codes.OK {
+				assert.Len(t, resp.Postings, tt.wantLen)
+				assert.Equal(t, int64(100), resp.Balance)
+			}
+		})
+	}
+}
@@ -1,7 +1,11 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
+	"math/big"
+	"strings"
+	"time"
 
 	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/models"
 	proto "github.com/e1esm/casino-transaction-system/tx-manager/src/internal/proto/tx-manager"
@@ -9,13 +13,74 @@ import (
 )
 
 var txTypeProtoToModel = map[proto.TransactionType]models.TransactionType{
-	proto.TransactionType_Bet: models.Bet,
-	proto.TransactionType_Win: models.Win,
+	proto.TransactionType_Bet:             models.Bet,
+	proto.TransactionType_Win:             models.Win,
+	proto.TransactionType_Deposit:         models.Deposit,
+	proto.TransactionType_Withdrawal:      models.Withdrawal,
+	proto.TransactionType_Refund:          models.Refund,
+	proto.TransactionType_Rollback:        models.Rollback,
+	proto.TransactionType_BonusCredit:     models.BonusCredit,
+	proto.TransactionType_BonusConversion: models.BonusConversion,
+	proto.TransactionType_JackpotWin:      models.JackpotWin,
 }
 
 var txTypeModelToProto = map[models.TransactionType]proto.TransactionType{
-	models.Bet: proto.TransactionType_Bet,
-	models.Win: proto.TransactionType_Win,
+	models.Bet:             proto.TransactionType_Bet,
+	models.Win:             proto.TransactionType_Win,
+	models.Deposit:         proto.TransactionType_Deposit,
+	models.Withdrawal:      proto.TransactionType_Withdrawal,
+	models.Refund:          proto.TransactionType_Refund,
+	models.Rollback:        proto.TransactionType_Rollback,
+	models.BonusCredit:     proto.TransactionType_BonusCredit,
+	models.BonusConversion: proto.TransactionType_BonusConversion,
+	models.JackpotWin:      proto.TransactionType_JackpotWin,
+}
+
+var filterFieldProtoToModel = map[proto.FilterField]models.FilterField{
+	proto.FilterField_FilterId:        models.FilterID,
+	proto.FilterField_FilterUserId:    models.FilterUserID,
+	proto.FilterField_FilterType:      models.FilterType,
+	proto.FilterField_FilterAmount:    models.FilterAmount,
+	proto.FilterField_FilterCurrency:  models.FilterCurrency,
+	proto.FilterField_FilterDate:      models.FilterDate,
+	proto.FilterField_FilterRoundId:   models.FilterRoundID,
+	proto.FilterField_FilterGameId:    models.FilterGameID,
+	proto.FilterField_FilterProvider:  models.FilterProvider,
+	proto.FilterField_FilterSessionId: models.FilterSessionID,
+	proto.FilterField_FilterChannel:   models.FilterChannel,
+}
+
+var comparisonOpProtoToModel = map[proto.ComparisonOp]models.FilterOp{
+	proto.ComparisonOp_Equal:          models.OpEqual,
+	proto.ComparisonOp_NotEqual:       models.OpNotEqual,
+	proto.ComparisonOp_Less:           models.OpLess,
+	proto.ComparisonOp_LessOrEqual:    models.OpLessOrEqual,
+	proto.ComparisonOp_Greater:        models.OpGreater,
+	proto.ComparisonOp_GreaterOrEqual: models.OpGreaterOrEqual,
+	proto.ComparisonOp_In:             models.OpIn,
+	proto.ComparisonOp_NotIn:          models.OpNotIn,
+}
+
+var sortKeyProtoToModel = map[proto.SortKey]models.SortField{
+	proto.SortKey_SortUserId: models.SortByUserID,
+	proto.SortKey_SortType:   models.SortByType,
+	proto.SortKey_SortAmount: models.SortByAmount,
+	proto.SortKey_SortTime:   models.SortByTime,
+}
+
+var directionModelToProto = map[models.Direction]proto.Direction{
+	models.Credit: proto.Direction_Credit,
+	models.Debit:  proto.Direction_Debit,
+}
+
+var statusModelToProto = map[models.Status]proto.Status{
+	models.Completed: proto.Status_Completed,
+	models.Voided:    proto.Status_Voided,
+}
+
+var roundStatusModelToProto = map[models.RoundStatus]proto.RoundStatus{
+	models.RoundOpen:    proto.RoundStatus_Open,
+	models.RoundSettled: proto.RoundStatus_Settled,
 }
 
 func convertTransactionsModelToProto(transactions []models.Transaction) []*proto.Transaction {
@@ -29,13 +94,30 @@ func convertTransactionsModelToProto(transactions []models.Transaction) []*proto
 }
 
 func convertTransactionModelToProto(tr models.Transaction) *proto.Transaction {
-	return &proto.Transaction{
-		Id:        tr.ID.String(),
-		UserId:    tr.UserID.String(),
-		Type:      txTypeModelToProto[tr.Type],
-		Amount:    int64(tr.Amount),
-		Timestamp: tr.TransactionTime.Unix(),
+	resp := &proto.Transaction{
+		Id:               tr.ID.String(),
+		UserId:           tr.UserID.String(),
+		Type:             txTypeModelToProto[tr.Type],
+		Amount:           tr.Amount,
+		Currency:         tr.Currency,
+		CurrencyExponent: int32(models.Exponent(tr.Currency)),
+		Timestamp:        tr.TransactionTime.Unix(),
+		Direction:        directionModelToProto[tr.Direction()],
+		ReferenceId:      tr.ReferenceID,
+		Status:           statusModelToProto[tr.Status],
+		RoundId:          tr.RoundID,
+		GameId:           tr.GameID,
+		Provider:         tr.Provider,
+		SessionId:        tr.SessionID,
+		Channel:          tr.Channel,
+		Reporting:        convertConversionModelToProto(tr.Reporting),
 	}
+
+	if tr.OriginalID != nil {
+		resp.OriginalId = tr.OriginalID.String()
+	}
+
+	return resp
 }
 
 func convertProtoFiltersToModel(req *proto.Filters) (models.TransactionFilter, error) {
@@ -61,8 +143,311 @@ func convertProtoFiltersToModel(req *proto.Filters) (models.TransactionFilter, e
 		txType = &v
 	}
 
+	if len(req.Currency) > 0 && !models.ValidCurrency(req.Currency) {
+		return models.TransactionFilter{}, fmt.Errorf("invalid currency: %s", req.Currency)
+	}
+
+	if req.From != nil && req.To != nil && *req.From >= *req.To {
+		return models.TransactionFilter{}, errors.New("from must be before to")
+	}
+
+	if req.MinAmount != nil && req.MaxAmount != nil && *req.MinAmount > *req.MaxAmount {
+		return models.TransactionFilter{}, errors.New("min amount must not exceed max amount")
+	}
+
 	return models.TransactionFilter{
-		UserID: id,
-		Type:   txType,
+		UserID:    id,
+		Type:      txType,
+		RoundID:   optional(req.RoundId),
+		GameID:    optional(req.GameId),
+		Provider:  optional(req.Provider),
+		SessionID: optional(req.SessionId),
+		Channel:   optional(req.Channel),
+		Currency:  optional(req.Currency),
+		From:      optionalTime(req.From),
+		To:        optionalTime(req.To),
+		MinAmount: req.MinAmount,
+		MaxAmount: req.MaxAmount,
 	}, nil
 }
+
+// parseCurrency defaults an empty currency to the one of legacy transactions.
+func parseCurrency(currency string) (string, error) {
+	if len(currency) == 0 {
+		return models.DefaultCurrency, nil
+	}
+
+	if !models.ValidCurrency(currency) {
+		return "", fmt.Errorf("invalid currency: %s", currency)
+	}
+
+	return currency, nil
+}
+
+// parseReportingCurrency leaves the currency empty when no conversion was requested.
+func parseReportingCurrency(currency string) (string, error) {
+	if len(currency) > 0 && !models.ValidCurrency(currency) {
+		return "", fmt.Errorf("invalid reporting currency: %s", currency)
+	}
+
+	return currency, nil
+}
+
+// convertProtoFilterExprToModel returns a validated expression, or nil if there is none.
+func convertProtoFilterExprToModel(req *proto.FilterExpr) (*models.FilterExpr, error) {
+	if req == nil {
+		return nil, nil
+	}
+
+	expr, err := convertProtoFilterNodeToModel(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = expr.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &expr, nil
+}
+
+func convertProtoFilterNodeToModel(req *proto.FilterExpr) (models.FilterExpr, error) {
+	switch node := req.GetNode().(type) {
+	case *proto.FilterExpr_Comparison:
+		c, err := convertProtoComparisonToModel(node.Comparison)
+		if err != nil {
+			return models.FilterExpr{}, err
+		}
+
+		return models.FilterExpr{Comparison: &c}, nil
+	case *proto.FilterExpr_And:
+		operands, err := convertProtoFilterListToModel(node.And)
+		return models.FilterExpr{And: operands}, err
+	case *proto.FilterExpr_Or:
+		operands, err := convertProtoFilterListToModel(node.Or)
+		return models.FilterExpr{Or: operands}, err
+	default:
+		return models.FilterExpr{}, errors.New("empty filter node")
+	}
+}
+
+func convertProtoFilterListToModel(req *proto.FilterList) ([]models.FilterExpr, error) {
+	operands := make([]models.FilterExpr, 0, len(req.GetOperands()))
+	for _, op := range req.GetOperands() {
+		expr, err := convertProtoFilterNodeToModel(op)
+		if err != nil {
+			return nil, err
+		}
+
+		operands = append(operands, expr)
+	}
+
+	return operands, nil
+}
+
+func convertProtoComparisonToModel(req *proto.Comparison) (models.Comparison, error) {
+	field, ok := filterFieldProtoToModel[req.GetField()]
+	if !ok {
+		return models.Comparison{}, fmt.Errorf("unknown filter field: %v", req.GetField())
+	}
+
+	op, ok := comparisonOpProtoToModel[req.GetOp()]
+	if !ok {
+		return models.Comparison{}, fmt.Errorf("unknown filter operator: %v", req.GetOp())
+	}
+
+	values := make([]any, 0, len(req.GetValues()))
+	for _, v := range req.GetValues() {
+		value, err := convertProtoFilterValueToModel(field, v)
+		if err != nil {
+			return models.Comparison{}, err
+		}
+
+		values = append(values, value)
+	}
+
+	return models.Comparison{Field: field, Op: op, Values: values}, nil
+}
+
+func convertProtoFilterValueToModel(field models.FilterField, v *proto.FilterValue) (any, error) {
+	switch kind := v.GetKind().(type) {
+	case *proto.FilterValue_Integer:
+		if field == models.FilterAmount {
+			return kind.Integer, nil
+		}
+	case *proto.FilterValue_Time:
+		if field == models.FilterDate {
+			return time.Unix(kind.Time, 0).UTC(), nil
+		}
+	case *proto.FilterValue_Text:
+		switch field {
+		case models.FilterID, models.FilterUserID:
+			id, err := uuid.Parse(kind.Text)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", field, err)
+			}
+
+			return id, nil
+		case models.FilterType:
+			return models.TransactionType(kind.Text), nil
+		case models.FilterAmount, models.FilterDate:
+			// need an integer and a time.
+		default:
+			return kind.Text, nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid %s value", field)
+}
+
+func convertProtoSortToModel(fields []*proto.SortField) ([]models.Sort, error) {
+	sort := make([]models.Sort, 0, len(fields))
+	for _, f := range fields {
+		field, ok := sortKeyProtoToModel[f.GetField()]
+		if !ok {
+			return nil, fmt.Errorf("unknown sort field: %v", f.GetField())
+		}
+
+		switch f.GetDirection() {
+		case proto.SortDirection_Ascending, proto.SortDirection_Descending:
+		default:
+			return nil, fmt.Errorf("unknown sort direction: %v", f.GetDirection())
+		}
+
+		sort = append(sort, models.Sort{Field: field, Desc: f.GetDirection() == proto.SortDirection_Descending})
+	}
+
+	return sort, nil
+}
+
+func convertProtoCountModeToModel(mode proto.CountMode) models.CountMode {
+	if mode == proto.CountMode_CountExact {
+		return models.CountExact
+	}
+
+	return models.CountEstimated
+}
+
+func optional(s string) *string {
+	if len(s) == 0 {
+		return nil
+	}
+
+	return &s
+}
+
+func optionalTime(unix *int64) *time.Time {
+	if unix == nil {
+		return nil
+	}
+
+	t := time.Unix(*unix, 0).UTC()
+
+	return &t
+}
+
+func convertRoundModelToProto(r models.Round) *proto.Round {
+	resp := &proto.Round{
+		Id:           r.ID,
+		UserId:       r.UserID.String(),
+		GameId:       r.GameID,
+		Provider:     r.Provider,
+		Status:       roundStatusModelToProto[r.Status],
+		Currency:     r.Currency,
+		BetTotal:     r.BetTotal,
+		WinTotal:     r.WinTotal,
+		Net:          r.Net,
+		BetCount:     int32(r.BetCount),
+		WinCount:     int32(r.WinCount),
+		OpenedAt:     r.OpenedAt.Unix(),
+		Orphaned:     r.Orphaned,
+		UnmatchedWin: r.UnmatchedWin,
+		Transactions: convertTransactionsModelToProto(r.Transactions),
+	}
+
+	if r.SettledAt != nil {
+		resp.SettledAt = r.SettledAt.Unix()
+	}
+
+	return resp
+}
+
+func convertBalanceModelToProto(b models.Balance) *proto.Balance {
+	return &proto.Balance{
+		UserId:              b.UserID.String(),
+		Currency:            b.Currency,
+		Balance:             b.Balance,
+		CreditTotal:         b.CreditTotal,
+		DebitTotal:          b.DebitTotal,
+		TransactionCount:    int64(b.TransactionCount),
+		LastTransactionTime: b.LastTransactionTime.Unix(),
+		UpdatedAt:           b.UpdatedAt.Unix(),
+		Reporting:           convertConversionModelToProto(b.Reporting),
+	}
+}
+
+func convertBalancePointModelToProto(p models.BalancePoint) *proto.BalancePoint {
+	return &proto.BalancePoint{
+		Timestamp:        p.At.Unix(),
+		Currency:         p.Currency,
+		Balance:          p.Balance,
+		TransactionCount: int64(p.TransactionCount),
+		Reporting:        convertConversionModelToProto(p.Reporting),
+	}
+}
+
+func convertPostingModelToProto(p models.Posting) *proto.Posting {
+	return &proto.Posting{
+		Id:            p.ID.String(),
+		TransactionId: p.TransactionID.String(),
+		Account:       p.Account,
+		Amount:        p.Amount,
+		Currency:      p.Currency,
+		Timestamp:     p.TransactionTime.Unix(),
+		Reporting:     convertConversionModelToProto(p.Reporting),
+	}
+}
+
+func convertProtoRoundFiltersToModel(req *proto.ListOpenRoundsRequest) (models.RoundFilter, error) {
+	if len(req.UserId) == 0 {
+		return models.RoundFilter{}, nil
+	}
+
+	id, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return models.RoundFilter{}, fmt.Errorf("failed to parse user id: %w", err)
+	}
+
+	return models.RoundFilter{UserID: &id}, nil
+}
+
+func convertConversionModelToProto(c *models.Conversion) *proto.Conversion {
+	if c == nil {
+		return nil
+	}
+
+	resp := &proto.Conversion{
+		Currency:         c.Currency,
+		Amount:           c.Amount,
+		CurrencyExponent: int32(models.Exponent(c.Currency)),
+		Rate:             formatRate(c.Rate),
+	}
+
+	if !c.RateDate.IsZero() {
+		resp.RateDate = c.RateDate.Unix()
+	}
+
+	return resp
+}
+
+// rateDigits is enough for cross rates derived from rates published with 4 to 6 digits.
+const rateDigits = 10
+
+func formatRate(r *big.Rat) string {
+	s := r.FloatString(rateDigits)
+	if strings.Contains(s, ".") {
+		s = strings.TrimSuffix(strings.TrimRight(s, "0"), ".")
+	}
+
+	return s
+}
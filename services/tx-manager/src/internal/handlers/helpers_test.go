@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"math/big"
 	"testing"
 	"time"
 
@@ -14,6 +15,7 @@ func TestConvertTransactionModelToProto(t *testing.T) {
 	now := time.Now()
 	id := uuid.New()
 	userID := uuid.New()
+	originalID := uuid.New()
 
 	tests := []struct {
 		name string
@@ -27,14 +29,18 @@ func TestConvertTransactionModelToProto(t *testing.T) {
 				UserID:          userID,
 				Type:            models.Bet,
 				Amount:          100,
+				Currency:        "EUR",
 				TransactionTime: now,
 			},
 			want: &proto.Transaction{
-				Id:        id.String(),
-				UserId:    userID.String(),
-				Type:      proto.TransactionType_Bet,
-				Amount:    100,
-				Timestamp: now.Unix(),
+				Id:               id.String(),
+				UserId:           userID.String(),
+				Type:             proto.TransactionType_Bet,
+				Amount:           100,
+				Currency:         "EUR",
+				CurrencyExponent: 2,
+				Timestamp:        now.Unix(),
+				Direction:        proto.Direction_Debit,
 			},
 		},
 		{
@@ -44,14 +50,67 @@ func TestConvertTransactionModelToProto(t *testing.T) {
 				UserID:          userID,
 				Type:            models.Win,
 				Amount:          500,
+				Currency:        "EUR",
 				TransactionTime: now,
 			},
 			want: &proto.Transaction{
-				Id:        id.String(),
-				UserId:    userID.String(),
-				Type:      proto.TransactionType_Win,
-				Amount:    500,
-				Timestamp: now.Unix(),
+				Id:               id.String(),
+				UserId:           userID.String(),
+				Type:             proto.TransactionType_Win,
+				Amount:           500,
+				Currency:         "EUR",
+				CurrencyExponent: 2,
+				Timestamp:        now.Unix(),
+				Direction:        proto.Direction_Credit,
+			},
+		},
+		{
+			name: "withdrawal transaction",
+			in: models.Transaction{
+				ID:              id,
+				UserID:          userID,
+				Type:            models.Withdrawal,
+				Amount:          300,
+				Currency:        "JPY",
+				TransactionTime: now,
+			},
+			want: &proto.Transaction{
+				Id:               id.String(),
+				UserId:           userID.String(),
+				Type:             proto.TransactionType_Withdrawal,
+				Amount:           300,
+				Currency:         "JPY",
+				CurrencyExponent: 0,
+				Timestamp:        now.Unix(),
+				Direction:        proto.Direction_Debit,
+			},
+		},
+		{
+			name: "rollback transaction",
+			in: models.Transaction{
+				ID:              id,
+				UserID:          userID,
+				Type:            models.Rollback,
+				Amount:          300,
+				Currency:        "EUR",
+				TransactionTime: now,
+				ReferenceID:     "provider-bet-1",
+				OriginalID:      &originalID,
+				OriginalType:    models.Bet,
+				Status:          models.Completed,
+			},
+			want: &proto.Transaction{
+				Id:               id.String(),
+				UserId:           userID.String(),
+				Type:             proto.TransactionType_Rollback,
+				Amount:           300,
+				Currency:         "EUR",
+				CurrencyExponent: 2,
+				Timestamp:        now.Unix(),
+				Direction:        proto.Direction_Credit,
+				ReferenceId:      "provider-bet-1",
+				OriginalId:       originalID.String(),
+				Status:           proto.Status_Completed,
 			},
 		},
 	}
@@ -83,6 +142,7 @@ func TestConvertTransactionsModelToProto(t *testing.T) {
 					UserID:          user1,
 					Type:            models.Bet,
 					Amount:          100,
+					Currency:        "EUR",
 					TransactionTime: now,
 				},
 				{
@@ -90,23 +150,30 @@ func TestConvertTransactionsModelToProto(t *testing.T) {
 					UserID:          user2,
 					Type:            models.Win,
 					Amount:          200,
+					Currency:        "EUR",
 					TransactionTime: now,
 				},
 			},
 			want: []*proto.Transaction{
 				{
-					Id:        id1.String(),
-					UserId:    user1.String(),
-					Type:      proto.TransactionType_Bet,
-					Amount:    100,
-					Timestamp: now.Unix(),
+					Id:               id1.String(),
+					UserId:           user1.String(),
+					Type:             proto.TransactionType_Bet,
+					Amount:           100,
+					Currency:         "EUR",
+					CurrencyExponent: 2,
+					Timestamp:        now.Unix(),
+					Direction:        proto.Direction_Debit,
 				},
 				{
-					Id:        id2.String(),
-					UserId:    user2.String(),
-					Type:      proto.TransactionType_Win,
-					Amount:    200,
-					Timestamp: now.Unix(),
+					Id:               id2.String(),
+					UserId:           user2.String(),
+					Type:             proto.TransactionType_Win,
+					Amount:           200,
+					Currency:         "EUR",
+					CurrencyExponent: 2,
+					Timestamp:        now.Unix(),
+					Direction:        proto.Direction_Credit,
 				},
 			},
 		},
@@ -123,16 +190,20 @@ func TestConvertTransactionsModelToProto(t *testing.T) {
 					UserID:          user1,
 					Type:            models.Bet,
 					Amount:          777,
+					Currency:        "EUR",
 					TransactionTime: now,
 				},
 			},
 			want: []*proto.Transaction{
 				{
-					Id:        id1.String(),
-					UserId:    user1.String(),
-					Type:      proto.TransactionType_Bet,
-					Amount:    777,
-					Timestamp: now.Unix(),
+					Id:               id1.String(),
+					UserId:           user1.String(),
+					Type:             proto.TransactionType_Bet,
+					Amount:           777,
+					Currency:         "EUR",
+					CurrencyExponent: 2,
+					Timestamp:        now.Unix(),
+					Direction:        proto.Direction_Debit,
 				},
 			},
 		},
@@ -148,6 +219,8 @@ func TestConvertTransactionsModelToProto(t *testing.T) {
 
 func TestConvertProtoFiltersToModel(t *testing.T) {
 	userID := uuid.New()
+	from, to := int64(1735768800), int64(1735797600)
+	minAmount, maxAmount := int64(1000), int64(50000)
 
 	tests := []struct {
 		name      string
@@ -213,6 +286,71 @@ func TestConvertProtoFiltersToModel(t *testing.T) {
 				Type:   ptr(models.Win),
 			},
 		},
+		{
+			name: "game context filters",
+			in: &proto.Filters{
+				RoundId:  "round-1",
+				GameId:   "book-of-dead",
+				Provider: "playngo",
+				Channel:  "mobile",
+			},
+			want: models.TransactionFilter{
+				RoundID:  ptr("round-1"),
+				GameID:   ptr("book-of-dead"),
+				Provider: ptr("playngo"),
+				Channel:  ptr("mobile"),
+			},
+		},
+		{
+			name: "currency filter",
+			in: &proto.Filters{
+				Currency: "USD",
+			},
+			want: models.TransactionFilter{
+				Currency: ptr("USD"),
+			},
+		},
+		{
+			name: "invalid currency gives error",
+			in: &proto.Filters{
+				Currency: "usd",
+			},
+			wantErr:   true,
+			errSubstr: "invalid currency",
+		},
+		{
+			name: "time and amount ranges",
+			in: &proto.Filters{
+				Type:      proto.TransactionType_Bet,
+				From:      &from,
+				To:        &to,
+				MinAmount: &minAmount,
+			},
+			want: models.TransactionFilter{
+				Type:      ptr(models.Bet),
+				From:      ptr(time.Unix(from, 0).UTC()),
+				To:        ptr(time.Unix(to, 0).UTC()),
+				MinAmount: &minAmount,
+			},
+		},
+		{
+			name: "from not before to gives error",
+			in: &proto.Filters{
+				From: &to,
+				To:   &from,
+			},
+			wantErr:   true,
+			errSubstr: "from must be before to",
+		},
+		{
+			name: "min amount above max amount gives error",
+			in: &proto.Filters{
+				MinAmount: &maxAmount,
+				MaxAmount: &minAmount,
+			},
+			wantErr:   true,
+			errSubstr: "min amount must not exceed max amount",
+		},
 		{
 			name: "only user ID set",
 			in: &proto.Filters{
@@ -241,4 +379,111 @@ func TestConvertProtoFiltersToModel(t *testing.T) {
 	}
 }
 
+func TestConvertProtoFilterExprToModel(t *testing.T) {
+	userID := uuid.New()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	text := func(s string) *proto.FilterValue { return &proto.FilterValue{Kind: &proto.FilterValue_Text{Text: s}} }
+	integer := func(n int64) *proto.FilterValue {
+		return &proto.FilterValue{Kind: &proto.FilterValue_Integer{Integer: n}}
+	}
+	at := func(t time.Time) *proto.FilterValue {
+		return &proto.FilterValue{Kind: &proto.FilterValue_Time{Time: t.Unix()}}
+	}
+	cmp := func(field proto.FilterField, op proto.ComparisonOp, values ...*proto.FilterValue) *proto.FilterExpr {
+		return &proto.FilterExpr{Node: &proto.FilterExpr_Comparison{Comparison: &proto.Comparison{Field: field, Op: op, Values: values}}}
+	}
+
+	tests := []struct {
+		name      string
+		in        *proto.FilterExpr
+		want      *models.FilterExpr
+		errSubstr string
+	}{
+		{
+			name: "no expression",
+		},
+		{
+			name: "typed values",
+			in: &proto.FilterExpr{Node: &proto.FilterExpr_And{And: &proto.FilterList{Operands: []*proto.FilterExpr{
+				cmp(proto.FilterField_FilterType, proto.ComparisonOp_Equal, text("bet")),
+				cmp(proto.FilterField_FilterAmount, proto.ComparisonOp_Greater, integer(1000)),
+				cmp(proto.FilterField_FilterDate, proto.ComparisonOp_GreaterOrEqual, at(from)),
+				{Node: &proto.FilterExpr_Or{Or: &proto.FilterList{Operands: []*proto.FilterExpr{
+					cmp(proto.FilterField_FilterUserId, proto.ComparisonOp_Equal, text(userID.String())),
+					cmp(proto.FilterField_FilterChannel, proto.ComparisonOp_In, text("web"), text("mobile")),
+				}}}},
+			}}}},
+			want: &models.FilterExpr{And: []models.FilterExpr{
+				{Comparison: &models.Comparison{Field: models.FilterType, Op: models.OpEqual, Values: []any{models.Bet}}},
+				{Comparison: &models.Comparison{Field: models.FilterAmount, Op: models.OpGreater, Values: []any{int64(1000)}}},
+				{Comparison: &models.Comparison{Field: models.FilterDate, Op: models.OpGreaterOrEqual, Values: []any{from}}},
+				{Or: []models.FilterExpr{
+					{Comparison: &models.Comparison{Field: models.FilterUserID, Op: models.OpEqual, Values: []any{userID}}},
+					{Comparison: &models.Comparison{Field: models.FilterChannel, Op: models.OpIn, Values: []any{"web", "mobile"}}},
+				}},
+			}},
+		},
+		{
+			name:      "text for amount",
+			in:        cmp(proto.FilterField_FilterAmount, proto.ComparisonOp_Greater, text("1000")),
+			errSubstr: "invalid amount value",
+		},
+		{
+			name:      "invalid user id",
+			in:        cmp(proto.FilterField_FilterUserId, proto.ComparisonOp_Equal, text("NOT-A-UUID")),
+			errSubstr: "failed to parse user_id",
+		},
+		{
+			name:      "unknown field",
+			in:        cmp(proto.FilterField_UnknownFilterField, proto.ComparisonOp_Equal, text("x")),
+			errSubstr: "unknown filter field",
+		},
+		{
+			name:      "unknown operator",
+			in:        cmp(proto.FilterField_FilterAmount, proto.ComparisonOp_UnknownComparisonOp, integer(1)),
+			errSubstr: "unknown filter operator",
+		},
+		{
+			name:      "empty node",
+			in:        &proto.FilterExpr{},
+			errSubstr: "empty filter node",
+		},
+		{
+			name:      "fails validation",
+			in:        cmp(proto.FilterField_FilterType, proto.ComparisonOp_Equal, text("cashback")),
+			errSubstr: "unknown transaction type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := convertProtoFilterExprToModel(tt.in)
+			if len(tt.errSubstr) > 0 {
+				assert.ErrorContains(t, err, tt.errSubstr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func ptr[T any](v T) *T { return &v }
+
+func TestFormatRate(t *testing.T) {
+	tests := []struct {
+		rate     *big.Rat
+		expected string
+	}{
+		{rate: big.NewRat(1, 1), expected: "1"},
+		{rate: big.NewRat(325, 2), expected: "162.5"},
+		{rate: big.NewRat(100, 104), expected: "0.9615384615"},
+		{rate: big.NewRat(10321, 10000), expected: "1.0321"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, formatRate(tt.rate))
+	}
+}
@@ -39,40 +39,188 @@ func (_m *MockTransactionService) EXPECT() *MockTransactionService_Expecter {
 	return &MockTransactionService_Expecter{mock: &_m.Mock}
 }
 
-// GetAll provides a mock function for the type MockTransactionService
-func (_mock *MockTransactionService) GetAll(ctx context.Context, filters models.TransactionFilter, orderBy string, limit int64, offset int64) ([]models.Transaction, int64, error) {
-	ret := _mock.Called(ctx, filters, orderBy, limit, offset)
+// BatchGet provides a mock function for the type MockTransactionService
+func (_mock *MockTransactionService) BatchGet(ctx context.Context, ids []uuid.UUID, reportingCurrency string) ([]models.Transaction, []uuid.UUID, error) {
+	ret := _mock.Called(ctx, ids, reportingCurrency)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAll")
+		panic("no return value specified for BatchGet")
 	}
 
 	var r0 []models.Transaction
-	var r1 int64
+	var r1 []uuid.UUID
 	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, models.TransactionFilter, string, int64, int64) ([]models.Transaction, int64, error)); ok {
-		return returnFunc(ctx, filters, orderBy, limit, offset)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []uuid.UUID, string) ([]models.Transaction, []uuid.UUID, error)); ok {
+		return returnFunc(ctx, ids, reportingCurrency)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, models.TransactionFilter, string, int64, int64) []models.Transaction); ok {
-		r0 = returnFunc(ctx, filters, orderBy, limit, offset)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []uuid.UUID, string) []models.Transaction); ok {
+		r0 = returnFunc(ctx, ids, reportingCurrency)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.Transaction)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, models.TransactionFilter, string, int64, int64) int64); ok {
-		r1 = returnFunc(ctx, filters, orderBy, limit, offset)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []uuid.UUID, string) []uuid.UUID); ok {
+		r1 = returnFunc(ctx, ids, reportingCurrency)
 	} else {
-		r1 = ret.Get(1).(int64)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]uuid.UUID)
+		}
 	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, models.TransactionFilter, string, int64, int64) error); ok {
-		r2 = returnFunc(ctx, filters, orderBy, limit, offset)
+	if returnFunc, ok := ret.Get(2).(func(context.Context, []uuid.UUID, string) error); ok {
+		r2 = returnFunc(ctx, ids, reportingCurrency)
 	} else {
 		r2 = ret.Error(2)
 	}
 	return r0, r1, r2
 }
 
+// MockTransactionService_BatchGet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BatchGet'
+type MockTransactionService_BatchGet_Call struct {
+	*mock.Call
+}
+
+// BatchGet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []uuid.UUID
+//   - reportingCurrency string
+func (_e *MockTransactionService_Expecter) BatchGet(ctx interface{}, ids interface{}, reportingCurrency interface{}) *MockTransactionService_BatchGet_Call {
+	return &MockTransactionService_BatchGet_Call{Call: _e.mock.On("BatchGet", ctx, ids, reportingCurrency)}
+}
+
+func (_c *MockTransactionService_BatchGet_Call) Run(run func(ctx context.Context, ids []uuid.UUID, reportingCurrency string)) *MockTransactionService_BatchGet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].([]uuid.UUID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransactionService_BatchGet_Call) Return(transactions []models.Transaction, uUIDs []uuid.UUID, err error) *MockTransactionService_BatchGet_Call {
+	_c.Call.Return(transactions, uUIDs, err)
+	return _c
+}
+
+func (_c *MockTransactionService_BatchGet_Call) RunAndReturn(run func(ctx context.Context, ids []uuid.UUID, reportingCurrency string) ([]models.Transaction, []uuid.UUID, error)) *MockTransactionService_BatchGet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Count provides a mock function for the type MockTransactionService
+func (_mock *MockTransactionService) Count(ctx context.Context, filters models.TransactionFilter, mode models.CountMode) (models.Total, error) {
+	ret := _mock.Called(ctx, filters, mode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 models.Total
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.TransactionFilter, models.CountMode) (models.Total, error)); ok {
+		return returnFunc(ctx, filters, mode)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.TransactionFilter, models.CountMode) models.Total); ok {
+		r0 = returnFunc(ctx, filters, mode)
+	} else {
+		r0 = ret.Get(0).(models.Total)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.TransactionFilter, models.CountMode) error); ok {
+		r1 = returnFunc(ctx, filters, mode)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTransactionService_Count_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Count'
+type MockTransactionService_Count_Call struct {
+	*mock.Call
+}
+
+// Count is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filters models.TransactionFilter
+//   - mode models.CountMode
+func (_e *MockTransactionService_Expecter) Count(ctx interface{}, filters interface{}, mode interface{}) *MockTransactionService_Count_Call {
+	return &MockTransactionService_Count_Call{Call: _e.mock.On("Count", ctx, filters, mode)}
+}
+
+func (_c *MockTransactionService_Count_Call) Run(run func(ctx context.Context, filters models.TransactionFilter, mode models.CountMode)) *MockTransactionService_Count_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.TransactionFilter
+		if args[1] != nil {
+			arg1 = args[1].(models.TransactionFilter)
+		}
+		var arg2 models.CountMode
+		if args[2] != nil {
+			arg2 = args[2].(models.CountMode)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransactionService_Count_Call) Return(total models.Total, err error) *MockTransactionService_Count_Call {
+	_c.Call.Return(total, err)
+	return _c
+}
+
+func (_c *MockTransactionService_Count_Call) RunAndReturn(run func(ctx context.Context, filters models.TransactionFilter, mode models.CountMode) (models.Total, error)) *MockTransactionService_Count_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function for the type MockTransactionService
+func (_mock *MockTransactionService) GetAll(ctx context.Context, filters models.TransactionFilter, sort []models.Sort, limit int64, offset int64, reportingCurrency string) ([]models.Transaction, error) {
+	ret := _mock.Called(ctx, filters, sort, limit, offset, reportingCurrency)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []models.Transaction
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.TransactionFilter, []models.Sort, int64, int64, string) ([]models.Transaction, error)); ok {
+		return returnFunc(ctx, filters, sort, limit, offset, reportingCurrency)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.TransactionFilter, []models.Sort, int64, int64, string) []models.Transaction); ok {
+		r0 = returnFunc(ctx, filters, sort, limit, offset, reportingCurrency)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Transaction)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.TransactionFilter, []models.Sort, int64, int64, string) error); ok {
+		r1 = returnFunc(ctx, filters, sort, limit, offset, reportingCurrency)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
 // MockTransactionService_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
 type MockTransactionService_GetAll_Call struct {
 	*mock.Call
@@ -81,14 +229,15 @@ type MockTransactionService_GetAll_Call struct {
 // GetAll is a helper method to define mock.On call
 //   - ctx context.Context
 //   - filters models.TransactionFilter
-//   - orderBy string
+//   - sort []models.Sort
 //   - limit int64
 //   - offset int64
-func (_e *MockTransactionService_Expecter) GetAll(ctx interface{}, filters interface{}, orderBy interface{}, limit interface{}, offset interface{}) *MockTransactionService_GetAll_Call {
-	return &MockTransactionService_GetAll_Call{Call: _e.mock.On("GetAll", ctx, filters, orderBy, limit, offset)}
+//   - reportingCurrency string
+func (_e *MockTransactionService_Expecter) GetAll(ctx interface{}, filters interface{}, sort interface{}, limit interface{}, offset interface{}, reportingCurrency interface{}) *MockTransactionService_GetAll_Call {
+	return &MockTransactionService_GetAll_Call{Call: _e.mock.On("GetAll", ctx, filters, sort, limit, offset, reportingCurrency)}
 }
 
-func (_c *MockTransactionService_GetAll_Call) Run(run func(ctx context.Context, filters models.TransactionFilter, orderBy string, limit int64, offset int64)) *MockTransactionService_GetAll_Call {
+func (_c *MockTransactionService_GetAll_Call) Run(run func(ctx context.Context, filters models.TransactionFilter, sort []models.Sort, limit int64, offset int64, reportingCurrency string)) *MockTransactionService_GetAll_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -98,9 +247,9 @@ func (_c *MockTransactionService_GetAll_Call) Run(run func(ctx context.Context,
 		if args[1] != nil {
 			arg1 = args[1].(models.TransactionFilter)
 		}
-		var arg2 string
+		var arg2 []models.Sort
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].([]models.Sort)
 		}
 		var arg3 int64
 		if args[3] != nil {
@@ -110,45 +259,50 @@ func (_c *MockTransactionService_GetAll_Call) Run(run func(ctx context.Context,
 		if args[4] != nil {
 			arg4 = args[4].(int64)
 		}
+		var arg5 string
+		if args[5] != nil {
+			arg5 = args[5].(string)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
 			arg3,
 			arg4,
+			arg5,
 		)
 	})
 	return _c
 }
 
-func (_c *MockTransactionService_GetAll_Call) Return(transactions []models.Transaction, n int64, err error) *MockTransactionService_GetAll_Call {
-	_c.Call.Return(transactions, n, err)
+func (_c *MockTransactionService_GetAll_Call) Return(transactions []models.Transaction, err error) *MockTransactionService_GetAll_Call {
+	_c.Call.Return(transactions, err)
 	return _c
 }
 
-func (_c *MockTransactionService_GetAll_Call) RunAndReturn(run func(ctx context.Context, filters models.TransactionFilter, orderBy string, limit int64, offset int64) ([]models.Transaction, int64, error)) *MockTransactionService_GetAll_Call {
+func (_c *MockTransactionService_GetAll_Call) RunAndReturn(run func(ctx context.Context, filters models.TransactionFilter, sort []models.Sort, limit int64, offset int64, reportingCurrency string) ([]models.Transaction, error)) *MockTransactionService_GetAll_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByID provides a mock function for the type MockTransactionService
-func (_mock *MockTransactionService) GetByID(ctx context.Context, id uuid.UUID) (*models.Transaction, error) {
+// GetChain provides a mock function for the type MockTransactionService
+func (_mock *MockTransactionService) GetChain(ctx context.Context, id uuid.UUID) ([]models.Transaction, error) {
 	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByID")
+		panic("no return value specified for GetChain")
 	}
 
-	var r0 *models.Transaction
+	var r0 []models.Transaction
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*models.Transaction, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]models.Transaction, error)); ok {
 		return returnFunc(ctx, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.Transaction); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []models.Transaction); ok {
 		r0 = returnFunc(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Transaction)
+			r0 = ret.Get(0).([]models.Transaction)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
@@ -159,19 +313,19 @@ func (_mock *MockTransactionService) GetByID(ctx context.Context, id uuid.UUID)
 	return r0, r1
 }
 
-// MockTransactionService_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
-type MockTransactionService_GetByID_Call struct {
+// MockTransactionService_GetChain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChain'
+type MockTransactionService_GetChain_Call struct {
 	*mock.Call
 }
 
-// GetByID is a helper method to define mock.On call
+// GetChain is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id uuid.UUID
-func (_e *MockTransactionService_Expecter) GetByID(ctx interface{}, id interface{}) *MockTransactionService_GetByID_Call {
-	return &MockTransactionService_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+func (_e *MockTransactionService_Expecter) GetChain(ctx interface{}, id interface{}) *MockTransactionService_GetChain_Call {
+	return &MockTransactionService_GetChain_Call{Call: _e.mock.On("GetChain", ctx, id)}
 }
 
-func (_c *MockTransactionService_GetByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockTransactionService_GetByID_Call {
+func (_c *MockTransactionService_GetChain_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockTransactionService_GetChain_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -189,12 +343,106 @@ func (_c *MockTransactionService_GetByID_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockTransactionService_GetByID_Call) Return(transaction *models.Transaction, err error) *MockTransactionService_GetByID_Call {
-	_c.Call.Return(transaction, err)
+func (_c *MockTransactionService_GetChain_Call) Return(transactions []models.Transaction, err error) *MockTransactionService_GetChain_Call {
+	_c.Call.Return(transactions, err)
+	return _c
+}
+
+func (_c *MockTransactionService_GetChain_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) ([]models.Transaction, error)) *MockTransactionService_GetChain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPage provides a mock function for the type MockTransactionService
+func (_mock *MockTransactionService) GetPage(ctx context.Context, filters models.TransactionFilter, after *models.Cursor, limit int64, reportingCurrency string) ([]models.Transaction, *models.Cursor, error) {
+	ret := _mock.Called(ctx, filters, after, limit, reportingCurrency)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPage")
+	}
+
+	var r0 []models.Transaction
+	var r1 *models.Cursor
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.TransactionFilter, *models.Cursor, int64, string) ([]models.Transaction, *models.Cursor, error)); ok {
+		return returnFunc(ctx, filters, after, limit, reportingCurrency)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.TransactionFilter, *models.Cursor, int64, string) []models.Transaction); ok {
+		r0 = returnFunc(ctx, filters, after, limit, reportingCurrency)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Transaction)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.TransactionFilter, *models.Cursor, int64, string) *models.Cursor); ok {
+		r1 = returnFunc(ctx, filters, after, limit, reportingCurrency)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.Cursor)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, models.TransactionFilter, *models.Cursor, int64, string) error); ok {
+		r2 = returnFunc(ctx, filters, after, limit, reportingCurrency)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockTransactionService_GetPage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPage'
+type MockTransactionService_GetPage_Call struct {
+	*mock.Call
+}
+
+// GetPage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filters models.TransactionFilter
+//   - after *models.Cursor
+//   - limit int64
+//   - reportingCurrency string
+func (_e *MockTransactionService_Expecter) GetPage(ctx interface{}, filters interface{}, after interface{}, limit interface{}, reportingCurrency interface{}) *MockTransactionService_GetPage_Call {
+	return &MockTransactionService_GetPage_Call{Call: _e.mock.On("GetPage", ctx, filters, after, limit, reportingCurrency)}
+}
+
+func (_c *MockTransactionService_GetPage_Call) Run(run func(ctx context.Context, filters models.TransactionFilter, after *models.Cursor, limit int64, reportingCurrency string)) *MockTransactionService_GetPage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.TransactionFilter
+		if args[1] != nil {
+			arg1 = args[1].(models.TransactionFilter)
+		}
+		var arg2 *models.Cursor
+		if args[2] != nil {
+			arg2 = args[2].(*models.Cursor)
+		}
+		var arg3 int64
+		if args[3] != nil {
+			arg3 = args[3].(int64)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransactionService_GetPage_Call) Return(transactions []models.Transaction, cursor *models.Cursor, err error) *MockTransactionService_GetPage_Call {
+	_c.Call.Return(transactions, cursor, err)
 	return _c
 }
 
-func (_c *MockTransactionService_GetByID_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (*models.Transaction, error)) *MockTransactionService_GetByID_Call {
+func (_c *MockTransactionService_GetPage_Call) RunAndReturn(run func(ctx context.Context, filters models.TransactionFilter, after *models.Cursor, limit int64, reportingCurrency string) ([]models.Transaction, *models.Cursor, error)) *MockTransactionService_GetPage_Call {
 	_c.Call.Return(run)
 	return _c
 }
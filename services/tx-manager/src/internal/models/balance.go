@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Balance is the projection of all transactions of a user in one currency: credits minus debits.
+type Balance struct {
+	UserID              uuid.UUID
+	Currency            string
+	Balance             int64
+	CreditTotal         int64
+	DebitTotal          int64
+	TransactionCount    int
+	LastTransactionTime time.Time
+	UpdatedAt           time.Time
+	// Reporting is the balance in the reporting currency, when one was requested.
+	Reporting *Conversion
+}
+
+// BalancePoint is the balance of a user including every transaction up to At.
+type BalancePoint struct {
+	At               time.Time
+	Currency         string
+	Balance          int64
+	TransactionCount int
+	Reporting        *Conversion
+}
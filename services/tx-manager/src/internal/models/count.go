@@ -0,0 +1,16 @@
+package models
+
+type CountMode int
+
+const (
+	// CountEstimated takes the row estimate of the query planner, which is cheap on large tables but can be far off.
+	CountEstimated CountMode = iota
+	// CountExact counts every matching row.
+	CountExact
+)
+
+// Total is the number of transactions matching a listing, across all its pages.
+type Total struct {
+	Count int64
+	Exact bool
+}
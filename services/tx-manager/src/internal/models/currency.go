@@ -0,0 +1,32 @@
+package models
+
+import "github.com/go-playground/validator/v10"
+
+// DefaultCurrency is assumed for events and rows that were stored before amounts had a currency.
+const DefaultCurrency = "EUR"
+
+var currencies = validator.New()
+
+// exponents lists the ISO 4217 currencies whose minor unit isn't a hundredth of the major one.
+var exponents = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0, "PYG": 0,
+	"RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+// Exponent returns the number of decimal digits of the minor unit of a currency,
+// e.g. 2 for EUR where amounts are stored in cents.
+func Exponent(currency string) int {
+	if exp, ok := exponents[currency]; ok {
+		return exp
+	}
+
+	return 2
+}
+
+// ValidCurrency tells whether code is an ISO 4217 alphabetic code, checked against
+// the same list as the iso4217 tag that validates events.
+func ValidCurrency(code string) bool {
+	return currencies.Var(code, "iso4217") == nil
+}
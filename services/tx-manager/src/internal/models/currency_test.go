@@ -0,0 +1,22 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExponent(t *testing.T) {
+	assert.Equal(t, 2, Exponent("EUR"))
+	assert.Equal(t, 0, Exponent("JPY"))
+	assert.Equal(t, 3, Exponent("KWD"))
+	assert.Equal(t, 4, Exponent("CLF"))
+}
+
+func TestValidCurrency(t *testing.T) {
+	assert.True(t, ValidCurrency("USD"))
+	assert.False(t, ValidCurrency("usd"))
+	assert.False(t, ValidCurrency("EURO"))
+	assert.False(t, ValidCurrency("XXY"))
+	assert.False(t, ValidCurrency(""))
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Cursor is the position of a transaction in the keyset order of listings: newest first, then by id.
+type Cursor struct {
+	Time time.Time
+	ID   uuid.UUID
+}
+
+func CursorOf(t Transaction) Cursor {
+	return Cursor{Time: t.TransactionTime, ID: t.ID}
+}
+
+// Encode returns the cursor as an opaque token.
+func (c Cursor) Encode() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(c.Time.UTC().Format(time.RFC3339Nano) + "|" + c.ID.String()))
+}
+
+func ParseCursor(token string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return Cursor{}, errors.New("malformed page token")
+	}
+
+	at, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return Cursor{}, errors.New("malformed page token")
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, at)
+	if err != nil {
+		return Cursor{}, errors.New("malformed page token")
+	}
+
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		return Cursor{}, errors.New("malformed page token")
+	}
+
+	return Cursor{Time: t, ID: parsedID}, nil
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor(t *testing.T) {
+	c := Cursor{Time: time.Date(2025, 1, 1, 22, 0, 0, 123456000, time.UTC), ID: uuid.New()}
+
+	parsed, err := ParseCursor(c.Encode())
+	require.NoError(t, err)
+	assert.True(t, c.Time.Equal(parsed.Time))
+	assert.Equal(t, c.ID, parsed.ID)
+
+	for _, token := range []string{
+		"not base64!",
+		base64.RawURLEncoding.EncodeToString([]byte("2025-01-01T22:00:00Z")),
+		base64.RawURLEncoding.EncodeToString([]byte("yesterday|" + c.ID.String())),
+		base64.RawURLEncoding.EncodeToString([]byte("2025-01-01T22:00:00Z|42")),
+	} {
+		_, err := ParseCursor(token)
+		assert.Error(t, err, token)
+	}
+}
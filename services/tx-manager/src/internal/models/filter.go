@@ -0,0 +1,251 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxFilterComparisons bounds the size of a filter expression.
+	MaxFilterComparisons = 32
+	// MaxFilterValues bounds the list of an =in= or =out= comparison.
+	MaxFilterValues = 100
+)
+
+// FilterField is a transaction attribute a filter expression compares.
+type FilterField string
+
+const (
+	FilterID        FilterField = "id"
+	FilterUserID    FilterField = "user_id"
+	FilterType      FilterField = "type"
+	FilterAmount    FilterField = "amount"
+	FilterCurrency  FilterField = "currency"
+	FilterDate      FilterField = "date"
+	FilterRoundID   FilterField = "round_id"
+	FilterGameID    FilterField = "game_id"
+	FilterProvider  FilterField = "provider"
+	FilterSessionID FilterField = "session_id"
+	FilterChannel   FilterField = "channel"
+)
+
+type FilterOp string
+
+const (
+	OpEqual          FilterOp = "=="
+	OpNotEqual       FilterOp = "!="
+	OpLess           FilterOp = "<"
+	OpLessOrEqual    FilterOp = "<="
+	OpGreater        FilterOp = ">"
+	OpGreaterOrEqual FilterOp = ">="
+	OpIn             FilterOp = "=in="
+	OpNotIn          FilterOp = "=out="
+)
+
+type filterColumn struct {
+	name     string
+	nullable bool
+	// ordered columns can be compared with <, <=, > and >=.
+	ordered bool
+	// equality is false for columns only compared by range, like timestamps.
+	equality bool
+}
+
+var filterColumns = map[FilterField]filterColumn{
+	FilterID:        {name: "id", equality: true},
+	FilterUserID:    {name: "user_id", equality: true},
+	FilterType:      {name: "transaction_type", equality: true},
+	FilterAmount:    {name: "amount", equality: true, ordered: true},
+	FilterCurrency:  {name: "currency", equality: true},
+	FilterDate:      {name: "transaction_time", ordered: true},
+	FilterRoundID:   {name: "round_id", equality: true, nullable: true},
+	FilterGameID:    {name: "game_id", equality: true, nullable: true},
+	FilterProvider:  {name: "provider", equality: true, nullable: true},
+	FilterSessionID: {name: "session_id", equality: true, nullable: true},
+	FilterChannel:   {name: "channel", equality: true, nullable: true},
+}
+
+// Comparison matches transactions whose field compares to the values.
+// Values hold a uuid.UUID for ids, a TransactionType for type, an int64 for amount,
+// a time.Time for date and a string otherwise. Only =in= and =out= take more than one.
+type Comparison struct {
+	Field  FilterField
+	Op     FilterOp
+	Values []any
+}
+
+// FilterExpr is a node of a filter expression: a comparison, or the conjunction or disjunction of its operands.
+type FilterExpr struct {
+	Comparison *Comparison
+	And        []FilterExpr
+	Or         []FilterExpr
+}
+
+// Validate checks the expression is well formed, so that it compiles to SQL.
+func (e FilterExpr) Validate() error {
+	n, err := e.validate()
+	if err != nil {
+		return err
+	}
+
+	if n > MaxFilterComparisons {
+		return fmt.Errorf("filter has %d comparisons, at most %d are allowed", n, MaxFilterComparisons)
+	}
+
+	return nil
+}
+
+func (e FilterExpr) validate() (int, error) {
+	var operands []FilterExpr
+
+	switch {
+	case e.Comparison != nil && e.And == nil && e.Or == nil:
+		return 1, e.Comparison.Validate()
+	case e.Comparison == nil && len(e.And) > 0 && e.Or == nil:
+		operands = e.And
+	case e.Comparison == nil && e.And == nil && len(e.Or) > 0:
+		operands = e.Or
+	default:
+		return 0, errors.New("filter node must be a comparison or a non-empty conjunction or disjunction")
+	}
+
+	total := 0
+	for _, op := range operands {
+		n, err := op.validate()
+		if err != nil {
+			return 0, err
+		}
+
+		total += n
+	}
+
+	return total, nil
+}
+
+func (c Comparison) Validate() error {
+	column, ok := filterColumns[c.Field]
+	if !ok {
+		return fmt.Errorf("unknown filter field: %s", c.Field)
+	}
+
+	switch c.Op {
+	case OpEqual, OpNotEqual:
+		if !column.equality {
+			return fmt.Errorf("%s can't be compared with %s", c.Field, c.Op)
+		}
+
+		if len(c.Values) != 1 {
+			return fmt.Errorf("%s takes a single value", c.Op)
+		}
+	case OpIn, OpNotIn:
+		if !column.equality {
+			return fmt.Errorf("%s can't be compared with %s", c.Field, c.Op)
+		}
+
+		if len(c.Values) == 0 || len(c.Values) > MaxFilterValues {
+			return fmt.Errorf("%s takes 1 to %d values", c.Op, MaxFilterValues)
+		}
+	case OpLess, OpLessOrEqual, OpGreater, OpGreaterOrEqual:
+		if !column.ordered {
+			return fmt.Errorf("%s can't be compared with %s", c.Field, c.Op)
+		}
+
+		if len(c.Values) != 1 {
+			return fmt.Errorf("%s takes a single value", c.Op)
+		}
+	default:
+		return fmt.Errorf("unknown filter operator: %s", c.Op)
+	}
+
+	for _, v := range c.Values {
+		if err := validateFilterValue(c.Field, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateFilterValue(field FilterField, v any) error {
+	var ok bool
+
+	switch field {
+	case FilterID, FilterUserID:
+		_, ok = v.(uuid.UUID)
+	case FilterType:
+		var t TransactionType
+		if t, ok = v.(TransactionType); ok && !t.Valid() {
+			return fmt.Errorf("unknown transaction type: %s", t)
+		}
+	case FilterAmount:
+		_, ok = v.(int64)
+	case FilterDate:
+		_, ok = v.(time.Time)
+	case FilterCurrency:
+		var s string
+		if s, ok = v.(string); ok && !ValidCurrency(s) {
+			return fmt.Errorf("invalid currency: %s", s)
+		}
+	default:
+		_, ok = v.(string)
+	}
+
+	if !ok {
+		return fmt.Errorf("invalid %s value: %v", field, v)
+	}
+
+	return nil
+}
+
+// SQL compiles a validated expression to a condition whose values are passed as
+// parameters numbered after args. Column names come from a fixed list, never from the expression.
+func (e FilterExpr) SQL(args []any) (string, []any) {
+	if e.Comparison != nil {
+		return e.Comparison.SQL(args)
+	}
+
+	operands, sep := e.And, " AND "
+	if len(e.Or) > 0 {
+		operands, sep = e.Or, " OR "
+	}
+
+	conditions := make([]string, 0, len(operands))
+	for _, op := range operands {
+		var cond string
+		cond, args = op.SQL(args)
+		conditions = append(conditions, cond)
+	}
+
+	return "(" + strings.Join(conditions, sep) + ")", args
+}
+
+func (c Comparison) SQL(args []any) (string, []any) {
+	column := filterColumns[c.Field]
+
+	placeholders := make([]string, 0, len(c.Values))
+	for _, v := range c.Values {
+		args = append(args, v)
+		placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+	}
+
+	switch c.Op {
+	case OpEqual:
+		return fmt.Sprintf("%s = %s", column.name, placeholders[0]), args
+	case OpNotEqual:
+		return fmt.Sprintf("%s IS DISTINCT FROM %s", column.name, placeholders[0]), args
+	case OpIn:
+		return fmt.Sprintf("%s IN (%s)", column.name, strings.Join(placeholders, ", ")), args
+	case OpNotIn:
+		if column.nullable {
+			return fmt.Sprintf("(%s IS NULL OR %s NOT IN (%s))", column.name, column.name, strings.Join(placeholders, ", ")), args
+		}
+
+		return fmt.Sprintf("%s NOT IN (%s)", column.name, strings.Join(placeholders, ", ")), args
+	default:
+		return fmt.Sprintf("%s %s %s", column.name, c.Op, placeholders[0]), args
+	}
+}
@@ -0,0 +1,107 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func cmp(field FilterField, op FilterOp, values ...any) FilterExpr {
+	return FilterExpr{Comparison: &Comparison{Field: field, Op: op, Values: values}}
+}
+
+func TestFilterExpr_SQL(t *testing.T) {
+	userID := uuid.New()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		expr         FilterExpr
+		args         []any
+		expectedSQL  string
+		expectedArgs []any
+	}{
+		{
+			name:         "comparison",
+			expr:         cmp(FilterAmount, OpGreater, int64(1000)),
+			expectedSQL:  "amount > $1",
+			expectedArgs: []any{int64(1000)},
+		},
+		{
+			name:         "numbered after existing args",
+			expr:         cmp(FilterUserID, OpEqual, userID),
+			args:         []any{"x"},
+			expectedSQL:  "user_id = $2",
+			expectedArgs: []any{"x", userID},
+		},
+		{
+			name: "conjunction of a disjunction",
+			expr: FilterExpr{And: []FilterExpr{
+				cmp(FilterType, OpIn, Bet, Win),
+				cmp(FilterDate, OpGreaterOrEqual, from),
+				{Or: []FilterExpr{
+					cmp(FilterCurrency, OpEqual, "EUR"),
+					cmp(FilterCurrency, OpNotEqual, "USD"),
+				}},
+			}},
+			expectedSQL:  "(transaction_type IN ($1, $2) AND transaction_time >= $3 AND (currency = $4 OR currency IS DISTINCT FROM $5))",
+			expectedArgs: []any{Bet, Win, from, "EUR", "USD"},
+		},
+		{
+			name:         "not in on an optional column keeps rows without it",
+			expr:         cmp(FilterChannel, OpNotIn, "web", "mobile"),
+			expectedSQL:  "(channel IS NULL OR channel NOT IN ($1, $2))",
+			expectedArgs: []any{"web", "mobile"},
+		},
+		{
+			name:         "not in on a required column",
+			expr:         cmp(FilterType, OpNotIn, Rollback),
+			expectedSQL:  "transaction_type NOT IN ($1)",
+			expectedArgs: []any{Rollback},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NoError(t, tt.expr.Validate())
+
+			sql, args := tt.expr.SQL(tt.args)
+			assert.Equal(t, tt.expectedSQL, sql)
+			assert.Equal(t, tt.expectedArgs, args)
+		})
+	}
+}
+
+func TestFilterExpr_Validate(t *testing.T) {
+	many := make([]FilterExpr, MaxFilterComparisons+1)
+	for i := range many {
+		many[i] = cmp(FilterAmount, OpGreater, int64(i))
+	}
+
+	tests := []struct {
+		name string
+		expr FilterExpr
+	}{
+		{name: "empty node", expr: FilterExpr{}},
+		{name: "comparison and operands", expr: FilterExpr{Comparison: &Comparison{Field: FilterAmount, Op: OpEqual, Values: []any{int64(1)}}, And: []FilterExpr{cmp(FilterAmount, OpEqual, int64(1))}}},
+		{name: "unknown field", expr: cmp("t_hash", OpEqual, "x")},
+		{name: "unknown operator", expr: cmp(FilterAmount, "=like=", int64(1))},
+		{name: "range on a field without order", expr: cmp(FilterUserID, OpGreater, uuid.New())},
+		{name: "equality on date", expr: cmp(FilterDate, OpEqual, time.Now())},
+		{name: "several values for equality", expr: cmp(FilterAmount, OpEqual, int64(1), int64(2))},
+		{name: "empty list", expr: cmp(FilterType, OpIn)},
+		{name: "wrong value type", expr: cmp(FilterAmount, OpGreater, "1000")},
+		{name: "unknown transaction type", expr: cmp(FilterType, OpEqual, TransactionType("cashback"))},
+		{name: "invalid currency", expr: cmp(FilterCurrency, OpEqual, "usd")},
+		{name: "invalid nested comparison", expr: FilterExpr{Or: []FilterExpr{cmp(FilterAmount, OpEqual, int64(1)), cmp(FilterID, OpEqual, "1")}}},
+		{name: "too many comparisons", expr: FilterExpr{And: many}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, tt.expr.Validate())
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Named accounts of the ledger besides the player wallets.
+const (
+	HouseAccount       = "house"
+	BankAccount        = "bank"
+	BonusPoolAccount   = "bonus_pool"
+	JackpotPoolAccount = "jackpot_pool"
+
+	playerAccountPrefix = "player:"
+)
+
+// counterAccounts is where the money of a player transaction comes from or goes to.
+var counterAccounts = map[TransactionType]string{
+	Bet:             HouseAccount,
+	Win:             HouseAccount,
+	Refund:          HouseAccount,
+	Deposit:         BankAccount,
+	Withdrawal:      BankAccount,
+	BonusCredit:     BonusPoolAccount,
+	BonusConversion: BonusPoolAccount,
+	JackpotWin:      JackpotPoolAccount,
+}
+
+// Posting is one leg of a transaction in the ledger. Positive amounts credit the account.
+type Posting struct {
+	ID              uuid.UUID
+	TransactionID   uuid.UUID
+	Account         string
+	Amount          int64
+	Currency        string
+	TransactionTime time.Time
+	Reporting       *Conversion
+}
+
+// AccountBalance is the sum of the postings of an account.
+type AccountBalance struct {
+	Balance   int64
+	Reporting *Conversion
+}
+
+func PlayerAccount(userID uuid.UUID) string {
+	return playerAccountPrefix + userID.String()
+}
+
+// ValidAccount tells whether the account is a named one or a player wallet.
+func ValidAccount(account string) bool {
+	for _, named := range counterAccounts {
+		if account == named {
+			return true
+		}
+	}
+
+	_, ok := PlayerOf(account)
+
+	return ok
+}
+
+// PlayerOf returns the user owning a player wallet account.
+func PlayerOf(account string) (uuid.UUID, bool) {
+	id, ok := strings.CutPrefix(account, playerAccountPrefix)
+	if !ok {
+		return uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
+
+// Postings returns the legs of the transaction: the player wallet and the account on the other side.
+// A rollback books against the account of the transaction it voids.
+func (t Transaction) Postings() []Posting {
+	amount := int64(t.Sign()) * t.Amount
+
+	counter := t.Type
+	if t.Type == Rollback {
+		counter = t.OriginalType
+	}
+
+	return []Posting{
+		{TransactionID: t.ID, Account: PlayerAccount(t.UserID), Amount: amount, Currency: t.Currency, TransactionTime: t.TransactionTime},
+		{TransactionID: t.ID, Account: counterAccounts[counter], Amount: -amount, Currency: t.Currency, TransactionTime: t.TransactionTime},
+	}
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransaction_Postings(t *testing.T) {
+	userID := uuid.New()
+	now := time.Now()
+
+	tests := []struct {
+		txType   TransactionType
+		original TransactionType
+		player   int64
+		counter  string
+	}{
+		{txType: Bet, player: -100, counter: HouseAccount},
+		{txType: Win, player: 100, counter: HouseAccount},
+		{txType: Refund, player: 100, counter: HouseAccount},
+		{txType: Rollback, original: Bet, player: 100, counter: HouseAccount},
+		{txType: Rollback, original: JackpotWin, player: -100, counter: JackpotPoolAccount},
+		{txType: Deposit, player: 100, counter: BankAccount},
+		{txType: Withdrawal, player: -100, counter: BankAccount},
+		{txType: BonusCredit, player: 100, counter: BonusPoolAccount},
+		{txType: BonusConversion, player: 100, counter: BonusPoolAccount},
+		{txType: JackpotWin, player: 100, counter: JackpotPoolAccount},
+	}
+
+	for _, tt := range tests {
+		name := string(tt.txType)
+		if len(tt.original) > 0 {
+			name += " of " + string(tt.original)
+		}
+
+		t.Run(name, func(t *testing.T) {
+			postings := Transaction{UserID: userID, Type: tt.txType, OriginalType: tt.original, Amount: 100, TransactionTime: now}.Postings()
+
+			assert.Len(t, postings, 2)
+			assert.Equal(t, PlayerAccount(userID), postings[0].Account)
+			assert.Equal(t, tt.player, postings[0].Amount)
+			assert.Equal(t, tt.counter, postings[1].Account)
+			assert.Equal(t, -tt.player, postings[1].Amount)
+		})
+	}
+}
+
+func TestPlayerOf(t *testing.T) {
+	userID := uuid.New()
+
+	id, ok := PlayerOf(PlayerAccount(userID))
+	assert.True(t, ok)
+	assert.Equal(t, userID, id)
+
+	for _, account := range []string{HouseAccount, "player:", "player:not-a-uuid", userID.String()} {
+		_, ok := PlayerOf(account)
+		assert.False(t, ok, account)
+	}
+}
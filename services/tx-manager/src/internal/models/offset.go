@@ -0,0 +1,8 @@
+package models
+
+type Offset struct {
+	Group     string
+	Topic     string
+	Partition int32
+	Offset    int64
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+)
+
+const RateDateLayout = "2006-01-02"
+
+// Rate is the number of units of Currency worth one euro from Date on, like the ECB reference rates.
+type Rate struct {
+	Date     time.Time
+	Currency string
+	Value    *big.Rat
+}
+
+// Conversion is an amount converted to a reporting currency together with the rate that was used.
+type Conversion struct {
+	Currency string
+	Amount   int64
+	// Rate is the number of units of Currency per unit of the original currency.
+	Rate     *big.Rat
+	RateDate time.Time
+}
+
+func ParseRate(date, currency, value string) (Rate, error) {
+	d, err := time.Parse(RateDateLayout, date)
+	if err != nil {
+		return Rate{}, fmt.Errorf("invalid rate date: %w", err)
+	}
+
+	if !ValidCurrency(currency) {
+		return Rate{}, fmt.Errorf("invalid rate currency: %s", currency)
+	}
+
+	v, ok := new(big.Rat).SetString(value)
+	if !ok || v.Sign() <= 0 {
+		return Rate{}, fmt.Errorf("invalid %s rate: %s", currency, value)
+	}
+
+	return Rate{Date: d, Currency: currency, Value: v}, nil
+}
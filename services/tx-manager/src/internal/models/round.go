@@ -0,0 +1,73 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RoundStatus string
+
+const (
+	RoundOpen    RoundStatus = "open"
+	RoundSettled RoundStatus = "settled"
+)
+
+// Round is the projection of all transactions sharing a round id.
+// It is settled once it has a bet and a win, refund or rollback.
+type Round struct {
+	ID           string
+	UserID       uuid.UUID
+	GameID       string
+	Provider     string
+	Status       RoundStatus
+	Currency     string
+	BetTotal     int64
+	WinTotal     int64
+	Net          int64
+	BetCount     int
+	WinCount     int
+	OpenedAt     time.Time
+	SettledAt    *time.Time
+	Orphaned     bool
+	UnmatchedWin bool
+	Transactions []Transaction
+}
+
+// Flag marks rounds left open for longer than timeout and wins that have no bet.
+func (r *Round) Flag(now time.Time, timeout time.Duration) {
+	r.Orphaned = r.Status == RoundOpen && r.OpenedAt.Before(now.Add(-timeout))
+	r.UnmatchedWin = r.WinCount > 0 && r.BetCount == 0
+}
+
+type RoundFilter struct {
+	UserID       *uuid.UUID
+	OpenedBefore *time.Time
+}
+
+func (rf RoundFilter) String() (string, []any) {
+	var conditions []string
+	var args []any
+
+	argPos := 1
+
+	if rf.UserID != nil {
+		conditions = append(conditions, fmt.Sprintf("user_id = $%d", argPos))
+		args = append(args, *rf.UserID)
+		argPos++
+	}
+
+	if rf.OpenedBefore != nil {
+		conditions = append(conditions, fmt.Sprintf("opened_at < $%d", argPos))
+		args = append(args, *rf.OpenedBefore)
+		argPos++
+	}
+
+	if len(conditions) > 0 {
+		return strings.Join(conditions, " AND "), args
+	}
+
+	return "", nil
+}
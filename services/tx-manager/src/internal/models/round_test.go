@@ -0,0 +1,85 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRound_Flag(t *testing.T) {
+	now := time.Date(2025, 1, 1, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		round        Round
+		orphaned     bool
+		unmatchedWin bool
+	}{
+		{
+			name:  "recent open round",
+			round: Round{Status: RoundOpen, BetCount: 1, OpenedAt: now.Add(-time.Minute)},
+		},
+		{
+			name:     "open round past the timeout",
+			round:    Round{Status: RoundOpen, BetCount: 1, OpenedAt: now.Add(-2 * time.Hour)},
+			orphaned: true,
+		},
+		{
+			name:  "settled round past the timeout",
+			round: Round{Status: RoundSettled, BetCount: 1, WinCount: 1, OpenedAt: now.Add(-2 * time.Hour)},
+		},
+		{
+			name:         "win without a bet",
+			round:        Round{Status: RoundOpen, WinCount: 1, OpenedAt: now.Add(-time.Minute)},
+			unmatchedWin: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.round.Flag(now, time.Hour)
+
+			assert.Equal(t, tt.orphaned, tt.round.Orphaned)
+			assert.Equal(t, tt.unmatchedWin, tt.round.UnmatchedWin)
+		})
+	}
+}
+
+func TestRoundFilter_String(t *testing.T) {
+	userID := uuid.New()
+	before := time.Date(2025, 1, 1, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		filter       RoundFilter
+		expectedSQL  string
+		expectedArgs []any
+	}{
+		{
+			name:         "user and opened before",
+			filter:       RoundFilter{UserID: &userID, OpenedBefore: &before},
+			expectedSQL:  "user_id = $1 AND opened_at < $2",
+			expectedArgs: []any{userID, before},
+		},
+		{
+			name:         "only opened before",
+			filter:       RoundFilter{OpenedBefore: &before},
+			expectedSQL:  "opened_at < $1",
+			expectedArgs: []any{before},
+		},
+		{
+			name:   "no filter",
+			filter: RoundFilter{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSQL, gotArgs := tt.filter.String()
+			assert.Equal(t, tt.expectedSQL, gotSQL)
+			assert.Equal(t, tt.expectedArgs, gotArgs)
+		})
+	}
+}
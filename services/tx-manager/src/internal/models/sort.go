@@ -0,0 +1,16 @@
+package models
+
+type SortField string
+
+const (
+	SortByUserID SortField = "user_id"
+	SortByType   SortField = "type"
+	SortByAmount SortField = "amount"
+	SortByTime   SortField = "time"
+)
+
+// Sort orders a listing by one field. Listings sorted by several fields apply them in turn.
+type Sort struct {
+	Field SortField
+	Desc  bool
+}
@@ -3,7 +3,6 @@ package models
 import (
 	"crypto/sha256"
 	"encoding/hex"
-	"fmt"
 	"strconv"
 	"strings"
 	"time"
@@ -13,51 +12,270 @@ import (
 
 type TransactionType string
 
+const (
+	HashVersion = "v2"
+
+	canonicalTimeLayout = "2006-01-02T15:04:05.000000Z"
+)
+
+type Direction string
+
+type Status string
+
+const (
+	Completed Status = "completed"
+	Voided    Status = "voided"
+)
+
+const (
+	Credit Direction = "credit"
+	Debit  Direction = "debit"
+)
+
 var (
-	Bet TransactionType = "bet"
-	Win TransactionType = "win"
+	Bet             TransactionType = "bet"
+	Win             TransactionType = "win"
+	Deposit         TransactionType = "deposit"
+	Withdrawal      TransactionType = "withdrawal"
+	Refund          TransactionType = "refund"
+	Rollback        TransactionType = "rollback"
+	BonusCredit     TransactionType = "bonus_credit"
+	BonusConversion TransactionType = "bonus_conversion"
+	JackpotWin      TransactionType = "jackpot_win"
 )
 
+var directions = map[TransactionType]Direction{
+	Bet:             Debit,
+	Win:             Credit,
+	Deposit:         Credit,
+	Withdrawal:      Debit,
+	Refund:          Credit,
+	BonusCredit:     Credit,
+	BonusConversion: Credit,
+	JackpotWin:      Credit,
+}
+
+func (t TransactionType) Valid() bool {
+	_, ok := directions[t]
+	return ok || t == Rollback
+}
+
+// Direction tells whether the transaction credits or debits the player.
+// A rollback has none of its own, it takes the reverse of the transaction it voids.
+func (t TransactionType) Direction() Direction {
+	return directions[t]
+}
+
+// Wins tells whether the transaction pays out a round.
+func (t TransactionType) Wins() bool {
+	return t == Win || t == JackpotWin
+}
+
+// Settles tells whether the transaction closes the round it belongs to.
+func (t TransactionType) Settles() bool {
+	return t.Wins() || t == Refund || t == Rollback
+}
+
+func (t TransactionType) Sign() int {
+	return t.Direction().Sign()
+}
+
+func (d Direction) Sign() int {
+	switch d {
+	case Credit:
+		return 1
+	case Debit:
+		return -1
+	default:
+		return 0
+	}
+}
+
+func (d Direction) Reverse() Direction {
+	switch d {
+	case Credit:
+		return Debit
+	case Debit:
+		return Credit
+	default:
+		return ""
+	}
+}
+
 type Transaction struct {
-	ID              uuid.UUID
-	UserID          uuid.UUID
-	Type            TransactionType
-	Amount          int
+	ID      uuid.UUID
+	EventID string
+	UserID  uuid.UUID
+	Type    TransactionType
+	// Amount is in minor units of Currency, e.g. cents for EUR.
+	Amount          int64
+	Currency        string
 	TransactionTime time.Time
+	// ReferenceID is the id or provider event id of the transaction this one relates to,
+	// e.g. the bet voided by a rollback. OriginalID is set once the reference is resolved.
+	ReferenceID string
+	OriginalID  *uuid.UUID
+	// OriginalType is the type of the transaction a rollback voids, it decides the rollback's direction.
+	OriginalType TransactionType
+	Status       Status
+	RoundID      string
+	GameID       string
+	Provider     string
+	SessionID    string
+	Channel      string
+	// Reporting is the amount in the reporting currency, when one was requested.
+	Reporting *Conversion
+}
+
+// Direction tells whether the transaction credits or debits the player,
+// a rollback moves the money back the other way than the transaction it voids.
+func (t Transaction) Direction() Direction {
+	if t.Type == Rollback {
+		return t.OriginalType.Direction().Reverse()
+	}
+
+	return t.Type.Direction()
+}
+
+func (t Transaction) Sign() int {
+	return t.Direction().Sign()
 }
 
 func (t *Transaction) Hash() string {
-	data := t.UserID.String() + string(t.Type) + strconv.Itoa(t.Amount) + t.TransactionTime.UTC().String()
+	parts := []string{
+		t.UserID.String(),
+		string(t.Type),
+		strconv.FormatInt(t.Amount, 10),
+		t.TransactionTime.UTC().Truncate(time.Microsecond).Format(canonicalTimeLayout),
+	}
+
+	// v2 hashes of referencing events were stored with the raw reference, so it keeps its place and format.
+	if len(t.ReferenceID) > 0 {
+		parts = append(parts, t.ReferenceID)
+	}
+
+	// Optional fields are appended by name only when set, so hashes of events without them don't change.
+	// Events without a currency are in the default one, so it is left out too.
+	currency := t.Currency
+	if currency == DefaultCurrency {
+		currency = ""
+	}
+
+	for _, field := range []struct{ name, value string }{
+		{"currency", currency},
+		{"round", t.RoundID},
+		{"game", t.GameID},
+		{"provider", t.Provider},
+		{"session", t.SessionID},
+		{"channel", t.Channel},
+	} {
+		if len(field.value) > 0 {
+			parts = append(parts, field.name+"="+field.value)
+		}
+	}
+
+	data := strings.Join(parts, "|")
+
 	hash := sha256.Sum256([]byte(data))
-	return hex.EncodeToString(hash[:])
+	return HashVersion + ":" + hex.EncodeToString(hash[:])
+}
+
+func (t *Transaction) DedupHash() *string {
+	if len(t.EventID) > 0 {
+		return nil
+	}
+
+	hash := t.Hash()
+	return &hash
 }
 
 type TransactionFilter struct {
-	UserID *uuid.UUID
-	Type   *TransactionType
+	UserID    *uuid.UUID
+	Type      *TransactionType
+	RoundID   *string
+	GameID    *string
+	Provider  *string
+	SessionID *string
+	Channel   *string
+	Currency  *string
+	// From is inclusive and To exclusive.
+	From *time.Time
+	To   *time.Time
+	// MinAmount and MaxAmount are in minor units and inclusive.
+	MinAmount *int64
+	MaxAmount *int64
+	// Expr is a filter expression, e.g. parsed from type==bet;amount>1000.
+	Expr *FilterExpr
 }
 
-func (tf TransactionFilter) String() (string, []any) {
-	var conditions []string
-	var args []any
+// Where compiles the filter to a condition with numbered parameters, the fields and the expression all have to match.
+func (tf TransactionFilter) Where() (string, []any, error) {
+	var exprs []FilterExpr
 
-	argPos := 1
+	compare := func(field FilterField, op FilterOp, value any) {
+		exprs = append(exprs, FilterExpr{Comparison: &Comparison{Field: field, Op: op, Values: []any{value}}})
+	}
 
 	if tf.UserID != nil {
-		conditions = append(conditions, fmt.Sprintf("user_id = $%d", argPos))
-		args = append(args, *tf.UserID)
-		argPos++
+		compare(FilterUserID, OpEqual, *tf.UserID)
 	}
 
 	if tf.Type != nil {
-		conditions = append(conditions, fmt.Sprintf("transaction_type = $%d", argPos))
-		args = append(args, *tf.Type)
-		argPos++
+		compare(FilterType, OpEqual, *tf.Type)
+	}
+
+	for _, field := range []struct {
+		field FilterField
+		value *string
+	}{
+		{FilterRoundID, tf.RoundID},
+		{FilterGameID, tf.GameID},
+		{FilterProvider, tf.Provider},
+		{FilterSessionID, tf.SessionID},
+		{FilterChannel, tf.Channel},
+		{FilterCurrency, tf.Currency},
+	} {
+		if field.value != nil {
+			compare(field.field, OpEqual, *field.value)
+		}
+	}
+
+	if tf.From != nil {
+		compare(FilterDate, OpGreaterOrEqual, *tf.From)
+	}
+
+	if tf.To != nil {
+		compare(FilterDate, OpLess, *tf.To)
+	}
+
+	if tf.MinAmount != nil {
+		compare(FilterAmount, OpGreaterOrEqual, *tf.MinAmount)
+	}
+
+	if tf.MaxAmount != nil {
+		compare(FilterAmount, OpLessOrEqual, *tf.MaxAmount)
+	}
+
+	if tf.Expr != nil {
+		if err := tf.Expr.Validate(); err != nil {
+			return "", nil, err
+		}
+
+		exprs = append(exprs, *tf.Expr)
 	}
 
-	if len(conditions) > 0 {
-		return strings.Join(conditions, " AND "), args
+	if len(exprs) == 0 {
+		return "", nil, nil
+	}
+
+	var args []any
+
+	conditions := make([]string, 0, len(exprs))
+	for _, e := range exprs {
+		var cond string
+		cond, args = e.SQL(args)
+		conditions = append(conditions, cond)
 	}
 
-	return "", nil
+	return strings.Join(conditions, " AND "), args, nil
 }
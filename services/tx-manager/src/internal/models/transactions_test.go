@@ -1,6 +1,9 @@
 package models
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
 	"testing"
 	"time"
 
@@ -84,6 +87,77 @@ func TestTransaction_Hash(t *testing.T) {
 			},
 			expectedSame: false,
 		},
+		{
+			name: "different reference produces different hash",
+			tx: Transaction{
+				UserID:          uid1,
+				Type:            Rollback,
+				Amount:          100,
+				TransactionTime: now,
+				ReferenceID:     "provider-event-1",
+			},
+			tx2: Transaction{
+				UserID:          uid1,
+				Type:            Rollback,
+				Amount:          100,
+				TransactionTime: now,
+				ReferenceID:     "provider-event-2",
+			},
+			expectedSame: false,
+		},
+		{
+			name: "same value in different optional fields produces different hash",
+			tx: Transaction{
+				UserID:          uid1,
+				Type:            Bet,
+				Amount:          100,
+				TransactionTime: now,
+				RoundID:         "r-1",
+			},
+			tx2: Transaction{
+				UserID:          uid1,
+				Type:            Bet,
+				Amount:          100,
+				TransactionTime: now,
+				SessionID:       "r-1",
+			},
+			expectedSame: false,
+		},
+		{
+			name: "default currency keeps the legacy hash",
+			tx: Transaction{
+				UserID:          uid1,
+				Type:            Bet,
+				Amount:          100,
+				TransactionTime: now,
+			},
+			tx2: Transaction{
+				UserID:          uid1,
+				Type:            Bet,
+				Amount:          100,
+				Currency:        DefaultCurrency,
+				TransactionTime: now,
+			},
+			expectedSame: true,
+		},
+		{
+			name: "different currency produces different hash",
+			tx: Transaction{
+				UserID:          uid1,
+				Type:            Bet,
+				Amount:          100,
+				Currency:        "EUR",
+				TransactionTime: now,
+			},
+			tx2: Transaction{
+				UserID:          uid1,
+				Type:            Bet,
+				Amount:          100,
+				Currency:        "USD",
+				TransactionTime: now,
+			},
+			expectedSame: false,
+		},
 		{
 			name: "different timestamp produces different hash",
 			tx: Transaction{
@@ -105,6 +179,8 @@ func TestTransaction_Hash(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			h1 := tt.tx.Hash()
+			assert.True(t, strings.HasPrefix(h1, HashVersion+":"))
+
 			h2 := tt.tx2.Hash()
 
 			if tt.expectedSame {
@@ -116,9 +192,92 @@ func TestTransaction_Hash(t *testing.T) {
 	}
 }
 
-func TestTransactionFilter_String(t *testing.T) {
+func TestTransaction_HashIsCanonical(t *testing.T) {
+	uid := uuid.MustParse("461805a5-d762-441b-91ac-961629f926e7")
+	utc := time.Date(2025, 1, 1, 15, 0, 0, 123456789, time.UTC)
+
+	tx := Transaction{UserID: uid, Type: Bet, Amount: 100, TransactionTime: utc}
+	sameInstant := Transaction{UserID: uid, Type: Bet, Amount: 100, TransactionTime: utc.In(time.FixedZone("UTC+3", 3*60*60))}
+	storedPrecision := Transaction{UserID: uid, Type: Bet, Amount: 100, TransactionTime: utc.Truncate(time.Microsecond)}
+
+	sum := sha256.Sum256([]byte("461805a5-d762-441b-91ac-961629f926e7|bet|100|2025-01-01T15:00:00.123456Z"))
+
+	assert.Equal(t, "v2:"+hex.EncodeToString(sum[:]), tx.Hash())
+	assert.Equal(t, tx.Hash(), sameInstant.Hash())
+	assert.Equal(t, tx.Hash(), storedPrecision.Hash())
+
+	rollback := Transaction{UserID: uid, Type: Rollback, Amount: 100, TransactionTime: utc, ReferenceID: "bet-1", RoundID: "r1"}
+	sum = sha256.Sum256([]byte("461805a5-d762-441b-91ac-961629f926e7|rollback|100|2025-01-01T15:00:00.123456Z|bet-1|round=r1"))
+
+	assert.Equal(t, "v2:"+hex.EncodeToString(sum[:]), rollback.Hash())
+}
+
+func TestTransaction_DedupHash(t *testing.T) {
+	tx := Transaction{UserID: uuid.New(), Type: Bet, Amount: 100, TransactionTime: time.Now()}
+
+	hash := tx.DedupHash()
+	assert.NotNil(t, hash)
+	assert.Equal(t, tx.Hash(), *hash)
+
+	tx.EventID = "provider-event-1"
+	assert.Nil(t, tx.DedupHash())
+}
+
+func TestTransactionType_Direction(t *testing.T) {
+	tests := []struct {
+		txType    TransactionType
+		direction Direction
+		sign      int
+	}{
+		{txType: Bet, direction: Debit, sign: -1},
+		{txType: Win, direction: Credit, sign: 1},
+		{txType: Deposit, direction: Credit, sign: 1},
+		{txType: Withdrawal, direction: Debit, sign: -1},
+		{txType: Refund, direction: Credit, sign: 1},
+		{txType: Rollback, direction: "", sign: 0},
+		{txType: BonusCredit, direction: Credit, sign: 1},
+		{txType: BonusConversion, direction: Credit, sign: 1},
+		{txType: JackpotWin, direction: Credit, sign: 1},
+		{txType: TransactionType("unknown"), direction: "", sign: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.txType), func(t *testing.T) {
+			assert.Equal(t, tt.direction, tt.txType.Direction())
+			assert.Equal(t, tt.sign, tt.txType.Sign())
+			assert.Equal(t, tt.direction != "" || tt.txType == Rollback, tt.txType.Valid())
+		})
+	}
+}
+
+func TestTransaction_Direction(t *testing.T) {
+	tests := []struct {
+		name      string
+		tx        Transaction
+		direction Direction
+		sign      int
+	}{
+		{name: "bet", tx: Transaction{Type: Bet}, direction: Debit, sign: -1},
+		{name: "rollback of a bet", tx: Transaction{Type: Rollback, OriginalType: Bet}, direction: Credit, sign: 1},
+		{name: "rollback of a win", tx: Transaction{Type: Rollback, OriginalType: Win}, direction: Debit, sign: -1},
+		{name: "rollback of a withdrawal", tx: Transaction{Type: Rollback, OriginalType: Withdrawal}, direction: Credit, sign: 1},
+		{name: "unresolved rollback", tx: Transaction{Type: Rollback}, direction: "", sign: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.direction, tt.tx.Direction())
+			assert.Equal(t, tt.sign, tt.tx.Sign())
+		})
+	}
+}
+
+func TestTransactionFilter_Where(t *testing.T) {
 	userID1 := uuid.New()
 	userID2 := uuid.New()
+	from := time.Date(2025, 1, 1, 22, 0, 0, 0, time.UTC)
+	to := from.Add(8 * time.Hour)
+	minAmount, maxAmount := int64(1000), int64(50000)
 
 	tests := []struct {
 		name         string
@@ -151,6 +310,59 @@ func TestTransactionFilter_String(t *testing.T) {
 			expectedSQL:  "transaction_type = $1",
 			expectedArgs: []any{Win},
 		},
+		{
+			name: "game context fields set",
+			filter: TransactionFilter{
+				UserID:   &userID1,
+				RoundID:  ptr("round-1"),
+				Provider: ptr("pragmatic"),
+				Channel:  ptr("mobile"),
+			},
+			expectedSQL:  "user_id = $1 AND round_id = $2 AND provider = $3 AND channel = $4",
+			expectedArgs: []any{userID1, "round-1", "pragmatic", "mobile"},
+		},
+		{
+			name: "currency set",
+			filter: TransactionFilter{
+				UserID:   &userID1,
+				Currency: ptr("USD"),
+			},
+			expectedSQL:  "user_id = $1 AND currency = $2",
+			expectedArgs: []any{userID1, "USD"},
+		},
+		{
+			name: "time and amount ranges set",
+			filter: TransactionFilter{
+				Type:      ptrTransactionType(Bet),
+				From:      &from,
+				To:        &to,
+				MinAmount: &minAmount,
+				MaxAmount: &maxAmount,
+			},
+			expectedSQL:  "transaction_type = $1 AND transaction_time >= $2 AND transaction_time < $3 AND amount >= $4 AND amount <= $5",
+			expectedArgs: []any{Bet, from, to, minAmount, maxAmount},
+		},
+		{
+			name: "only lower amount bound set",
+			filter: TransactionFilter{
+				UserID:    &userID2,
+				MinAmount: &minAmount,
+			},
+			expectedSQL:  "user_id = $1 AND amount >= $2",
+			expectedArgs: []any{userID2, minAmount},
+		},
+		{
+			name: "fields and expression",
+			filter: TransactionFilter{
+				UserID: &userID1,
+				Expr: &FilterExpr{Or: []FilterExpr{
+					{Comparison: &Comparison{Field: FilterType, Op: OpEqual, Values: []any{Win}}},
+					{Comparison: &Comparison{Field: FilterAmount, Op: OpGreater, Values: []any{maxAmount}}},
+				}},
+			},
+			expectedSQL:  "user_id = $1 AND (transaction_type = $2 OR amount > $3)",
+			expectedArgs: []any{userID1, Win, maxAmount},
+		},
 		{
 			name:         "neither field set",
 			filter:       TransactionFilter{},
@@ -161,13 +373,25 @@ func TestTransactionFilter_String(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotSQL, gotArgs := tt.filter.String()
+			gotSQL, gotArgs, err := tt.filter.Where()
+			assert.NoError(t, err)
 			assert.Equal(t, tt.expectedSQL, gotSQL)
 			assert.Equal(t, tt.expectedArgs, gotArgs)
 		})
 	}
 }
 
+func TestTransactionFilter_WhereInvalidExpr(t *testing.T) {
+	filter := TransactionFilter{Expr: &FilterExpr{Comparison: &Comparison{Field: FilterDate, Op: OpEqual, Values: []any{time.Now()}}}}
+
+	_, _, err := filter.Where()
+	assert.Error(t, err)
+}
+
 func ptrTransactionType(t TransactionType) *TransactionType {
 	return &t
 }
+
+func ptr(s string) *string {
+	return &s
+}
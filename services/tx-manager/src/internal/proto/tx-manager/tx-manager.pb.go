@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.36.5
+// 	protoc-gen-go v1.36.10
 // 	protoc        v5.29.3
 // source: tx-manager.proto
 
@@ -24,9 +24,16 @@ const (
 type TransactionType int32
 
 const (
-	TransactionType_All TransactionType = 0
-	TransactionType_Bet TransactionType = 1
-	TransactionType_Win TransactionType = 2
+	TransactionType_All             TransactionType = 0
+	TransactionType_Bet             TransactionType = 1
+	TransactionType_Win             TransactionType = 2
+	TransactionType_Deposit         TransactionType = 3
+	TransactionType_Withdrawal      TransactionType = 4
+	TransactionType_Refund          TransactionType = 5
+	TransactionType_Rollback        TransactionType = 6
+	TransactionType_BonusCredit     TransactionType = 7
+	TransactionType_BonusConversion TransactionType = 8
+	TransactionType_JackpotWin      TransactionType = 9
 )
 
 // Enum value maps for TransactionType.
@@ -35,11 +42,25 @@ var (
 		0: "All",
 		1: "Bet",
 		2: "Win",
+		3: "Deposit",
+		4: "Withdrawal",
+		5: "Refund",
+		6: "Rollback",
+		7: "BonusCredit",
+		8: "BonusConversion",
+		9: "JackpotWin",
 	}
 	TransactionType_value = map[string]int32{
-		"All": 0,
-		"Bet": 1,
-		"Win": 2,
+		"All":             0,
+		"Bet":             1,
+		"Win":             2,
+		"Deposit":         3,
+		"Withdrawal":      4,
+		"Refund":          5,
+		"Rollback":        6,
+		"BonusCredit":     7,
+		"BonusConversion": 8,
+		"JackpotWin":      9,
 	}
 )
 
@@ -70,29 +91,2197 @@ func (TransactionType) EnumDescriptor() ([]byte, []int) {
 	return file_tx_manager_proto_rawDescGZIP(), []int{0}
 }
 
+type Direction int32
+
+const (
+	Direction_UnknownDirection Direction = 0
+	Direction_Credit           Direction = 1
+	Direction_Debit            Direction = 2
+)
+
+// Enum value maps for Direction.
+var (
+	Direction_name = map[int32]string{
+		0: "UnknownDirection",
+		1: "Credit",
+		2: "Debit",
+	}
+	Direction_value = map[string]int32{
+		"UnknownDirection": 0,
+		"Credit":           1,
+		"Debit":            2,
+	}
+)
+
+func (x Direction) Enum() *Direction {
+	p := new(Direction)
+	*p = x
+	return p
+}
+
+func (x Direction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Direction) Descriptor() protoreflect.EnumDescriptor {
+	return file_tx_manager_proto_enumTypes[1].Descriptor()
+}
+
+func (Direction) Type() protoreflect.EnumType {
+	return &file_tx_manager_proto_enumTypes[1]
+}
+
+func (x Direction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Direction.Descriptor instead.
+func (Direction) EnumDescriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{1}
+}
+
+type Status int32
+
+const (
+	Status_UnknownStatus Status = 0
+	Status_Completed     Status = 1
+	Status_Voided        Status = 2
+)
+
+// Enum value maps for Status.
+var (
+	Status_name = map[int32]string{
+		0: "UnknownStatus",
+		1: "Completed",
+		2: "Voided",
+	}
+	Status_value = map[string]int32{
+		"UnknownStatus": 0,
+		"Completed":     1,
+		"Voided":        2,
+	}
+)
+
+func (x Status) Enum() *Status {
+	p := new(Status)
+	*p = x
+	return p
+}
+
+func (x Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_tx_manager_proto_enumTypes[2].Descriptor()
+}
+
+func (Status) Type() protoreflect.EnumType {
+	return &file_tx_manager_proto_enumTypes[2]
+}
+
+func (x Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Status.Descriptor instead.
+func (Status) EnumDescriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{2}
+}
+
+type FilterField int32
+
+const (
+	FilterField_UnknownFilterField FilterField = 0
+	FilterField_FilterId           FilterField = 1
+	FilterField_FilterUserId       FilterField = 2
+	FilterField_FilterType         FilterField = 3
+	FilterField_FilterAmount       FilterField = 4
+	FilterField_FilterCurrency     FilterField = 5
+	FilterField_FilterDate         FilterField = 6
+	FilterField_FilterRoundId      FilterField = 7
+	FilterField_FilterGameId       FilterField = 8
+	FilterField_FilterProvider     FilterField = 9
+	FilterField_FilterSessionId    FilterField = 10
+	FilterField_FilterChannel      FilterField = 11
+)
+
+// Enum value maps for FilterField.
+var (
+	FilterField_name = map[int32]string{
+		0:  "UnknownFilterField",
+		1:  "FilterId",
+		2:  "FilterUserId",
+		3:  "FilterType",
+		4:  "FilterAmount",
+		5:  "FilterCurrency",
+		6:  "FilterDate",
+		7:  "FilterRoundId",
+		8:  "FilterGameId",
+		9:  "FilterProvider",
+		10: "FilterSessionId",
+		11: "FilterChannel",
+	}
+	FilterField_value = map[string]int32{
+		"UnknownFilterField": 0,
+		"FilterId":           1,
+		"FilterUserId":       2,
+		"FilterType":         3,
+		"FilterAmount":       4,
+		"FilterCurrency":     5,
+		"FilterDate":         6,
+		"FilterRoundId":      7,
+		"FilterGameId":       8,
+		"FilterProvider":     9,
+		"FilterSessionId":    10,
+		"FilterChannel":      11,
+	}
+)
+
+func (x FilterField) Enum() *FilterField {
+	p := new(FilterField)
+	*p = x
+	return p
+}
+
+func (x FilterField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FilterField) Descriptor() protoreflect.EnumDescriptor {
+	return file_tx_manager_proto_enumTypes[3].Descriptor()
+}
+
+func (FilterField) Type() protoreflect.EnumType {
+	return &file_tx_manager_proto_enumTypes[3]
+}
+
+func (x FilterField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FilterField.Descriptor instead.
+func (FilterField) EnumDescriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{3}
+}
+
+type ComparisonOp int32
+
+const (
+	ComparisonOp_UnknownComparisonOp ComparisonOp = 0
+	ComparisonOp_Equal               ComparisonOp = 1
+	ComparisonOp_NotEqual            ComparisonOp = 2
+	ComparisonOp_Less                ComparisonOp = 3
+	ComparisonOp_LessOrEqual         ComparisonOp = 4
+	ComparisonOp_Greater             ComparisonOp = 5
+	ComparisonOp_GreaterOrEqual      ComparisonOp = 6
+	ComparisonOp_In                  ComparisonOp = 7
+	ComparisonOp_NotIn               ComparisonOp = 8
+)
+
+// Enum value maps for ComparisonOp.
+var (
+	ComparisonOp_name = map[int32]string{
+		0: "UnknownComparisonOp",
+		1: "Equal",
+		2: "NotEqual",
+		3: "Less",
+		4: "LessOrEqual",
+		5: "Greater",
+		6: "GreaterOrEqual",
+		7: "In",
+		8: "NotIn",
+	}
+	ComparisonOp_value = map[string]int32{
+		"UnknownComparisonOp": 0,
+		"Equal":               1,
+		"NotEqual":            2,
+		"Less":                3,
+		"LessOrEqual":         4,
+		"Greater":             5,
+		"GreaterOrEqual":      6,
+		"In":                  7,
+		"NotIn":               8,
+	}
+)
+
+func (x ComparisonOp) Enum() *ComparisonOp {
+	p := new(ComparisonOp)
+	*p = x
+	return p
+}
+
+func (x ComparisonOp) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ComparisonOp) Descriptor() protoreflect.EnumDescriptor {
+	return file_tx_manager_proto_enumTypes[4].Descriptor()
+}
+
+func (ComparisonOp) Type() protoreflect.EnumType {
+	return &file_tx_manager_proto_enumTypes[4]
+}
+
+func (x ComparisonOp) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ComparisonOp.Descriptor instead.
+func (ComparisonOp) EnumDescriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{4}
+}
+
+type SortKey int32
+
+const (
+	SortKey_UnknownSortKey SortKey = 0
+	SortKey_SortUserId     SortKey = 1
+	SortKey_SortType       SortKey = 2
+	SortKey_SortAmount     SortKey = 3
+	SortKey_SortTime       SortKey = 4
+)
+
+// Enum value maps for SortKey.
+var (
+	SortKey_name = map[int32]string{
+		0: "UnknownSortKey",
+		1: "SortUserId",
+		2: "SortType",
+		3: "SortAmount",
+		4: "SortTime",
+	}
+	SortKey_value = map[string]int32{
+		"UnknownSortKey": 0,
+		"SortUserId":     1,
+		"SortType":       2,
+		"SortAmount":     3,
+		"SortTime":       4,
+	}
+)
+
+func (x SortKey) Enum() *SortKey {
+	p := new(SortKey)
+	*p = x
+	return p
+}
+
+func (x SortKey) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortKey) Descriptor() protoreflect.EnumDescriptor {
+	return file_tx_manager_proto_enumTypes[5].Descriptor()
+}
+
+func (SortKey) Type() protoreflect.EnumType {
+	return &file_tx_manager_proto_enumTypes[5]
+}
+
+func (x SortKey) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortKey.Descriptor instead.
+func (SortKey) EnumDescriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{5}
+}
+
+type SortDirection int32
+
+const (
+	SortDirection_Ascending  SortDirection = 0
+	SortDirection_Descending SortDirection = 1
+)
+
+// Enum value maps for SortDirection.
+var (
+	SortDirection_name = map[int32]string{
+		0: "Ascending",
+		1: "Descending",
+	}
+	SortDirection_value = map[string]int32{
+		"Ascending":  0,
+		"Descending": 1,
+	}
+)
+
+func (x SortDirection) Enum() *SortDirection {
+	p := new(SortDirection)
+	*p = x
+	return p
+}
+
+func (x SortDirection) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortDirection) Descriptor() protoreflect.EnumDescriptor {
+	return file_tx_manager_proto_enumTypes[6].Descriptor()
+}
+
+func (SortDirection) Type() protoreflect.EnumType {
+	return &file_tx_manager_proto_enumTypes[6]
+}
+
+func (x SortDirection) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortDirection.Descriptor instead.
+func (SortDirection) EnumDescriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{6}
+}
+
+type CountMode int32
+
+const (
+	// Cheap on large tables but can be far off.
+	CountMode_CountEstimated CountMode = 0
+	// Counts every matching row, which is slow on large tables.
+	CountMode_CountExact CountMode = 1
+)
+
+// Enum value maps for CountMode.
+var (
+	CountMode_name = map[int32]string{
+		0: "CountEstimated",
+		1: "CountExact",
+	}
+	CountMode_value = map[string]int32{
+		"CountEstimated": 0,
+		"CountExact":     1,
+	}
+)
+
+func (x CountMode) Enum() *CountMode {
+	p := new(CountMode)
+	*p = x
+	return p
+}
+
+func (x CountMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CountMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_tx_manager_proto_enumTypes[7].Descriptor()
+}
+
+func (CountMode) Type() protoreflect.EnumType {
+	return &file_tx_manager_proto_enumTypes[7]
+}
+
+func (x CountMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CountMode.Descriptor instead.
+func (CountMode) EnumDescriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{7}
+}
+
+type RoundStatus int32
+
+const (
+	RoundStatus_UnknownRoundStatus RoundStatus = 0
+	RoundStatus_Open               RoundStatus = 1
+	RoundStatus_Settled            RoundStatus = 2
+)
+
+// Enum value maps for RoundStatus.
+var (
+	RoundStatus_name = map[int32]string{
+		0: "UnknownRoundStatus",
+		1: "Open",
+		2: "Settled",
+	}
+	RoundStatus_value = map[string]int32{
+		"UnknownRoundStatus": 0,
+		"Open":               1,
+		"Settled":            2,
+	}
+)
+
+func (x RoundStatus) Enum() *RoundStatus {
+	p := new(RoundStatus)
+	*p = x
+	return p
+}
+
+func (x RoundStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RoundStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_tx_manager_proto_enumTypes[8].Descriptor()
+}
+
+func (RoundStatus) Type() protoreflect.EnumType {
+	return &file_tx_manager_proto_enumTypes[8]
+}
+
+func (x RoundStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RoundStatus.Descriptor instead.
+func (RoundStatus) EnumDescriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{8}
+}
+
 type GetTransactionByFiltersResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Transaction []*Transaction         `protobuf:"bytes,1,rep,name=transaction,proto3" json:"transaction,omitempty"`
+	// Number of transactions matching the filters across all pages.
+	Total int64 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	// Set in keyset mode when there are more transactions, pass it as page_token to get them.
+	NextPageToken string `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// False when total is the planner's estimate.
+	TotalExact    bool `protobuf:"varint,4,opt,name=total_exact,json=totalExact,proto3" json:"total_exact,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransactionByFiltersResponse) Reset() {
+	*x = GetTransactionByFiltersResponse{}
+	mi := &file_tx_manager_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransactionByFiltersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionByFiltersResponse) ProtoMessage() {}
+
+func (x *GetTransactionByFiltersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tx_manager_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionByFiltersResponse.ProtoReflect.Descriptor instead.
+func (*GetTransactionByFiltersResponse) Descriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetTransactionByFiltersResponse) GetTransaction() []*Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *GetTransactionByFiltersResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetTransactionByFiltersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *GetTransactionByFiltersResponse) GetTotalExact() bool {
+	if x != nil {
+		return x.TotalExact
+	}
+	return false
+}
+
+type Filters struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	UserId    string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Type      TransactionType        `protobuf:"varint,2,opt,name=type,proto3,enum=tx_manager.TransactionType" json:"type,omitempty"`
+	RoundId   string                 `protobuf:"bytes,3,opt,name=round_id,json=roundId,proto3" json:"round_id,omitempty"`
+	GameId    string                 `protobuf:"bytes,4,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	Provider  string                 `protobuf:"bytes,5,opt,name=provider,proto3" json:"provider,omitempty"`
+	SessionId string                 `protobuf:"bytes,6,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Channel   string                 `protobuf:"bytes,7,opt,name=channel,proto3" json:"channel,omitempty"`
+	// ISO 4217 code.
+	Currency string `protobuf:"bytes,8,opt,name=currency,proto3" json:"currency,omitempty"`
+	// Unix seconds, from is inclusive and to exclusive.
+	From *int64 `protobuf:"varint,9,opt,name=from,proto3,oneof" json:"from,omitempty"`
+	To   *int64 `protobuf:"varint,10,opt,name=to,proto3,oneof" json:"to,omitempty"`
+	// In minor units, both inclusive.
+	MinAmount     *int64 `protobuf:"varint,11,opt,name=min_amount,json=minAmount,proto3,oneof" json:"min_amount,omitempty"`
+	MaxAmount     *int64 `protobuf:"varint,12,opt,name=max_amount,json=maxAmount,proto3,oneof" json:"max_amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Filters) Reset() {
+	*x = Filters{}
+	mi := &file_tx_manager_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Filters) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Filters) ProtoMessage() {}
+
+func (x *Filters) ProtoReflect() protoreflect.Message {
+	mi := &file_tx_manager_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Filters.ProtoReflect.Descriptor instead.
+func (*Filters) Descriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Filters) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Filters) GetType() TransactionType {
+	if x != nil {
+		return x.Type
+	}
+	return TransactionType_All
+}
+
+func (x *Filters) GetRoundId() string {
+	if x != nil {
+		return x.RoundId
+	}
+	return ""
+}
+
+func (x *Filters) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *Filters) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *Filters) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *Filters) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *Filters) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Filters) GetFrom() int64 {
+	if x != nil && x.From != nil {
+		return *x.From
+	}
+	return 0
+}
+
+func (x *Filters) GetTo() int64 {
+	if x != nil && x.To != nil {
+		return *x.To
+	}
+	return 0
+}
+
+func (x *Filters) GetMinAmount() int64 {
+	if x != nil && x.MinAmount != nil {
+		return *x.MinAmount
+	}
+	return 0
+}
+
+func (x *Filters) GetMaxAmount() int64 {
+	if x != nil && x.MaxAmount != nil {
+		return *x.MaxAmount
+	}
+	return 0
+}
+
+// FilterExpr is a node of a filter expression, e.g. type==bet;amount>1000 is
+// an and of two comparisons.
+type FilterExpr struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Node:
+	//
+	//	*FilterExpr_Comparison
+	//	*FilterExpr_And
+	//	*FilterExpr_Or
+	Node          isFilterExpr_Node `protobuf_oneof:"node"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FilterExpr) Reset() {
+	*x = FilterExpr{}
+	mi := &file_tx_manager_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FilterExpr) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterExpr) ProtoMessage() {}
+
+func (x *FilterExpr) ProtoReflect() protoreflect.Message {
+	mi := &file_tx_manager_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterExpr.ProtoReflect.Descriptor instead.
+func (*FilterExpr) Descriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FilterExpr) GetNode() isFilterExpr_Node {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+func (x *FilterExpr) GetComparison() *Comparison {
+	if x != nil {
+		if x, ok := x.Node.(*FilterExpr_Comparison); ok {
+			return x.Comparison
+		}
+	}
+	return nil
+}
+
+func (x *FilterExpr) GetAnd() *FilterList {
+	if x != nil {
+		if x, ok := x.Node.(*FilterExpr_And); ok {
+			return x.And
+		}
+	}
+	return nil
+}
+
+func (x *FilterExpr) GetOr() *FilterList {
+	if x != nil {
+		if x, ok := x.Node.(*FilterExpr_Or); ok {
+			return x.Or
+		}
+	}
+	return nil
+}
+
+type isFilterExpr_Node interface {
+	isFilterExpr_Node()
+}
+
+type FilterExpr_Comparison struct {
+	Comparison *Comparison `protobuf:"bytes,1,opt,name=comparison,proto3,oneof"`
+}
+
+type FilterExpr_And struct {
+	And *FilterList `protobuf:"bytes,2,opt,name=and,proto3,oneof"`
+}
+
+type FilterExpr_Or struct {
+	Or *FilterList `protobuf:"bytes,3,opt,name=or,proto3,oneof"`
+}
+
+func (*FilterExpr_Comparison) isFilterExpr_Node() {}
+
+func (*FilterExpr_And) isFilterExpr_Node() {}
+
+func (*FilterExpr_Or) isFilterExpr_Node() {}
+
+type FilterList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Operands      []*FilterExpr          `protobuf:"bytes,1,rep,name=operands,proto3" json:"operands,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FilterList) Reset() {
+	*x = FilterList{}
+	mi := &file_tx_manager_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FilterList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterList) ProtoMessage() {}
+
+func (x *FilterList) ProtoReflect() protoreflect.Message {
+	mi := &file_tx_manager_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterList.ProtoReflect.Descriptor instead.
+func (*FilterList) Descriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FilterList) GetOperands() []*FilterExpr {
+	if x != nil {
+		return x.Operands
+	}
+	return nil
+}
+
+type Comparison struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Field FilterField            `protobuf:"varint,1,opt,name=field,proto3,enum=tx_manager.FilterField" json:"field,omitempty"`
+	Op    ComparisonOp           `protobuf:"varint,2,opt,name=op,proto3,enum=tx_manager.ComparisonOp" json:"op,omitempty"`
+	// One value, or a list for In and NotIn. The kind has to fit the field:
+	// text for ids, type, currency and game context, integer for amount and time for date.
+	Values        []*FilterValue `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Comparison) Reset() {
+	*x = Comparison{}
+	mi := &file_tx_manager_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Comparison) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Comparison) ProtoMessage() {}
+
+func (x *Comparison) ProtoReflect() protoreflect.Message {
+	mi := &file_tx_manager_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Comparison.ProtoReflect.Descriptor instead.
+func (*Comparison) Descriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Comparison) GetField() FilterField {
+	if x != nil {
+		return x.Field
+	}
+	return FilterField_UnknownFilterField
+}
+
+func (x *Comparison) GetOp() ComparisonOp {
+	if x != nil {
+		return x.Op
+	}
+	return ComparisonOp_UnknownComparisonOp
+}
+
+func (x *Comparison) GetValues() []*FilterValue {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type FilterValue struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Kind:
+	//
+	//	*FilterValue_Text
+	//	*FilterValue_Integer
+	//	*FilterValue_Time
+	Kind          isFilterValue_Kind `protobuf_oneof:"kind"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FilterValue) Reset() {
+	*x = FilterValue{}
+	mi := &file_tx_manager_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FilterValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterValue) ProtoMessage() {}
+
+func (x *FilterValue) ProtoReflect() protoreflect.Message {
+	mi := &file_tx_manager_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterValue.ProtoReflect.Descriptor instead.
+func (*FilterValue) Descriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *FilterValue) GetKind() isFilterValue_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return nil
+}
+
+func (x *FilterValue) GetText() string {
+	if x != nil {
+		if x, ok := x.Kind.(*FilterValue_Text); ok {
+			return x.Text
+		}
+	}
+	return ""
+}
+
+func (x *FilterValue) GetInteger() int64 {
+	if x != nil {
+		if x, ok := x.Kind.(*FilterValue_Integer); ok {
+			return x.Integer
+		}
+	}
+	return 0
+}
+
+func (x *FilterValue) GetTime() int64 {
+	if x != nil {
+		if x, ok := x.Kind.(*FilterValue_Time); ok {
+			return x.Time
+		}
+	}
+	return 0
+}
+
+type isFilterValue_Kind interface {
+	isFilterValue_Kind()
+}
+
+type FilterValue_Text struct {
+	Text string `protobuf:"bytes,1,opt,name=text,proto3,oneof"`
+}
+
+type FilterValue_Integer struct {
+	Integer int64 `protobuf:"varint,2,opt,name=integer,proto3,oneof"`
+}
+
+type FilterValue_Time struct {
+	// Unix seconds.
+	Time int64 `protobuf:"varint,3,opt,name=time,proto3,oneof"`
+}
+
+func (*FilterValue_Text) isFilterValue_Kind() {}
+
+func (*FilterValue_Integer) isFilterValue_Kind() {}
+
+func (*FilterValue_Time) isFilterValue_Kind() {}
+
+type SortField struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         SortKey                `protobuf:"varint,1,opt,name=field,proto3,enum=tx_manager.SortKey" json:"field,omitempty"`
+	Direction     SortDirection          `protobuf:"varint,2,opt,name=direction,proto3,enum=tx_manager.SortDirection" json:"direction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SortField) Reset() {
+	*x = SortField{}
+	mi := &file_tx_manager_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SortField) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SortField) ProtoMessage() {}
+
+func (x *SortField) ProtoReflect() protoreflect.Message {
+	mi := &file_tx_manager_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SortField.ProtoReflect.Descriptor instead.
+func (*SortField) Descriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SortField) GetField() SortKey {
+	if x != nil {
+		return x.Field
+	}
+	return SortKey_UnknownSortKey
+}
+
+func (x *SortField) GetDirection() SortDirection {
+	if x != nil {
+		return x.Direction
+	}
+	return SortDirection_Ascending
+}
+
+// Without orderBy and offset transactions are paged by keyset: newest first, continued with page_token.
+// orderBy and offset select the legacy offset paging.
+type GetTransactionByFiltersRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Filters *Filters               `protobuf:"bytes,1,opt,name=filters,proto3" json:"filters,omitempty"`
+	// Applied in turn, ties are broken by id. Selects offset paging.
+	OrderBy []*SortField `protobuf:"bytes,8,rep,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	// At most 1000.
+	Limit  int64 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset int64 `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	// ISO 4217 code to convert amounts to, with the rate valid at each transaction.
+	ReportingCurrency string `protobuf:"bytes,5,opt,name=reporting_currency,json=reportingCurrency,proto3" json:"reporting_currency,omitempty"`
+	// next_page_token of the previous page.
+	PageToken string    `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	CountMode CountMode `protobuf:"varint,7,opt,name=count_mode,json=countMode,proto3,enum=tx_manager.CountMode" json:"count_mode,omitempty"`
+	// Matched together with filters.
+	Filter        *FilterExpr `protobuf:"bytes,9,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransactionByFiltersRequest) Reset() {
+	*x = GetTransactionByFiltersRequest{}
+	mi := &file_tx_manager_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransactionByFiltersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionByFiltersRequest) ProtoMessage() {}
+
+func (x *GetTransactionByFiltersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tx_manager_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionByFiltersRequest.ProtoReflect.Descriptor instead.
+func (*GetTransactionByFiltersRequest) Descriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetTransactionByFiltersRequest) GetFilters() *Filters {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+func (x *GetTransactionByFiltersRequest) GetOrderBy() []*SortField {
+	if x != nil {
+		return x.OrderBy
+	}
+	return nil
+}
+
+func (x *GetTransactionByFiltersRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetTransactionByFiltersRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetTransactionByFiltersRequest) GetReportingCurrency() string {
+	if x != nil {
+		return x.ReportingCurrency
+	}
+	return ""
+}
+
+func (x *GetTransactionByFiltersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetTransactionByFiltersRequest) GetCountMode() CountMode {
+	if x != nil {
+		return x.CountMode
+	}
+	return CountMode_CountEstimated
+}
+
+func (x *GetTransactionByFiltersRequest) GetFilter() *FilterExpr {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type BatchGetTransactionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// At most the configured batch size, duplicates are returned once.
+	Ids []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	// ISO 4217 code to convert amounts to, with the rate valid at each transaction.
+	ReportingCurrency string `protobuf:"bytes,2,opt,name=reporting_currency,json=reportingCurrency,proto3" json:"reporting_currency,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *BatchGetTransactionsRequest) Reset() {
+	*x = BatchGetTransactionsRequest{}
+	mi := &file_tx_manager_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetTransactionsRequest) ProtoMessage() {}
+
+func (x *BatchGetTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tx_manager_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BatchGetTransactionsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *BatchGetTransactionsRequest) GetReportingCurrency() string {
+	if x != nil {
+		return x.ReportingCurrency
+	}
+	return ""
+}
+
+type BatchGetTransactionsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// In the order of the request.
+	Transactions  []*Transaction `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	MissingIds    []string       `protobuf:"bytes,2,rep,name=missing_ids,json=missingIds,proto3" json:"missing_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetTransactionsResponse) Reset() {
+	*x = BatchGetTransactionsResponse{}
+	mi := &file_tx_manager_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetTransactionsResponse) ProtoMessage() {}
+
+func (x *BatchGetTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tx_manager_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BatchGetTransactionsResponse) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *BatchGetTransactionsResponse) GetMissingIds() []string {
+	if x != nil {
+		return x.MissingIds
+	}
+	return nil
+}
+
+type GetTransactionByIDRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransactionByIDRequest) Reset() {
+	*x = GetTransactionByIDRequest{}
+	mi := &file_tx_manager_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransactionByIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionByIDRequest) ProtoMessage() {}
+
+func (x *GetTransactionByIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tx_manager_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionByIDRequest.ProtoReflect.Descriptor instead.
+func (*GetTransactionByIDRequest) Descriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetTransactionByIDRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type Transaction struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Type   TransactionType        `protobuf:"varint,3,opt,name=type,proto3,enum=tx_manager.TransactionType" json:"type,omitempty"`
+	// In minor units of currency, e.g. cents for EUR.
+	Amount    int64     `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Timestamp int64     `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Direction Direction `protobuf:"varint,6,opt,name=direction,proto3,enum=tx_manager.Direction" json:"direction,omitempty"`
+	// Id or provider event id of the referenced transaction, e.g. the bet voided by a rollback.
+	ReferenceId string `protobuf:"bytes,7,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	// Id of the referenced transaction once it is known.
+	OriginalId string `protobuf:"bytes,8,opt,name=original_id,json=originalId,proto3" json:"original_id,omitempty"`
+	Status     Status `protobuf:"varint,9,opt,name=status,proto3,enum=tx_manager.Status" json:"status,omitempty"`
+	RoundId    string `protobuf:"bytes,10,opt,name=round_id,json=roundId,proto3" json:"round_id,omitempty"`
+	GameId     string `protobuf:"bytes,11,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	Provider   string `protobuf:"bytes,12,opt,name=provider,proto3" json:"provider,omitempty"`
+	SessionId  string `protobuf:"bytes,13,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Channel    string `protobuf:"bytes,14,opt,name=channel,proto3" json:"channel,omitempty"`
+	// ISO 4217 code.
+	Currency string `protobuf:"bytes,15,opt,name=currency,proto3" json:"currency,omitempty"`
+	// Number of digits of amount after the decimal point, e.g. 2 for EUR.
+	CurrencyExponent int32 `protobuf:"varint,16,opt,name=currency_exponent,json=currencyExponent,proto3" json:"currency_exponent,omitempty"`
+	// Set when a reporting currency was requested.
+	Reporting     *Conversion `protobuf:"bytes,17,opt,name=reporting,proto3" json:"reporting,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Transaction) Reset() {
+	*x = Transaction{}
+	mi := &file_tx_manager_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction) ProtoMessage() {}
+
+func (x *Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_tx_manager_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Transaction) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Transaction) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Transaction) GetType() TransactionType {
+	if x != nil {
+		return x.Type
+	}
+	return TransactionType_All
+}
+
+func (x *Transaction) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Transaction) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *Transaction) GetDirection() Direction {
+	if x != nil {
+		return x.Direction
+	}
+	return Direction_UnknownDirection
+}
+
+func (x *Transaction) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+func (x *Transaction) GetOriginalId() string {
+	if x != nil {
+		return x.OriginalId
+	}
+	return ""
+}
+
+func (x *Transaction) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_UnknownStatus
+}
+
+func (x *Transaction) GetRoundId() string {
+	if x != nil {
+		return x.RoundId
+	}
+	return ""
+}
+
+func (x *Transaction) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *Transaction) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *Transaction) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *Transaction) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *Transaction) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Transaction) GetCurrencyExponent() int32 {
+	if x != nil {
+		return x.CurrencyExponent
+	}
+	return 0
+}
+
+func (x *Transaction) GetReporting() *Conversion {
+	if x != nil {
+		return x.Reporting
+	}
+	return nil
+}
+
+// An amount converted to a reporting currency.
+type Conversion struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Currency string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	// In minor units of currency.
+	Amount           int64 `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	CurrencyExponent int32 `protobuf:"varint,3,opt,name=currency_exponent,json=currencyExponent,proto3" json:"currency_exponent,omitempty"`
+	// Units of currency per unit of the original currency, as a decimal string.
+	Rate string `protobuf:"bytes,4,opt,name=rate,proto3" json:"rate,omitempty"`
+	// Date of the oldest published rate the rate was derived from, zero when no conversion was needed.
+	RateDate      int64 `protobuf:"varint,5,opt,name=rate_date,json=rateDate,proto3" json:"rate_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Conversion) Reset() {
+	*x = Conversion{}
+	mi := &file_tx_manager_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Conversion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Conversion) ProtoMessage() {}
+
+func (x *Conversion) ProtoReflect() protoreflect.Message {
+	mi := &file_tx_manager_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Conversion.ProtoReflect.Descriptor instead.
+func (*Conversion) Descriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Conversion) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Conversion) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Conversion) GetCurrencyExponent() int32 {
+	if x != nil {
+		return x.CurrencyExponent
+	}
+	return 0
+}
+
+func (x *Conversion) GetRate() string {
+	if x != nil {
+		return x.Rate
+	}
+	return ""
+}
+
+func (x *Conversion) GetRateDate() int64 {
+	if x != nil {
+		return x.RateDate
+	}
+	return 0
+}
+
+type GetTransactionByIDResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Transaction *Transaction           `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	// The original transaction and everything referencing it, oldest first.
+	Chain         []*Transaction `protobuf:"bytes,2,rep,name=chain,proto3" json:"chain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransactionByIDResponse) Reset() {
+	*x = GetTransactionByIDResponse{}
+	mi := &file_tx_manager_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransactionByIDResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionByIDResponse) ProtoMessage() {}
+
+func (x *GetTransactionByIDResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tx_manager_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionByIDResponse.ProtoReflect.Descriptor instead.
+func (*GetTransactionByIDResponse) Descriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetTransactionByIDResponse) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *GetTransactionByIDResponse) GetChain() []*Transaction {
+	if x != nil {
+		return x.Chain
+	}
+	return nil
+}
+
+type Round struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId   string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	GameId   string                 `protobuf:"bytes,3,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	Provider string                 `protobuf:"bytes,4,opt,name=provider,proto3" json:"provider,omitempty"`
+	Status   RoundStatus            `protobuf:"varint,5,opt,name=status,proto3,enum=tx_manager.RoundStatus" json:"status,omitempty"`
+	BetTotal int64                  `protobuf:"varint,6,opt,name=bet_total,json=betTotal,proto3" json:"bet_total,omitempty"`
+	WinTotal int64                  `protobuf:"varint,7,opt,name=win_total,json=winTotal,proto3" json:"win_total,omitempty"`
+	// Sum of credits minus debits of the round.
+	Net      int64 `protobuf:"varint,8,opt,name=net,proto3" json:"net,omitempty"`
+	BetCount int32 `protobuf:"varint,9,opt,name=bet_count,json=betCount,proto3" json:"bet_count,omitempty"`
+	WinCount int32 `protobuf:"varint,10,opt,name=win_count,json=winCount,proto3" json:"win_count,omitempty"`
+	OpenedAt int64 `protobuf:"varint,11,opt,name=opened_at,json=openedAt,proto3" json:"opened_at,omitempty"`
+	// Zero while the round is open.
+	SettledAt int64 `protobuf:"varint,12,opt,name=settled_at,json=settledAt,proto3" json:"settled_at,omitempty"`
+	// Open for longer than the orphan timeout.
+	Orphaned bool `protobuf:"varint,13,opt,name=orphaned,proto3" json:"orphaned,omitempty"`
+	// Has a win but no bet.
+	UnmatchedWin bool           `protobuf:"varint,14,opt,name=unmatched_win,json=unmatchedWin,proto3" json:"unmatched_win,omitempty"`
+	Transactions []*Transaction `protobuf:"bytes,15,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	// Currency of the first transaction of the round.
+	Currency      string `protobuf:"bytes,16,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Round) Reset() {
+	*x = Round{}
+	mi := &file_tx_manager_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Round) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Round) ProtoMessage() {}
+
+func (x *Round) ProtoReflect() protoreflect.Message {
+	mi := &file_tx_manager_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Round.ProtoReflect.Descriptor instead.
+func (*Round) Descriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Round) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Round) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Round) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *Round) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *Round) GetStatus() RoundStatus {
+	if x != nil {
+		return x.Status
+	}
+	return RoundStatus_UnknownRoundStatus
+}
+
+func (x *Round) GetBetTotal() int64 {
+	if x != nil {
+		return x.BetTotal
+	}
+	return 0
+}
+
+func (x *Round) GetWinTotal() int64 {
+	if x != nil {
+		return x.WinTotal
+	}
+	return 0
+}
+
+func (x *Round) GetNet() int64 {
+	if x != nil {
+		return x.Net
+	}
+	return 0
+}
+
+func (x *Round) GetBetCount() int32 {
+	if x != nil {
+		return x.BetCount
+	}
+	return 0
+}
+
+func (x *Round) GetWinCount() int32 {
+	if x != nil {
+		return x.WinCount
+	}
+	return 0
+}
+
+func (x *Round) GetOpenedAt() int64 {
+	if x != nil {
+		return x.OpenedAt
+	}
+	return 0
+}
+
+func (x *Round) GetSettledAt() int64 {
+	if x != nil {
+		return x.SettledAt
+	}
+	return 0
+}
+
+func (x *Round) GetOrphaned() bool {
+	if x != nil {
+		return x.Orphaned
+	}
+	return false
+}
+
+func (x *Round) GetUnmatchedWin() bool {
+	if x != nil {
+		return x.UnmatchedWin
+	}
+	return false
+}
+
+func (x *Round) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *Round) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type GetRoundRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Provider      string                 `protobuf:"bytes,2,opt,name=provider,proto3" json:"provider,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRoundRequest) Reset() {
+	*x = GetRoundRequest{}
+	mi := &file_tx_manager_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRoundRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoundRequest) ProtoMessage() {}
+
+func (x *GetRoundRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tx_manager_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoundRequest.ProtoReflect.Descriptor instead.
+func (*GetRoundRequest) Descriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetRoundRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetRoundRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+type GetRoundResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Round         *Round                 `protobuf:"bytes,1,opt,name=round,proto3" json:"round,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRoundResponse) Reset() {
+	*x = GetRoundResponse{}
+	mi := &file_tx_manager_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRoundResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoundResponse) ProtoMessage() {}
+
+func (x *GetRoundResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tx_manager_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoundResponse.ProtoReflect.Descriptor instead.
+func (*GetRoundResponse) Descriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetRoundResponse) GetRound() *Round {
+	if x != nil {
+		return x.Round
+	}
+	return nil
+}
+
+type ListOpenRoundsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OrphanedOnly  bool                   `protobuf:"varint,2,opt,name=orphaned_only,json=orphanedOnly,proto3" json:"orphaned_only,omitempty"`
+	Limit         int64                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int64                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOpenRoundsRequest) Reset() {
+	*x = ListOpenRoundsRequest{}
+	mi := &file_tx_manager_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOpenRoundsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOpenRoundsRequest) ProtoMessage() {}
+
+func (x *ListOpenRoundsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tx_manager_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOpenRoundsRequest.ProtoReflect.Descriptor instead.
+func (*ListOpenRoundsRequest) Descriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListOpenRoundsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListOpenRoundsRequest) GetOrphanedOnly() bool {
+	if x != nil {
+		return x.OrphanedOnly
+	}
+	return false
+}
+
+func (x *ListOpenRoundsRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListOpenRoundsRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListOpenRoundsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rounds        []*Round               `protobuf:"bytes,1,rep,name=rounds,proto3" json:"rounds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOpenRoundsResponse) Reset() {
+	*x = ListOpenRoundsResponse{}
+	mi := &file_tx_manager_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOpenRoundsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOpenRoundsResponse) ProtoMessage() {}
+
+func (x *ListOpenRoundsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tx_manager_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOpenRoundsResponse.ProtoReflect.Descriptor instead.
+func (*ListOpenRoundsResponse) Descriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListOpenRoundsResponse) GetRounds() []*Round {
+	if x != nil {
+		return x.Rounds
+	}
+	return nil
+}
+
+type Balance struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Sum of credits minus debits.
+	Balance             int64  `protobuf:"varint,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	CreditTotal         int64  `protobuf:"varint,3,opt,name=credit_total,json=creditTotal,proto3" json:"credit_total,omitempty"`
+	DebitTotal          int64  `protobuf:"varint,4,opt,name=debit_total,json=debitTotal,proto3" json:"debit_total,omitempty"`
+	TransactionCount    int64  `protobuf:"varint,5,opt,name=transaction_count,json=transactionCount,proto3" json:"transaction_count,omitempty"`
+	LastTransactionTime int64  `protobuf:"varint,6,opt,name=last_transaction_time,json=lastTransactionTime,proto3" json:"last_transaction_time,omitempty"`
+	UpdatedAt           int64  `protobuf:"varint,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Currency            string `protobuf:"bytes,8,opt,name=currency,proto3" json:"currency,omitempty"`
+	// The balance converted with the current rate, set when a reporting currency was requested.
+	Reporting     *Conversion `protobuf:"bytes,9,opt,name=reporting,proto3" json:"reporting,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Balance) Reset() {
+	*x = Balance{}
+	mi := &file_tx_manager_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Balance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Balance) ProtoMessage() {}
+
+func (x *Balance) ProtoReflect() protoreflect.Message {
+	mi := &file_tx_manager_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Balance.ProtoReflect.Descriptor instead.
+func (*Balance) Descriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Balance) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Balance) GetBalance() int64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *Balance) GetCreditTotal() int64 {
+	if x != nil {
+		return x.CreditTotal
+	}
+	return 0
+}
+
+func (x *Balance) GetDebitTotal() int64 {
+	if x != nil {
+		return x.DebitTotal
+	}
+	return 0
+}
+
+func (x *Balance) GetTransactionCount() int64 {
+	if x != nil {
+		return x.TransactionCount
+	}
+	return 0
+}
+
+func (x *Balance) GetLastTransactionTime() int64 {
+	if x != nil {
+		return x.LastTransactionTime
+	}
+	return 0
+}
+
+func (x *Balance) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *Balance) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Balance) GetReporting() *Conversion {
+	if x != nil {
+		return x.Reporting
+	}
+	return nil
+}
+
+type GetUserBalanceRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Defaults to EUR.
+	Currency          string `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	ReportingCurrency string `protobuf:"bytes,3,opt,name=reporting_currency,json=reportingCurrency,proto3" json:"reporting_currency,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetUserBalanceRequest) Reset() {
+	*x = GetUserBalanceRequest{}
+	mi := &file_tx_manager_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserBalanceRequest) ProtoMessage() {}
+
+func (x *GetUserBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tx_manager_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserBalanceRequest.ProtoReflect.Descriptor instead.
+func (*GetUserBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetUserBalanceRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetUserBalanceRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *GetUserBalanceRequest) GetReportingCurrency() string {
+	if x != nil {
+		return x.ReportingCurrency
+	}
+	return ""
+}
+
+type GetUserBalanceResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Transaction   []*Transaction         `protobuf:"bytes,1,rep,name=transaction,proto3" json:"transaction,omitempty"`
-	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Balance       *Balance               `protobuf:"bytes,1,opt,name=balance,proto3" json:"balance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserBalanceResponse) Reset() {
+	*x = GetUserBalanceResponse{}
+	mi := &file_tx_manager_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserBalanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserBalanceResponse) ProtoMessage() {}
+
+func (x *GetUserBalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tx_manager_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserBalanceResponse.ProtoReflect.Descriptor instead.
+func (*GetUserBalanceResponse) Descriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetUserBalanceResponse) GetBalance() *Balance {
+	if x != nil {
+		return x.Balance
+	}
+	return nil
+}
+
+// Balance of a user including every transaction up to timestamp.
+type BalancePoint struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp        int64                  `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Balance          int64                  `protobuf:"varint,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	TransactionCount int64                  `protobuf:"varint,3,opt,name=transaction_count,json=transactionCount,proto3" json:"transaction_count,omitempty"`
+	Currency         string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	// The balance converted with the rate valid at timestamp, set when a reporting currency was requested.
+	Reporting     *Conversion `protobuf:"bytes,5,opt,name=reporting,proto3" json:"reporting,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTransactionByFiltersResponse) Reset() {
-	*x = GetTransactionByFiltersResponse{}
-	mi := &file_tx_manager_proto_msgTypes[0]
+func (x *BalancePoint) Reset() {
+	*x = BalancePoint{}
+	mi := &file_tx_manager_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTransactionByFiltersResponse) String() string {
+func (x *BalancePoint) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTransactionByFiltersResponse) ProtoMessage() {}
+func (*BalancePoint) ProtoMessage() {}
 
-func (x *GetTransactionByFiltersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tx_manager_proto_msgTypes[0]
+func (x *BalancePoint) ProtoReflect() protoreflect.Message {
+	mi := &file_tx_manager_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -103,48 +2292,72 @@ func (x *GetTransactionByFiltersResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTransactionByFiltersResponse.ProtoReflect.Descriptor instead.
-func (*GetTransactionByFiltersResponse) Descriptor() ([]byte, []int) {
-	return file_tx_manager_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use BalancePoint.ProtoReflect.Descriptor instead.
+func (*BalancePoint) Descriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *GetTransactionByFiltersResponse) GetTransaction() []*Transaction {
+func (x *BalancePoint) GetTimestamp() int64 {
 	if x != nil {
-		return x.Transaction
+		return x.Timestamp
 	}
-	return nil
+	return 0
 }
 
-func (x *GetTransactionByFiltersResponse) GetTotal() int64 {
+func (x *BalancePoint) GetBalance() int64 {
 	if x != nil {
-		return x.Total
+		return x.Balance
 	}
 	return 0
 }
 
-type Filters struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Type          TransactionType        `protobuf:"varint,2,opt,name=type,proto3,enum=tx_manager.TransactionType" json:"type,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *BalancePoint) GetTransactionCount() int64 {
+	if x != nil {
+		return x.TransactionCount
+	}
+	return 0
 }
 
-func (x *Filters) Reset() {
-	*x = Filters{}
-	mi := &file_tx_manager_proto_msgTypes[1]
+func (x *BalancePoint) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *BalancePoint) GetReporting() *Conversion {
+	if x != nil {
+		return x.Reporting
+	}
+	return nil
+}
+
+type GetBalanceAtRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	UserId    string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Timestamp int64                  `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Defaults to EUR.
+	Currency          string `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	ReportingCurrency string `protobuf:"bytes,4,opt,name=reporting_currency,json=reportingCurrency,proto3" json:"reporting_currency,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetBalanceAtRequest) Reset() {
+	*x = GetBalanceAtRequest{}
+	mi := &file_tx_manager_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Filters) String() string {
+func (x *GetBalanceAtRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Filters) ProtoMessage() {}
+func (*GetBalanceAtRequest) ProtoMessage() {}
 
-func (x *Filters) ProtoReflect() protoreflect.Message {
-	mi := &file_tx_manager_proto_msgTypes[1]
+func (x *GetBalanceAtRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tx_manager_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -155,50 +2368,61 @@ func (x *Filters) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Filters.ProtoReflect.Descriptor instead.
-func (*Filters) Descriptor() ([]byte, []int) {
-	return file_tx_manager_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use GetBalanceAtRequest.ProtoReflect.Descriptor instead.
+func (*GetBalanceAtRequest) Descriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *Filters) GetUserId() string {
+func (x *GetBalanceAtRequest) GetUserId() string {
 	if x != nil {
 		return x.UserId
 	}
 	return ""
 }
 
-func (x *Filters) GetType() TransactionType {
+func (x *GetBalanceAtRequest) GetTimestamp() int64 {
 	if x != nil {
-		return x.Type
+		return x.Timestamp
 	}
-	return TransactionType_All
+	return 0
 }
 
-type GetTransactionByFiltersRequest struct {
+func (x *GetBalanceAtRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *GetBalanceAtRequest) GetReportingCurrency() string {
+	if x != nil {
+		return x.ReportingCurrency
+	}
+	return ""
+}
+
+type GetBalanceAtResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Filters       *Filters               `protobuf:"bytes,1,opt,name=filters,proto3" json:"filters,omitempty"`
-	OrderBy       string                 `protobuf:"bytes,2,opt,name=orderBy,proto3" json:"orderBy,omitempty"`
-	Limit         int64                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int64                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	Balance       *BalancePoint          `protobuf:"bytes,1,opt,name=balance,proto3" json:"balance,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTransactionByFiltersRequest) Reset() {
-	*x = GetTransactionByFiltersRequest{}
-	mi := &file_tx_manager_proto_msgTypes[2]
+func (x *GetBalanceAtResponse) Reset() {
+	*x = GetBalanceAtResponse{}
+	mi := &file_tx_manager_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTransactionByFiltersRequest) String() string {
+func (x *GetBalanceAtResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTransactionByFiltersRequest) ProtoMessage() {}
+func (*GetBalanceAtResponse) ProtoMessage() {}
 
-func (x *GetTransactionByFiltersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tx_manager_proto_msgTypes[2]
+func (x *GetBalanceAtResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tx_manager_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -209,61 +2433,126 @@ func (x *GetTransactionByFiltersRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTransactionByFiltersRequest.ProtoReflect.Descriptor instead.
-func (*GetTransactionByFiltersRequest) Descriptor() ([]byte, []int) {
-	return file_tx_manager_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use GetBalanceAtResponse.ProtoReflect.Descriptor instead.
+func (*GetBalanceAtResponse) Descriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *GetTransactionByFiltersRequest) GetFilters() *Filters {
+func (x *GetBalanceAtResponse) GetBalance() *BalancePoint {
 	if x != nil {
-		return x.Filters
+		return x.Balance
 	}
 	return nil
 }
 
-func (x *GetTransactionByFiltersRequest) GetOrderBy() string {
+type GetBalanceHistoryRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	UserId      string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	From        int64                  `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	To          int64                  `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+	StepSeconds int64                  `protobuf:"varint,4,opt,name=step_seconds,json=stepSeconds,proto3" json:"step_seconds,omitempty"`
+	// Defaults to EUR.
+	Currency          string `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	ReportingCurrency string `protobuf:"bytes,6,opt,name=reporting_currency,json=reportingCurrency,proto3" json:"reporting_currency,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetBalanceHistoryRequest) Reset() {
+	*x = GetBalanceHistoryRequest{}
+	mi := &file_tx_manager_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalanceHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceHistoryRequest) ProtoMessage() {}
+
+func (x *GetBalanceHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tx_manager_proto_msgTypes[25]
 	if x != nil {
-		return x.OrderBy
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetBalanceHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetBalanceHistoryRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *GetTransactionByFiltersRequest) GetLimit() int64 {
+func (x *GetBalanceHistoryRequest) GetFrom() int64 {
 	if x != nil {
-		return x.Limit
+		return x.From
 	}
 	return 0
 }
 
-func (x *GetTransactionByFiltersRequest) GetOffset() int64 {
+func (x *GetBalanceHistoryRequest) GetTo() int64 {
 	if x != nil {
-		return x.Offset
+		return x.To
 	}
 	return 0
 }
 
-type GetTransactionByIDRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+func (x *GetBalanceHistoryRequest) GetStepSeconds() int64 {
+	if x != nil {
+		return x.StepSeconds
+	}
+	return 0
+}
+
+func (x *GetBalanceHistoryRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *GetBalanceHistoryRequest) GetReportingCurrency() string {
+	if x != nil {
+		return x.ReportingCurrency
+	}
+	return ""
+}
+
+type GetBalanceHistoryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The balance at from, after every step and at to.
+	Points        []*BalancePoint `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTransactionByIDRequest) Reset() {
-	*x = GetTransactionByIDRequest{}
-	mi := &file_tx_manager_proto_msgTypes[3]
+func (x *GetBalanceHistoryResponse) Reset() {
+	*x = GetBalanceHistoryResponse{}
+	mi := &file_tx_manager_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTransactionByIDRequest) String() string {
+func (x *GetBalanceHistoryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTransactionByIDRequest) ProtoMessage() {}
+func (*GetBalanceHistoryResponse) ProtoMessage() {}
 
-func (x *GetTransactionByIDRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tx_manager_proto_msgTypes[3]
+func (x *GetBalanceHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tx_manager_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -274,44 +2563,48 @@ func (x *GetTransactionByIDRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTransactionByIDRequest.ProtoReflect.Descriptor instead.
-func (*GetTransactionByIDRequest) Descriptor() ([]byte, []int) {
-	return file_tx_manager_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use GetBalanceHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetBalanceHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *GetTransactionByIDRequest) GetId() string {
+func (x *GetBalanceHistoryResponse) GetPoints() []*BalancePoint {
 	if x != nil {
-		return x.Id
+		return x.Points
 	}
-	return ""
+	return nil
 }
 
-type Transaction struct {
+// One leg of a transaction in the double-entry ledger. Positive amounts credit the account.
+type Posting struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Type          TransactionType        `protobuf:"varint,3,opt,name=type,proto3,enum=tx_manager.TransactionType" json:"type,omitempty"`
+	TransactionId string                 `protobuf:"bytes,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Account       string                 `protobuf:"bytes,3,opt,name=account,proto3" json:"account,omitempty"`
 	Amount        int64                  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
 	Timestamp     int64                  `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Currency      string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	// Set when a reporting currency was requested.
+	Reporting     *Conversion `protobuf:"bytes,7,opt,name=reporting,proto3" json:"reporting,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Transaction) Reset() {
-	*x = Transaction{}
-	mi := &file_tx_manager_proto_msgTypes[4]
+func (x *Posting) Reset() {
+	*x = Posting{}
+	mi := &file_tx_manager_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Transaction) String() string {
+func (x *Posting) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Transaction) ProtoMessage() {}
+func (*Posting) ProtoMessage() {}
 
-func (x *Transaction) ProtoReflect() protoreflect.Message {
-	mi := &file_tx_manager_proto_msgTypes[4]
+func (x *Posting) ProtoReflect() protoreflect.Message {
+	mi := &file_tx_manager_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -322,68 +2615,88 @@ func (x *Transaction) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
-func (*Transaction) Descriptor() ([]byte, []int) {
-	return file_tx_manager_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use Posting.ProtoReflect.Descriptor instead.
+func (*Posting) Descriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *Transaction) GetId() string {
+func (x *Posting) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *Transaction) GetUserId() string {
+func (x *Posting) GetTransactionId() string {
 	if x != nil {
-		return x.UserId
+		return x.TransactionId
 	}
 	return ""
 }
 
-func (x *Transaction) GetType() TransactionType {
+func (x *Posting) GetAccount() string {
 	if x != nil {
-		return x.Type
+		return x.Account
 	}
-	return TransactionType_All
+	return ""
 }
 
-func (x *Transaction) GetAmount() int64 {
+func (x *Posting) GetAmount() int64 {
 	if x != nil {
 		return x.Amount
 	}
 	return 0
 }
 
-func (x *Transaction) GetTimestamp() int64 {
+func (x *Posting) GetTimestamp() int64 {
 	if x != nil {
 		return x.Timestamp
 	}
 	return 0
 }
 
-type GetTransactionByIDResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Transaction   *Transaction           `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Posting) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
 }
 
-func (x *GetTransactionByIDResponse) Reset() {
-	*x = GetTransactionByIDResponse{}
-	mi := &file_tx_manager_proto_msgTypes[5]
+func (x *Posting) GetReporting() *Conversion {
+	if x != nil {
+		return x.Reporting
+	}
+	return nil
+}
+
+type ListPostingsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// house, bank, bonus_pool, jackpot_pool or player:<user id>.
+	Account string `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Limit   int64  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset  int64  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Defaults to EUR.
+	Currency          string `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	ReportingCurrency string `protobuf:"bytes,5,opt,name=reporting_currency,json=reportingCurrency,proto3" json:"reporting_currency,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ListPostingsRequest) Reset() {
+	*x = ListPostingsRequest{}
+	mi := &file_tx_manager_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTransactionByIDResponse) String() string {
+func (x *ListPostingsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTransactionByIDResponse) ProtoMessage() {}
+func (*ListPostingsRequest) ProtoMessage() {}
 
-func (x *GetTransactionByIDResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tx_manager_proto_msgTypes[5]
+func (x *ListPostingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tx_manager_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -394,85 +2707,385 @@ func (x *GetTransactionByIDResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTransactionByIDResponse.ProtoReflect.Descriptor instead.
-func (*GetTransactionByIDResponse) Descriptor() ([]byte, []int) {
-	return file_tx_manager_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ListPostingsRequest.ProtoReflect.Descriptor instead.
+func (*ListPostingsRequest) Descriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *GetTransactionByIDResponse) GetTransaction() *Transaction {
+func (x *ListPostingsRequest) GetAccount() string {
 	if x != nil {
-		return x.Transaction
+		return x.Account
+	}
+	return ""
+}
+
+func (x *ListPostingsRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListPostingsRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListPostingsRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ListPostingsRequest) GetReportingCurrency() string {
+	if x != nil {
+		return x.ReportingCurrency
+	}
+	return ""
+}
+
+type ListPostingsResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Postings []*Posting             `protobuf:"bytes,1,rep,name=postings,proto3" json:"postings,omitempty"`
+	// Sum of all postings of the account in the currency.
+	Balance int64 `protobuf:"varint,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	// The balance converted with the current rate, set when a reporting currency was requested.
+	ReportingBalance *Conversion `protobuf:"bytes,3,opt,name=reporting_balance,json=reportingBalance,proto3" json:"reporting_balance,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ListPostingsResponse) Reset() {
+	*x = ListPostingsResponse{}
+	mi := &file_tx_manager_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPostingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPostingsResponse) ProtoMessage() {}
+
+func (x *ListPostingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tx_manager_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPostingsResponse.ProtoReflect.Descriptor instead.
+func (*ListPostingsResponse) Descriptor() ([]byte, []int) {
+	return file_tx_manager_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListPostingsResponse) GetPostings() []*Posting {
+	if x != nil {
+		return x.Postings
+	}
+	return nil
+}
+
+func (x *ListPostingsResponse) GetBalance() int64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *ListPostingsResponse) GetReportingBalance() *Conversion {
+	if x != nil {
+		return x.ReportingBalance
 	}
 	return nil
 }
 
 var File_tx_manager_proto protoreflect.FileDescriptor
 
-var file_tx_manager_proto_rawDesc = string([]byte{
-	0x0a, 0x10, 0x74, 0x78, 0x2d, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x12, 0x0a, 0x74, 0x78, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x22, 0x72,
-	0x0a, 0x1f, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x42, 0x79, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x39, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x78, 0x5f, 0x6d, 0x61, 0x6e, 0x61,
-	0x67, 0x65, 0x72, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05,
-	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x6f, 0x74,
-	0x61, 0x6c, 0x22, 0x53, 0x0a, 0x07, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x17, 0x0a,
-	0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2f, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x74, 0x78, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65,
-	0x72, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70,
-	0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x97, 0x01, 0x0a, 0x1e, 0x47, 0x65, 0x74, 0x54,
-	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x79, 0x46, 0x69, 0x6c, 0x74,
-	0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2d, 0x0a, 0x07, 0x66, 0x69,
-	0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x74, 0x78,
-	0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73,
-	0x52, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x72, 0x64,
-	0x65, 0x72, 0x42, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65,
-	0x72, 0x42, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66,
-	0x73, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65,
-	0x74, 0x22, 0x2b, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
-	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x9d,
-	0x01, 0x0a, 0x0b, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e,
-	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x17,
-	0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2f, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x74, 0x78, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67,
-	0x65, 0x72, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79,
-	0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75,
-	0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
-	0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x57,
-	0x0a, 0x1a, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x0b,
-	0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x17, 0x2e, 0x74, 0x78, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x54,
-	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x74, 0x72, 0x61, 0x6e,
-	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2a, 0x2c, 0x0a, 0x0f, 0x54, 0x72, 0x61, 0x6e, 0x73,
-	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x6c,
-	0x6c, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x42, 0x65, 0x74, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03,
-	0x57, 0x69, 0x6e, 0x10, 0x02, 0x32, 0xed, 0x01, 0x0a, 0x12, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x12, 0x63, 0x0a, 0x12,
-	0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x79,
-	0x49, 0x44, 0x12, 0x25, 0x2e, 0x74, 0x78, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e,
-	0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x79,
-	0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x74, 0x78, 0x5f, 0x6d,
-	0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x72, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x42, 0x79, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x2a, 0x2e, 0x74,
-	0x78, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61,
-	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x79, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x74, 0x78, 0x5f, 0x6d, 0x61,
-	0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x42, 0x79, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x16, 0x5a, 0x14, 0x73, 0x72, 0x63, 0x2f, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2f, 0x74, 0x78, 0x2d, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
-})
+const file_tx_manager_proto_rawDesc = "" +
+	"\n" +
+	"\x10tx-manager.proto\x12\n" +
+	"tx_manager\"\xbb\x01\n" +
+	"\x1fGetTransactionByFiltersResponse\x129\n" +
+	"\vtransaction\x18\x01 \x03(\v2\x17.tx_manager.TransactionR\vtransaction\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\x12\x1f\n" +
+	"\vtotal_exact\x18\x04 \x01(\bR\n" +
+	"totalExact\"\x9c\x03\n" +
+	"\aFilters\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12/\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x1b.tx_manager.TransactionTypeR\x04type\x12\x19\n" +
+	"\bround_id\x18\x03 \x01(\tR\aroundId\x12\x17\n" +
+	"\agame_id\x18\x04 \x01(\tR\x06gameId\x12\x1a\n" +
+	"\bprovider\x18\x05 \x01(\tR\bprovider\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x06 \x01(\tR\tsessionId\x12\x18\n" +
+	"\achannel\x18\a \x01(\tR\achannel\x12\x1a\n" +
+	"\bcurrency\x18\b \x01(\tR\bcurrency\x12\x17\n" +
+	"\x04from\x18\t \x01(\x03H\x00R\x04from\x88\x01\x01\x12\x13\n" +
+	"\x02to\x18\n" +
+	" \x01(\x03H\x01R\x02to\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"min_amount\x18\v \x01(\x03H\x02R\tminAmount\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"max_amount\x18\f \x01(\x03H\x03R\tmaxAmount\x88\x01\x01B\a\n" +
+	"\x05_fromB\x05\n" +
+	"\x03_toB\r\n" +
+	"\v_min_amountB\r\n" +
+	"\v_max_amount\"\xa4\x01\n" +
+	"\n" +
+	"FilterExpr\x128\n" +
+	"\n" +
+	"comparison\x18\x01 \x01(\v2\x16.tx_manager.ComparisonH\x00R\n" +
+	"comparison\x12*\n" +
+	"\x03and\x18\x02 \x01(\v2\x16.tx_manager.FilterListH\x00R\x03and\x12(\n" +
+	"\x02or\x18\x03 \x01(\v2\x16.tx_manager.FilterListH\x00R\x02orB\x06\n" +
+	"\x04node\"@\n" +
+	"\n" +
+	"FilterList\x122\n" +
+	"\boperands\x18\x01 \x03(\v2\x16.tx_manager.FilterExprR\boperands\"\x96\x01\n" +
+	"\n" +
+	"Comparison\x12-\n" +
+	"\x05field\x18\x01 \x01(\x0e2\x17.tx_manager.FilterFieldR\x05field\x12(\n" +
+	"\x02op\x18\x02 \x01(\x0e2\x18.tx_manager.ComparisonOpR\x02op\x12/\n" +
+	"\x06values\x18\x03 \x03(\v2\x17.tx_manager.FilterValueR\x06values\"]\n" +
+	"\vFilterValue\x12\x14\n" +
+	"\x04text\x18\x01 \x01(\tH\x00R\x04text\x12\x1a\n" +
+	"\ainteger\x18\x02 \x01(\x03H\x00R\ainteger\x12\x14\n" +
+	"\x04time\x18\x03 \x01(\x03H\x00R\x04timeB\x06\n" +
+	"\x04kind\"o\n" +
+	"\tSortField\x12)\n" +
+	"\x05field\x18\x01 \x01(\x0e2\x13.tx_manager.SortKeyR\x05field\x127\n" +
+	"\tdirection\x18\x02 \x01(\x0e2\x19.tx_manager.SortDirectionR\tdirection\"\xf2\x02\n" +
+	"\x1eGetTransactionByFiltersRequest\x12-\n" +
+	"\afilters\x18\x01 \x01(\v2\x13.tx_manager.FiltersR\afilters\x120\n" +
+	"\border_by\x18\b \x03(\v2\x15.tx_manager.SortFieldR\aorderBy\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x03R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x03R\x06offset\x12-\n" +
+	"\x12reporting_currency\x18\x05 \x01(\tR\x11reportingCurrency\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x06 \x01(\tR\tpageToken\x124\n" +
+	"\n" +
+	"count_mode\x18\a \x01(\x0e2\x15.tx_manager.CountModeR\tcountMode\x12.\n" +
+	"\x06filter\x18\t \x01(\v2\x16.tx_manager.FilterExprR\x06filterJ\x04\b\x02\x10\x03R\aorderBy\"^\n" +
+	"\x1bBatchGetTransactionsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\x12-\n" +
+	"\x12reporting_currency\x18\x02 \x01(\tR\x11reportingCurrency\"|\n" +
+	"\x1cBatchGetTransactionsResponse\x12;\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x17.tx_manager.TransactionR\ftransactions\x12\x1f\n" +
+	"\vmissing_ids\x18\x02 \x03(\tR\n" +
+	"missingIds\"+\n" +
+	"\x19GetTransactionByIDRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xca\x04\n" +
+	"\vTransaction\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12/\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x1b.tx_manager.TransactionTypeR\x04type\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x03R\x06amount\x12\x1c\n" +
+	"\ttimestamp\x18\x05 \x01(\x03R\ttimestamp\x123\n" +
+	"\tdirection\x18\x06 \x01(\x0e2\x15.tx_manager.DirectionR\tdirection\x12!\n" +
+	"\freference_id\x18\a \x01(\tR\vreferenceId\x12\x1f\n" +
+	"\voriginal_id\x18\b \x01(\tR\n" +
+	"originalId\x12*\n" +
+	"\x06status\x18\t \x01(\x0e2\x12.tx_manager.StatusR\x06status\x12\x19\n" +
+	"\bround_id\x18\n" +
+	" \x01(\tR\aroundId\x12\x17\n" +
+	"\agame_id\x18\v \x01(\tR\x06gameId\x12\x1a\n" +
+	"\bprovider\x18\f \x01(\tR\bprovider\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\r \x01(\tR\tsessionId\x12\x18\n" +
+	"\achannel\x18\x0e \x01(\tR\achannel\x12\x1a\n" +
+	"\bcurrency\x18\x0f \x01(\tR\bcurrency\x12+\n" +
+	"\x11currency_exponent\x18\x10 \x01(\x05R\x10currencyExponent\x124\n" +
+	"\treporting\x18\x11 \x01(\v2\x16.tx_manager.ConversionR\treporting\"\x9e\x01\n" +
+	"\n" +
+	"Conversion\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\x12+\n" +
+	"\x11currency_exponent\x18\x03 \x01(\x05R\x10currencyExponent\x12\x12\n" +
+	"\x04rate\x18\x04 \x01(\tR\x04rate\x12\x1b\n" +
+	"\trate_date\x18\x05 \x01(\x03R\brateDate\"\x86\x01\n" +
+	"\x1aGetTransactionByIDResponse\x129\n" +
+	"\vtransaction\x18\x01 \x01(\v2\x17.tx_manager.TransactionR\vtransaction\x12-\n" +
+	"\x05chain\x18\x02 \x03(\v2\x17.tx_manager.TransactionR\x05chain\"\xf2\x03\n" +
+	"\x05Round\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x17\n" +
+	"\agame_id\x18\x03 \x01(\tR\x06gameId\x12\x1a\n" +
+	"\bprovider\x18\x04 \x01(\tR\bprovider\x12/\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x17.tx_manager.RoundStatusR\x06status\x12\x1b\n" +
+	"\tbet_total\x18\x06 \x01(\x03R\bbetTotal\x12\x1b\n" +
+	"\twin_total\x18\a \x01(\x03R\bwinTotal\x12\x10\n" +
+	"\x03net\x18\b \x01(\x03R\x03net\x12\x1b\n" +
+	"\tbet_count\x18\t \x01(\x05R\bbetCount\x12\x1b\n" +
+	"\twin_count\x18\n" +
+	" \x01(\x05R\bwinCount\x12\x1b\n" +
+	"\topened_at\x18\v \x01(\x03R\bopenedAt\x12\x1d\n" +
+	"\n" +
+	"settled_at\x18\f \x01(\x03R\tsettledAt\x12\x1a\n" +
+	"\borphaned\x18\r \x01(\bR\borphaned\x12#\n" +
+	"\runmatched_win\x18\x0e \x01(\bR\funmatchedWin\x12;\n" +
+	"\ftransactions\x18\x0f \x03(\v2\x17.tx_manager.TransactionR\ftransactions\x12\x1a\n" +
+	"\bcurrency\x18\x10 \x01(\tR\bcurrency\"=\n" +
+	"\x0fGetRoundRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bprovider\x18\x02 \x01(\tR\bprovider\";\n" +
+	"\x10GetRoundResponse\x12'\n" +
+	"\x05round\x18\x01 \x01(\v2\x11.tx_manager.RoundR\x05round\"\x83\x01\n" +
+	"\x15ListOpenRoundsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12#\n" +
+	"\rorphaned_only\x18\x02 \x01(\bR\forphanedOnly\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x03R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x03R\x06offset\"C\n" +
+	"\x16ListOpenRoundsResponse\x12)\n" +
+	"\x06rounds\x18\x01 \x03(\v2\x11.tx_manager.RoundR\x06rounds\"\xd2\x02\n" +
+	"\aBalance\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x18\n" +
+	"\abalance\x18\x02 \x01(\x03R\abalance\x12!\n" +
+	"\fcredit_total\x18\x03 \x01(\x03R\vcreditTotal\x12\x1f\n" +
+	"\vdebit_total\x18\x04 \x01(\x03R\n" +
+	"debitTotal\x12+\n" +
+	"\x11transaction_count\x18\x05 \x01(\x03R\x10transactionCount\x122\n" +
+	"\x15last_transaction_time\x18\x06 \x01(\x03R\x13lastTransactionTime\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\x03R\tupdatedAt\x12\x1a\n" +
+	"\bcurrency\x18\b \x01(\tR\bcurrency\x124\n" +
+	"\treporting\x18\t \x01(\v2\x16.tx_manager.ConversionR\treporting\"{\n" +
+	"\x15GetUserBalanceRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12-\n" +
+	"\x12reporting_currency\x18\x03 \x01(\tR\x11reportingCurrency\"G\n" +
+	"\x16GetUserBalanceResponse\x12-\n" +
+	"\abalance\x18\x01 \x01(\v2\x13.tx_manager.BalanceR\abalance\"\xc5\x01\n" +
+	"\fBalancePoint\x12\x1c\n" +
+	"\ttimestamp\x18\x01 \x01(\x03R\ttimestamp\x12\x18\n" +
+	"\abalance\x18\x02 \x01(\x03R\abalance\x12+\n" +
+	"\x11transaction_count\x18\x03 \x01(\x03R\x10transactionCount\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x124\n" +
+	"\treporting\x18\x05 \x01(\v2\x16.tx_manager.ConversionR\treporting\"\x97\x01\n" +
+	"\x13GetBalanceAtRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1c\n" +
+	"\ttimestamp\x18\x02 \x01(\x03R\ttimestamp\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12-\n" +
+	"\x12reporting_currency\x18\x04 \x01(\tR\x11reportingCurrency\"J\n" +
+	"\x14GetBalanceAtResponse\x122\n" +
+	"\abalance\x18\x01 \x01(\v2\x18.tx_manager.BalancePointR\abalance\"\xc5\x01\n" +
+	"\x18GetBalanceHistoryRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\x03R\x02to\x12!\n" +
+	"\fstep_seconds\x18\x04 \x01(\x03R\vstepSeconds\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12-\n" +
+	"\x12reporting_currency\x18\x06 \x01(\tR\x11reportingCurrency\"M\n" +
+	"\x19GetBalanceHistoryResponse\x120\n" +
+	"\x06points\x18\x01 \x03(\v2\x18.tx_manager.BalancePointR\x06points\"\xe2\x01\n" +
+	"\aPosting\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12%\n" +
+	"\x0etransaction_id\x18\x02 \x01(\tR\rtransactionId\x12\x18\n" +
+	"\aaccount\x18\x03 \x01(\tR\aaccount\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x03R\x06amount\x12\x1c\n" +
+	"\ttimestamp\x18\x05 \x01(\x03R\ttimestamp\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x124\n" +
+	"\treporting\x18\a \x01(\v2\x16.tx_manager.ConversionR\treporting\"\xa8\x01\n" +
+	"\x13ListPostingsRequest\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x03R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x03R\x06offset\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12-\n" +
+	"\x12reporting_currency\x18\x05 \x01(\tR\x11reportingCurrency\"\xa6\x01\n" +
+	"\x14ListPostingsResponse\x12/\n" +
+	"\bpostings\x18\x01 \x03(\v2\x13.tx_manager.PostingR\bpostings\x12\x18\n" +
+	"\abalance\x18\x02 \x01(\x03R\abalance\x12C\n" +
+	"\x11reporting_balance\x18\x03 \x01(\v2\x16.tx_manager.ConversionR\x10reportingBalance*\x99\x01\n" +
+	"\x0fTransactionType\x12\a\n" +
+	"\x03All\x10\x00\x12\a\n" +
+	"\x03Bet\x10\x01\x12\a\n" +
+	"\x03Win\x10\x02\x12\v\n" +
+	"\aDeposit\x10\x03\x12\x0e\n" +
+	"\n" +
+	"Withdrawal\x10\x04\x12\n" +
+	"\n" +
+	"\x06Refund\x10\x05\x12\f\n" +
+	"\bRollback\x10\x06\x12\x0f\n" +
+	"\vBonusCredit\x10\a\x12\x13\n" +
+	"\x0fBonusConversion\x10\b\x12\x0e\n" +
+	"\n" +
+	"JackpotWin\x10\t*8\n" +
+	"\tDirection\x12\x14\n" +
+	"\x10UnknownDirection\x10\x00\x12\n" +
+	"\n" +
+	"\x06Credit\x10\x01\x12\t\n" +
+	"\x05Debit\x10\x02*6\n" +
+	"\x06Status\x12\x11\n" +
+	"\rUnknownStatus\x10\x00\x12\r\n" +
+	"\tCompleted\x10\x01\x12\n" +
+	"\n" +
+	"\x06Voided\x10\x02*\xec\x01\n" +
+	"\vFilterField\x12\x16\n" +
+	"\x12UnknownFilterField\x10\x00\x12\f\n" +
+	"\bFilterId\x10\x01\x12\x10\n" +
+	"\fFilterUserId\x10\x02\x12\x0e\n" +
+	"\n" +
+	"FilterType\x10\x03\x12\x10\n" +
+	"\fFilterAmount\x10\x04\x12\x12\n" +
+	"\x0eFilterCurrency\x10\x05\x12\x0e\n" +
+	"\n" +
+	"FilterDate\x10\x06\x12\x11\n" +
+	"\rFilterRoundId\x10\a\x12\x10\n" +
+	"\fFilterGameId\x10\b\x12\x12\n" +
+	"\x0eFilterProvider\x10\t\x12\x13\n" +
+	"\x0fFilterSessionId\x10\n" +
+	"\x12\x11\n" +
+	"\rFilterChannel\x10\v*\x8f\x01\n" +
+	"\fComparisonOp\x12\x17\n" +
+	"\x13UnknownComparisonOp\x10\x00\x12\t\n" +
+	"\x05Equal\x10\x01\x12\f\n" +
+	"\bNotEqual\x10\x02\x12\b\n" +
+	"\x04Less\x10\x03\x12\x0f\n" +
+	"\vLessOrEqual\x10\x04\x12\v\n" +
+	"\aGreater\x10\x05\x12\x12\n" +
+	"\x0eGreaterOrEqual\x10\x06\x12\x06\n" +
+	"\x02In\x10\a\x12\t\n" +
+	"\x05NotIn\x10\b*Y\n" +
+	"\aSortKey\x12\x12\n" +
+	"\x0eUnknownSortKey\x10\x00\x12\x0e\n" +
+	"\n" +
+	"SortUserId\x10\x01\x12\f\n" +
+	"\bSortType\x10\x02\x12\x0e\n" +
+	"\n" +
+	"SortAmount\x10\x03\x12\f\n" +
+	"\bSortTime\x10\x04*.\n" +
+	"\rSortDirection\x12\r\n" +
+	"\tAscending\x10\x00\x12\x0e\n" +
+	"\n" +
+	"Descending\x10\x01*/\n" +
+	"\tCountMode\x12\x12\n" +
+	"\x0eCountEstimated\x10\x00\x12\x0e\n" +
+	"\n" +
+	"CountExact\x10\x01*<\n" +
+	"\vRoundStatus\x12\x16\n" +
+	"\x12UnknownRoundStatus\x10\x00\x12\b\n" +
+	"\x04Open\x10\x01\x12\v\n" +
+	"\aSettled\x10\x022\xd9\x06\n" +
+	"\x12TransactionManager\x12c\n" +
+	"\x12GetTransactionByID\x12%.tx_manager.GetTransactionByIDRequest\x1a&.tx_manager.GetTransactionByIDResponse\x12r\n" +
+	"\x17GetTransactionByFilters\x12*.tx_manager.GetTransactionByFiltersRequest\x1a+.tx_manager.GetTransactionByFiltersResponse\x12i\n" +
+	"\x14BatchGetTransactions\x12'.tx_manager.BatchGetTransactionsRequest\x1a(.tx_manager.BatchGetTransactionsResponse\x12E\n" +
+	"\bGetRound\x12\x1b.tx_manager.GetRoundRequest\x1a\x1c.tx_manager.GetRoundResponse\x12W\n" +
+	"\x0eListOpenRounds\x12!.tx_manager.ListOpenRoundsRequest\x1a\".tx_manager.ListOpenRoundsResponse\x12W\n" +
+	"\x0eGetUserBalance\x12!.tx_manager.GetUserBalanceRequest\x1a\".tx_manager.GetUserBalanceResponse\x12Q\n" +
+	"\fGetBalanceAt\x12\x1f.tx_manager.GetBalanceAtRequest\x1a .tx_manager.GetBalanceAtResponse\x12`\n" +
+	"\x11GetBalanceHistory\x12$.tx_manager.GetBalanceHistoryRequest\x1a%.tx_manager.GetBalanceHistoryResponse\x12Q\n" +
+	"\fListPostings\x12\x1f.tx_manager.ListPostingsRequest\x1a .tx_manager.ListPostingsResponseB\x16Z\x14src/proto/tx-managerb\x06proto3"
 
 var (
 	file_tx_manager_proto_rawDescOnce sync.Once
@@ -486,32 +3099,107 @@ func file_tx_manager_proto_rawDescGZIP() []byte {
 	return file_tx_manager_proto_rawDescData
 }
 
-var file_tx_manager_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_tx_manager_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_tx_manager_proto_enumTypes = make([]protoimpl.EnumInfo, 9)
+var file_tx_manager_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
 var file_tx_manager_proto_goTypes = []any{
 	(TransactionType)(0),                    // 0: tx_manager.TransactionType
-	(*GetTransactionByFiltersResponse)(nil), // 1: tx_manager.GetTransactionByFiltersResponse
-	(*Filters)(nil),                         // 2: tx_manager.Filters
-	(*GetTransactionByFiltersRequest)(nil),  // 3: tx_manager.GetTransactionByFiltersRequest
-	(*GetTransactionByIDRequest)(nil),       // 4: tx_manager.GetTransactionByIDRequest
-	(*Transaction)(nil),                     // 5: tx_manager.Transaction
-	(*GetTransactionByIDResponse)(nil),      // 6: tx_manager.GetTransactionByIDResponse
+	(Direction)(0),                          // 1: tx_manager.Direction
+	(Status)(0),                             // 2: tx_manager.Status
+	(FilterField)(0),                        // 3: tx_manager.FilterField
+	(ComparisonOp)(0),                       // 4: tx_manager.ComparisonOp
+	(SortKey)(0),                            // 5: tx_manager.SortKey
+	(SortDirection)(0),                      // 6: tx_manager.SortDirection
+	(CountMode)(0),                          // 7: tx_manager.CountMode
+	(RoundStatus)(0),                        // 8: tx_manager.RoundStatus
+	(*GetTransactionByFiltersResponse)(nil), // 9: tx_manager.GetTransactionByFiltersResponse
+	(*Filters)(nil),                         // 10: tx_manager.Filters
+	(*FilterExpr)(nil),                      // 11: tx_manager.FilterExpr
+	(*FilterList)(nil),                      // 12: tx_manager.FilterList
+	(*Comparison)(nil),                      // 13: tx_manager.Comparison
+	(*FilterValue)(nil),                     // 14: tx_manager.FilterValue
+	(*SortField)(nil),                       // 15: tx_manager.SortField
+	(*GetTransactionByFiltersRequest)(nil),  // 16: tx_manager.GetTransactionByFiltersRequest
+	(*BatchGetTransactionsRequest)(nil),     // 17: tx_manager.BatchGetTransactionsRequest
+	(*BatchGetTransactionsResponse)(nil),    // 18: tx_manager.BatchGetTransactionsResponse
+	(*GetTransactionByIDRequest)(nil),       // 19: tx_manager.GetTransactionByIDRequest
+	(*Transaction)(nil),                     // 20: tx_manager.Transaction
+	(*Conversion)(nil),                      // 21: tx_manager.Conversion
+	(*GetTransactionByIDResponse)(nil),      // 22: tx_manager.GetTransactionByIDResponse
+	(*Round)(nil),                           // 23: tx_manager.Round
+	(*GetRoundRequest)(nil),                 // 24: tx_manager.GetRoundRequest
+	(*GetRoundResponse)(nil),                // 25: tx_manager.GetRoundResponse
+	(*ListOpenRoundsRequest)(nil),           // 26: tx_manager.ListOpenRoundsRequest
+	(*ListOpenRoundsResponse)(nil),          // 27: tx_manager.ListOpenRoundsResponse
+	(*Balance)(nil),                         // 28: tx_manager.Balance
+	(*GetUserBalanceRequest)(nil),           // 29: tx_manager.GetUserBalanceRequest
+	(*GetUserBalanceResponse)(nil),          // 30: tx_manager.GetUserBalanceResponse
+	(*BalancePoint)(nil),                    // 31: tx_manager.BalancePoint
+	(*GetBalanceAtRequest)(nil),             // 32: tx_manager.GetBalanceAtRequest
+	(*GetBalanceAtResponse)(nil),            // 33: tx_manager.GetBalanceAtResponse
+	(*GetBalanceHistoryRequest)(nil),        // 34: tx_manager.GetBalanceHistoryRequest
+	(*GetBalanceHistoryResponse)(nil),       // 35: tx_manager.GetBalanceHistoryResponse
+	(*Posting)(nil),                         // 36: tx_manager.Posting
+	(*ListPostingsRequest)(nil),             // 37: tx_manager.ListPostingsRequest
+	(*ListPostingsResponse)(nil),            // 38: tx_manager.ListPostingsResponse
 }
 var file_tx_manager_proto_depIdxs = []int32{
-	5, // 0: tx_manager.GetTransactionByFiltersResponse.transaction:type_name -> tx_manager.Transaction
-	0, // 1: tx_manager.Filters.type:type_name -> tx_manager.TransactionType
-	2, // 2: tx_manager.GetTransactionByFiltersRequest.filters:type_name -> tx_manager.Filters
-	0, // 3: tx_manager.Transaction.type:type_name -> tx_manager.TransactionType
-	5, // 4: tx_manager.GetTransactionByIDResponse.transaction:type_name -> tx_manager.Transaction
-	4, // 5: tx_manager.TransactionManager.GetTransactionByID:input_type -> tx_manager.GetTransactionByIDRequest
-	3, // 6: tx_manager.TransactionManager.GetTransactionByFilters:input_type -> tx_manager.GetTransactionByFiltersRequest
-	6, // 7: tx_manager.TransactionManager.GetTransactionByID:output_type -> tx_manager.GetTransactionByIDResponse
-	1, // 8: tx_manager.TransactionManager.GetTransactionByFilters:output_type -> tx_manager.GetTransactionByFiltersResponse
-	7, // [7:9] is the sub-list for method output_type
-	5, // [5:7] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	20, // 0: tx_manager.GetTransactionByFiltersResponse.transaction:type_name -> tx_manager.Transaction
+	0,  // 1: tx_manager.Filters.type:type_name -> tx_manager.TransactionType
+	13, // 2: tx_manager.FilterExpr.comparison:type_name -> tx_manager.Comparison
+	12, // 3: tx_manager.FilterExpr.and:type_name -> tx_manager.FilterList
+	12, // 4: tx_manager.FilterExpr.or:type_name -> tx_manager.FilterList
+	11, // 5: tx_manager.FilterList.operands:type_name -> tx_manager.FilterExpr
+	3,  // 6: tx_manager.Comparison.field:type_name -> tx_manager.FilterField
+	4,  // 7: tx_manager.Comparison.op:type_name -> tx_manager.ComparisonOp
+	14, // 8: tx_manager.Comparison.values:type_name -> tx_manager.FilterValue
+	5,  // 9: tx_manager.SortField.field:type_name -> tx_manager.SortKey
+	6,  // 10: tx_manager.SortField.direction:type_name -> tx_manager.SortDirection
+	10, // 11: tx_manager.GetTransactionByFiltersRequest.filters:type_name -> tx_manager.Filters
+	15, // 12: tx_manager.GetTransactionByFiltersRequest.order_by:type_name -> tx_manager.SortField
+	7,  // 13: tx_manager.GetTransactionByFiltersRequest.count_mode:type_name -> tx_manager.CountMode
+	11, // 14: tx_manager.GetTransactionByFiltersRequest.filter:type_name -> tx_manager.FilterExpr
+	20, // 15: tx_manager.BatchGetTransactionsResponse.transactions:type_name -> tx_manager.Transaction
+	0,  // 16: tx_manager.Transaction.type:type_name -> tx_manager.TransactionType
+	1,  // 17: tx_manager.Transaction.direction:type_name -> tx_manager.Direction
+	2,  // 18: tx_manager.Transaction.status:type_name -> tx_manager.Status
+	21, // 19: tx_manager.Transaction.reporting:type_name -> tx_manager.Conversion
+	20, // 20: tx_manager.GetTransactionByIDResponse.transaction:type_name -> tx_manager.Transaction
+	20, // 21: tx_manager.GetTransactionByIDResponse.chain:type_name -> tx_manager.Transaction
+	8,  // 22: tx_manager.Round.status:type_name -> tx_manager.RoundStatus
+	20, // 23: tx_manager.Round.transactions:type_name -> tx_manager.Transaction
+	23, // 24: tx_manager.GetRoundResponse.round:type_name -> tx_manager.Round
+	23, // 25: tx_manager.ListOpenRoundsResponse.rounds:type_name -> tx_manager.Round
+	21, // 26: tx_manager.Balance.reporting:type_name -> tx_manager.Conversion
+	28, // 27: tx_manager.GetUserBalanceResponse.balance:type_name -> tx_manager.Balance
+	21, // 28: tx_manager.BalancePoint.reporting:type_name -> tx_manager.Conversion
+	31, // 29: tx_manager.GetBalanceAtResponse.balance:type_name -> tx_manager.BalancePoint
+	31, // 30: tx_manager.GetBalanceHistoryResponse.points:type_name -> tx_manager.BalancePoint
+	21, // 31: tx_manager.Posting.reporting:type_name -> tx_manager.Conversion
+	36, // 32: tx_manager.ListPostingsResponse.postings:type_name -> tx_manager.Posting
+	21, // 33: tx_manager.ListPostingsResponse.reporting_balance:type_name -> tx_manager.Conversion
+	19, // 34: tx_manager.TransactionManager.GetTransactionByID:input_type -> tx_manager.GetTransactionByIDRequest
+	16, // 35: tx_manager.TransactionManager.GetTransactionByFilters:input_type -> tx_manager.GetTransactionByFiltersRequest
+	17, // 36: tx_manager.TransactionManager.BatchGetTransactions:input_type -> tx_manager.BatchGetTransactionsRequest
+	24, // 37: tx_manager.TransactionManager.GetRound:input_type -> tx_manager.GetRoundRequest
+	26, // 38: tx_manager.TransactionManager.ListOpenRounds:input_type -> tx_manager.ListOpenRoundsRequest
+	29, // 39: tx_manager.TransactionManager.GetUserBalance:input_type -> tx_manager.GetUserBalanceRequest
+	32, // 40: tx_manager.TransactionManager.GetBalanceAt:input_type -> tx_manager.GetBalanceAtRequest
+	34, // 41: tx_manager.TransactionManager.GetBalanceHistory:input_type -> tx_manager.GetBalanceHistoryRequest
+	37, // 42: tx_manager.TransactionManager.ListPostings:input_type -> tx_manager.ListPostingsRequest
+	22, // 43: tx_manager.TransactionManager.GetTransactionByID:output_type -> tx_manager.GetTransactionByIDResponse
+	9,  // 44: tx_manager.TransactionManager.GetTransactionByFilters:output_type -> tx_manager.GetTransactionByFiltersResponse
+	18, // 45: tx_manager.TransactionManager.BatchGetTransactions:output_type -> tx_manager.BatchGetTransactionsResponse
+	25, // 46: tx_manager.TransactionManager.GetRound:output_type -> tx_manager.GetRoundResponse
+	27, // 47: tx_manager.TransactionManager.ListOpenRounds:output_type -> tx_manager.ListOpenRoundsResponse
+	30, // 48: tx_manager.TransactionManager.GetUserBalance:output_type -> tx_manager.GetUserBalanceResponse
+	33, // 49: tx_manager.TransactionManager.GetBalanceAt:output_type -> tx_manager.GetBalanceAtResponse
+	35, // 50: tx_manager.TransactionManager.GetBalanceHistory:output_type -> tx_manager.GetBalanceHistoryResponse
+	38, // 51: tx_manager.TransactionManager.ListPostings:output_type -> tx_manager.ListPostingsResponse
+	43, // [43:52] is the sub-list for method output_type
+	34, // [34:43] is the sub-list for method input_type
+	34, // [34:34] is the sub-list for extension type_name
+	34, // [34:34] is the sub-list for extension extendee
+	0,  // [0:34] is the sub-list for field type_name
 }
 
 func init() { file_tx_manager_proto_init() }
@@ -519,13 +3207,24 @@ func file_tx_manager_proto_init() {
 	if File_tx_manager_proto != nil {
 		return
 	}
+	file_tx_manager_proto_msgTypes[1].OneofWrappers = []any{}
+	file_tx_manager_proto_msgTypes[2].OneofWrappers = []any{
+		(*FilterExpr_Comparison)(nil),
+		(*FilterExpr_And)(nil),
+		(*FilterExpr_Or)(nil),
+	}
+	file_tx_manager_proto_msgTypes[5].OneofWrappers = []any{
+		(*FilterValue_Text)(nil),
+		(*FilterValue_Integer)(nil),
+		(*FilterValue_Time)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_tx_manager_proto_rawDesc), len(file_tx_manager_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   6,
+			NumEnums:      9,
+			NumMessages:   30,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
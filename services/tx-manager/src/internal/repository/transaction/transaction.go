@@ -2,9 +2,12 @@ package transaction
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/config"
 	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/models"
@@ -12,16 +15,43 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-var orderByFields = map[string]struct{}{
-	"user_id":          {},
-	"amount":           {},
-	"transaction_type": {},
-	"timestamp":        {},
+var sortColumns = map[models.SortField]string{
+	models.SortByUserID: "t.user_id",
+	models.SortByType:   "t.transaction_type",
+	models.SortByAmount: "t.amount",
+	models.SortByTime:   "t.transaction_time",
 }
 
+// keysetOrder is the order of cursor pages: newest first, then by id.
+const keysetOrder = "t.transaction_time DESC, t.id DESC"
+
+// selectTransactions reads transactions as t. A transaction is voided once a rollback references it.
+const selectTransactions = `
+		SELECT t.id, t.user_id, t.transaction_type, t.amount, t.transaction_time,
+			COALESCE(t.reference_id, ''), t.original_id,
+			COALESCE((SELECT o.transaction_type FROM transactions o WHERE o.id = t.original_id), ''),
+			CASE WHEN EXISTS (
+				SELECT 1 FROM transactions v WHERE v.original_id = t.id AND v.transaction_type = 'rollback'
+			) THEN 'voided' ELSE 'completed' END,
+			COALESCE(t.round_id, ''), COALESCE(t.game_id, ''), COALESCE(t.provider, ''),
+			COALESCE(t.session_id, ''), COALESCE(t.channel, ''), t.currency
+		FROM transactions t
+`
+
+const selectRounds = `
+		SELECT round_id, user_id, COALESCE(game_id, ''), provider,
+			CASE WHEN settled_at IS NOT NULL AND bet_count > 0 THEN 'settled' ELSE 'open' END, currency,
+			bet_total, win_total, net, bet_count, win_count, opened_at, settled_at
+		FROM rounds
+`
+
+// snapshotLockKey is the advisory lock held by the instance taking balance snapshots.
+const snapshotLockKey int64 = 0x62616c616e6365
+
 type Repository struct {
 	db *pgxpool.Pool
 }
@@ -50,34 +80,350 @@ func New(cfg config.DatabaseConfig) (*Repository, error) {
 	return NewWithPool(pool), nil
 }
 
-func (r *Repository) Add(ctx context.Context, transactions ...models.Transaction) error {
+func (r *Repository) Add(ctx context.Context, offsets []models.Offset, transactions ...models.Transaction) error {
+	// A rollback whose original isn't stored yet has neither a direction nor an original.
+	insertQuery := `
+        INSERT INTO transactions (id, user_id, transaction_type, direction, amount, transaction_time, t_hash, event_id, reference_id, original_id,
+            round_id, game_id, provider, session_id, channel, currency)
+        VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7, NULLIF($8, ''), NULLIF($9, ''), $10,
+            NULLIF($11, ''), NULLIF($12, ''), NULLIF($13, ''), NULLIF($14, ''), NULLIF($15, ''), $16)
+        ON CONFLICT DO NOTHING
+    `
+
+	// Inserts the transaction with its ledger postings and, only if it wasn't a duplicate, adds it to the user's balance.
+	// Balance updates are sums, so the order events arrive in doesn't matter.
+	inserted := `
+        WITH inserted AS (` + insertQuery + ` RETURNING id, user_id, round_id
+        ), postings AS (
+        INSERT INTO postings (transaction_id, account, amount, currency, transaction_time)
+        SELECT inserted.id, p.account, p.amount, $16, $6
+        FROM inserted, unnest($19::text[], $20::bigint[]) AS p(account, amount)
+        )`
+
+	balanceUpsert := `
+        INSERT INTO balances (user_id, currency, balance, credit_total, debit_total, transaction_count, last_transaction_time)
+        SELECT user_id, $16, $17::bigint - $18::bigint, $17::bigint, $18::bigint, 1, $6
+        FROM inserted
+        ON CONFLICT (user_id, currency) DO UPDATE SET
+            balance = balances.balance + EXCLUDED.balance,
+            credit_total = balances.credit_total + EXCLUDED.credit_total,
+            debit_total = balances.debit_total + EXCLUDED.debit_total,
+            transaction_count = balances.transaction_count + 1,
+            last_transaction_time = GREATEST(balances.last_transaction_time, EXCLUDED.last_transaction_time),
+            updated_at = now()
+    `
+
+	// Snapshots taken after a late transaction are shifted by a trigger once the balance row is locked.
+	balanceQuery := inserted + balanceUpsert
+
+	// Does the same and also adds the transaction to its round.
+	roundQuery := inserted + `, balance AS (` + balanceUpsert + `)
+        INSERT INTO rounds (provider, round_id, user_id, game_id, currency, bet_total, win_total, net, bet_count, win_count, opened_at, settled_at)
+        SELECT $13, round_id, $2, NULLIF($12, ''), $16, $21::bigint, $22::bigint, $23::bigint, $24::int, $25::int,
+            $6, CASE WHEN $26::boolean THEN $6 END
+        FROM inserted
+        ON CONFLICT (provider, round_id) DO UPDATE SET
+            game_id = COALESCE(rounds.game_id, EXCLUDED.game_id),
+            bet_total = rounds.bet_total + EXCLUDED.bet_total,
+            win_total = rounds.win_total + EXCLUDED.win_total,
+            net = rounds.net + EXCLUDED.net,
+            bet_count = rounds.bet_count + EXCLUDED.bet_count,
+            win_count = rounds.win_count + EXCLUDED.win_count,
+            opened_at = LEAST(rounds.opened_at, EXCLUDED.opened_at),
+            settled_at = LEAST(rounds.settled_at, EXCLUDED.settled_at),
+            updated_at = now()
+    `
+
+	offsetQuery := `
+        INSERT INTO consumer_offsets (consumer_group, topic, partition_id, next_offset)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (consumer_group, topic, partition_id)
+        DO UPDATE SET next_offset = GREATEST(consumer_offsets.next_offset, EXCLUDED.next_offset)
+    `
+
+	// Ids are assigned up front, so rollbacks can reference originals inserted in the same batch.
+	transactions = slices.Clone(transactions)
+
+	for i := range transactions {
+		if transactions[i].ID == uuid.Nil {
+			transactions[i].ID = uuid.New()
+		}
+
+		if len(transactions[i].Currency) == 0 {
+			transactions[i].Currency = models.DefaultCurrency
+		}
+	}
+
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		pending, err := resolvePending(ctx, tx, transactions)
+		if err != nil {
+			return err
+		}
+
+		transactions = append(transactions, pending...)
+		if err := resolveOriginals(ctx, tx, transactions); err != nil {
+			return err
+		}
+
+		// Originals are inserted before the rollbacks referencing them.
+		queued := make([]models.Transaction, 0, len(transactions))
+		for _, t := range transactions {
+			if t.Type != models.Rollback {
+				queued = append(queued, t)
+			}
+		}
+
+		for _, t := range transactions {
+			if t.Type == models.Rollback {
+				queued = append(queued, t)
+			}
+		}
+
+		batch := &pgx.Batch{}
+
+		for _, t := range queued {
+			args := []any{t.ID, t.UserID, t.Type, t.Direction(), t.Amount, t.TransactionTime, t.DedupHash(), t.EventID,
+				t.ReferenceID, t.OriginalID, t.RoundID, t.GameID, t.Provider, t.SessionID, t.Channel, t.Currency}
+
+			if t.Type == models.Rollback && t.OriginalID == nil {
+				// It is booked once its original arrives.
+				batch.Queue(insertQuery, args...)
+				continue
+			}
+
+			args = append(args, balanceDelta(t)...)
+			args = append(args, postingArgs(t.Postings())...)
+
+			if len(t.RoundID) > 0 {
+				batch.Queue(roundQuery, append(args, roundDelta(t)...)...)
+			} else {
+				batch.Queue(balanceQuery, args...)
+			}
+		}
+
+		for _, o := range offsets {
+			batch.Queue(offsetQuery, o.Group, o.Topic, o.Partition, o.Offset)
+		}
+
+		return tx.SendBatch(ctx, batch).Close()
+	})
+
+	return classify(err)
+}
+
+func (r *Repository) GetOffsets(ctx context.Context, group string) ([]models.Offset, error) {
 	query := `
-        INSERT INTO transactions (user_id, transaction_type, amount, transaction_time, t_hash)
-        VALUES ($1, $2, $3, $4, $5) ON CONFLICT (t_hash) DO NOTHING
+		SELECT consumer_group, topic, partition_id, next_offset FROM consumer_offsets
+		WHERE consumer_group = $1
     `
 
-	batch := &pgx.Batch{}
-	for _, t := range transactions {
-		batch.Queue(query, t.UserID, t.Type, t.Amount, t.TransactionTime, t.Hash())
+	rows, err := r.db.Query(ctx, query, group)
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
 
-	batchResults := r.db.SendBatch(ctx, batch)
-	defer batchResults.Close()
+	var resp []models.Offset
+	for rows.Next() {
+		var o models.Offset
 
-	_, err := batchResults.Exec()
+		if err := rows.Scan(&o.Group, &o.Topic, &o.Partition, &o.Offset); err != nil {
+			return nil, err
+		}
 
-	return err
+		resp = append(resp, o)
+	}
+
+	return resp, rows.Err()
 }
 
-func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (*models.Transaction, error) {
-	resp := models.Transaction{}
+// GetByIDs returns the transactions with the given ids that exist, in no particular order.
+func (r *Repository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]models.Transaction, error) {
+	return r.queryTransactions(ctx, selectTransactions+" WHERE t.id = ANY($1)", ids)
+}
 
+// GetChain returns the transaction with the given id together with the transaction
+// it references and everything else referencing the same original, oldest first.
+func (r *Repository) GetChain(ctx context.Context, id uuid.UUID) ([]models.Transaction, error) {
 	query := `
-		SELECT id, user_id, transaction_type, amount, transaction_time FROM transactions
-		where id = $1
+		WITH RECURSIVE ancestors AS (
+			SELECT id, original_id FROM transactions WHERE id = $1
+			UNION
+			SELECT p.id, p.original_id FROM transactions p JOIN ancestors a ON p.id = a.original_id
+		), chain AS (
+			SELECT id FROM ancestors WHERE original_id IS NULL
+			UNION
+			SELECT c.id FROM transactions c JOIN chain ON c.original_id = chain.id
+		)` + selectTransactions + `
+		JOIN chain ON chain.id = t.id
+		ORDER BY t.transaction_time, t.id
     `
 
-	err := r.db.QueryRow(ctx, query, id).Scan(&resp.ID, &resp.UserID, &resp.Type, &resp.Amount, &resp.TransactionTime)
+	rows, err := r.db.Query(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var resp []models.Transaction
+	for rows.Next() {
+		var t models.Transaction
+
+		if err := scanTransaction(rows, &t); err != nil {
+			return nil, err
+		}
+
+		resp = append(resp, t)
+	}
+
+	return resp, rows.Err()
+}
+
+// GetAll returns a page of transactions sorted by the given fields and then by id, so that offsets are stable.
+// Without a sort it uses the keyset order of GetPage, so offset pages continue the pages read by cursor.
+func (r *Repository) GetAll(ctx context.Context, filters models.TransactionFilter, sort []models.Sort, limit, offset int64) ([]models.Transaction, error) {
+	query := selectTransactions
+
+	cond, args, err := filters.Where()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", svcerr.ErrBadField, err)
+	}
+
+	if len(cond) > 0 {
+		query += " WHERE " + cond
+	}
+
+	orderBy, err := orderByClause(sort)
+	if err != nil {
+		return nil, err
+	}
+
+	query += " ORDER BY " + orderBy
+
+	args = append(args, limit, offset)
+	query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+
+	return r.queryTransactions(ctx, query, args...)
+}
+
+// GetPage returns up to limit transactions in the keyset order, newest first and then by id, starting after the cursor.
+func (r *Repository) GetPage(ctx context.Context, filters models.TransactionFilter, after *models.Cursor, limit int64) ([]models.Transaction, error) {
+	query := selectTransactions
+
+	cond, args, err := filters.Where()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", svcerr.ErrBadField, err)
+	}
+
+	if after != nil {
+		args = append(args, after.Time, after.ID)
+		keyset := fmt.Sprintf("(t.transaction_time, t.id) < ($%d, $%d)", len(args)-1, len(args))
+
+		if len(cond) > 0 {
+			cond += " AND " + keyset
+		} else {
+			cond = keyset
+		}
+	}
+
+	if len(cond) > 0 {
+		query += " WHERE " + cond
+	}
+
+	args = append(args, limit)
+	query += fmt.Sprintf(" ORDER BY %s LIMIT $%d", keysetOrder, len(args))
+
+	return r.queryTransactions(ctx, query, args...)
+}
+
+// Count returns the number of transactions matching filters.
+func (r *Repository) Count(ctx context.Context, filters models.TransactionFilter) (int64, error) {
+	query := "SELECT count(*) FROM transactions t"
+
+	cond, args, err := filters.Where()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", svcerr.ErrBadField, err)
+	}
+
+	if len(cond) > 0 {
+		query += " WHERE " + cond
+	}
+
+	var resp int64
+	if err := r.db.QueryRow(ctx, query, args...).Scan(&resp); err != nil {
+		return 0, err
+	}
+
+	return resp, nil
+}
+
+// EstimateCount returns the number of rows the planner expects to match filters,
+// based on the table statistics kept up to date by autovacuum and ANALYZE.
+func (r *Repository) EstimateCount(ctx context.Context, filters models.TransactionFilter) (int64, error) {
+	query := "EXPLAIN (FORMAT JSON) SELECT 1 FROM transactions t"
+
+	cond, args, err := filters.Where()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", svcerr.ErrBadField, err)
+	}
+
+	if len(cond) > 0 {
+		query += " WHERE " + cond
+	}
+
+	var plan []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+
+	var raw []byte
+	if err := r.db.QueryRow(ctx, query, args...).Scan(&raw); err != nil {
+		return 0, err
+	}
+
+	if err := json.Unmarshal(raw, &plan); err != nil {
+		return 0, fmt.Errorf("failed to read query plan: %w", err)
+	}
+
+	if len(plan) == 0 {
+		return 0, errors.New("query plan is empty")
+	}
+
+	return int64(plan[0].Plan.Rows), nil
+}
+
+func (r *Repository) queryTransactions(ctx context.Context, query string, args ...any) ([]models.Transaction, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var resp []models.Transaction
+	for rows.Next() {
+		var t models.Transaction
+
+		if err := scanTransaction(rows, &t); err != nil {
+			return nil, err
+		}
+
+		resp = append(resp, t)
+	}
+
+	return resp, rows.Err()
+}
+
+func (r *Repository) GetBalance(ctx context.Context, userID uuid.UUID, currency string) (*models.Balance, error) {
+	query := `
+		SELECT user_id, currency, balance, credit_total, debit_total, transaction_count, last_transaction_time, updated_at
+		FROM balances
+		WHERE user_id = $1 AND currency = $2
+    `
+
+	var b models.Balance
+	err := r.db.QueryRow(ctx, query, userID, currency).Scan(&b.UserID, &b.Currency, &b.Balance, &b.CreditTotal, &b.DebitTotal,
+		&b.TransactionCount, &b.LastTransactionTime, &b.UpdatedAt)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
@@ -86,59 +432,727 @@ func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (*models.Transac
 		return nil, err
 	}
 
+	return &b, nil
+}
+
+// SnapshotBalances stores the balance as of at for every user and currency with transactions since their last snapshot.
+// It returns the number of snapshots taken, none when another instance is taking them.
+func (r *Repository) SnapshotBalances(ctx context.Context, at time.Time) (int64, error) {
+	// Locks the balance rows of the users to snapshot, so their transactions being added are either
+	// committed before the snapshot is read or wait for it and then shift it in the trigger.
+	lockQuery := `
+		SELECT b.user_id, b.currency FROM balances b
+		WHERE EXISTS (
+			SELECT 1 FROM transactions t
+			WHERE t.user_id = b.user_id AND t.currency = b.currency AND t.direction IS NOT NULL AND t.transaction_time <= $1
+				AND t.transaction_time > COALESCE((
+					SELECT max(s.taken_at) FROM balance_snapshots s
+					WHERE s.user_id = b.user_id AND s.currency = b.currency AND s.taken_at <= $1
+				), '-infinity')
+		)
+		ORDER BY b.user_id, b.currency
+		FOR SHARE OF b
+    `
+
+	query := `
+		WITH last AS (
+			SELECT DISTINCT ON (user_id, currency) user_id, currency, taken_at, balance, transaction_count
+			FROM balance_snapshots
+			WHERE taken_at <= $1
+			ORDER BY user_id, currency, taken_at DESC
+		)
+		INSERT INTO balance_snapshots (user_id, currency, taken_at, balance, transaction_count)
+		SELECT t.user_id, t.currency, $1,
+			COALESCE(l.balance, 0) + SUM(CASE WHEN t.direction = 'credit' THEN t.amount ELSE -t.amount END),
+			COALESCE(l.transaction_count, 0) + COUNT(*)
+		FROM transactions t
+		JOIN unnest($2::uuid[], $3::text[]) AS locked(user_id, currency) ON locked.user_id = t.user_id AND locked.currency = t.currency
+		LEFT JOIN last l ON l.user_id = t.user_id AND l.currency = t.currency
+		WHERE t.direction IS NOT NULL AND t.transaction_time <= $1 AND (l.taken_at IS NULL OR t.transaction_time > l.taken_at)
+		GROUP BY t.user_id, t.currency, l.balance, l.transaction_count
+		ON CONFLICT (user_id, currency, taken_at) DO NOTHING
+    `
+
+	var taken int64
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		// Only one instance takes snapshots at a time, the others skip the run.
+		var acquired bool
+		if err := tx.QueryRow(ctx, "SELECT pg_try_advisory_xact_lock($1)", snapshotLockKey).Scan(&acquired); err != nil || !acquired {
+			return err
+		}
+
+		rows, err := tx.Query(ctx, lockQuery, at)
+		if err != nil {
+			return err
+		}
+
+		var (
+			users      []uuid.UUID
+			currencies []string
+		)
+
+		for rows.Next() {
+			var (
+				userID   uuid.UUID
+				currency string
+			)
+
+			if err := rows.Scan(&userID, &currency); err != nil {
+				rows.Close()
+				return err
+			}
+
+			users = append(users, userID)
+			currencies = append(currencies, currency)
+		}
+
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		if len(users) == 0 {
+			return nil
+		}
+
+		// A new statement, so it reads the transactions committed while the rows were locked.
+		tag, err := tx.Exec(ctx, query, at, users, currencies)
+		if err != nil {
+			return err
+		}
+
+		taken = tag.RowsAffected()
+		return nil
+	})
+
+	return taken, err
+}
+
+// GetBalanceAt replays the transactions of a user in a currency from the nearest snapshot up to at.
+// Rollbacks waiting for their original have no direction yet and are skipped.
+func (r *Repository) GetBalanceAt(ctx context.Context, userID uuid.UUID, currency string, at time.Time) (*models.BalancePoint, error) {
+	query := `
+		WITH snapshot AS (
+			SELECT taken_at, balance, transaction_count FROM balance_snapshots
+			WHERE user_id = $1 AND currency = $3 AND taken_at <= $2
+			ORDER BY taken_at DESC
+			LIMIT 1
+		)
+		SELECT COALESCE((SELECT balance FROM snapshot), 0) + COALESCE(SUM(CASE WHEN direction = 'credit' THEN amount ELSE -amount END), 0),
+			COALESCE((SELECT transaction_count FROM snapshot), 0) + COUNT(*)
+		FROM transactions
+		WHERE user_id = $1 AND currency = $3 AND direction IS NOT NULL AND transaction_time <= $2
+			AND transaction_time > COALESCE((SELECT taken_at FROM snapshot), '-infinity')
+    `
+
+	resp := models.BalancePoint{At: at, Currency: currency}
+	if err := r.db.QueryRow(ctx, query, userID, at, currency).Scan(&resp.Balance, &resp.TransactionCount); err != nil {
+		return nil, err
+	}
+
 	return &resp, nil
 }
 
-func (r *Repository) GetAll(ctx context.Context, filters models.TransactionFilter, orderBy string, limit, offset int64) ([]models.Transaction, error) {
+// GetBalanceChanges splits (from, to] into steps and returns what the transactions
+// of a user in a currency add to the balance within each step, keyed by the end of the step.
+// When to isn't a whole number of steps after from, the last step is shorter and ends at to.
+func (r *Repository) GetBalanceChanges(ctx context.Context, userID uuid.UUID, currency string, from, to time.Time, step time.Duration) ([]models.BalancePoint, error) {
 	query := `
-		SELECT id, user_id, transaction_type, amount, transaction_time FROM transactions
+		WITH points AS (
+			SELECT at, COALESCE(lag(at) OVER (ORDER BY at), $2::timestamptz) AS since
+			FROM (
+				SELECT generate_series($2::timestamptz + $4::bigint * interval '1 microsecond', $3::timestamptz, $4::bigint * interval '1 microsecond')
+				UNION
+				SELECT $3::timestamptz
+			) AS g(at)
+		)
+		SELECT p.at,
+			COALESCE(SUM(CASE WHEN t.direction = 'credit' THEN t.amount ELSE -t.amount END), 0),
+			COUNT(t.id)
+		FROM points p
+		LEFT JOIN transactions t ON t.user_id = $1 AND t.currency = $5 AND t.direction IS NOT NULL
+			AND t.transaction_time > p.since AND t.transaction_time <= p.at
+		GROUP BY p.at
+		ORDER BY p.at
     `
 
-	cond, args := filters.String()
-	if len(cond) > 0 {
-		query += " WHERE " + cond
+	rows, err := r.db.Query(ctx, query, userID, from, to, step.Microseconds(), currency)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var resp []models.BalancePoint
+	for rows.Next() {
+		p := models.BalancePoint{Currency: currency}
+
+		if err := rows.Scan(&p.At, &p.Balance, &p.TransactionCount); err != nil {
+			return nil, err
+		}
+
+		resp = append(resp, p)
+	}
+
+	return resp, rows.Err()
+}
+
+// GetPostings returns the postings of an account in a currency, newest first.
+func (r *Repository) GetPostings(ctx context.Context, account, currency string, limit, offset int64) ([]models.Posting, error) {
+	query := `
+		SELECT id, transaction_id, account, amount, currency, transaction_time
+		FROM postings
+		WHERE account = $1 AND currency = $2
+		ORDER BY transaction_time DESC, id DESC
+		LIMIT $3 OFFSET $4
+    `
+
+	rows, err := r.db.Query(ctx, query, account, currency, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var resp []models.Posting
+	for rows.Next() {
+		var p models.Posting
+
+		if err := rows.Scan(&p.ID, &p.TransactionID, &p.Account, &p.Amount, &p.Currency, &p.TransactionTime); err != nil {
+			return nil, err
+		}
+
+		resp = append(resp, p)
+	}
+
+	return resp, rows.Err()
+}
+
+// GetAccountBalance reads player wallets from the balances projection, which holds
+// the sum of their postings. Named accounts are summed from the covering postings index.
+func (r *Repository) GetAccountBalance(ctx context.Context, account, currency string) (int64, error) {
+	walletQuery := `
+		SELECT balance FROM balances WHERE user_id = $1 AND currency = $2
+    `
+
+	query := `
+		SELECT COALESCE(SUM(amount), 0) FROM postings WHERE account = $1 AND currency = $2
+    `
+
+	var balance int64
+
+	if userID, ok := models.PlayerOf(account); ok {
+		err := r.db.QueryRow(ctx, walletQuery, userID, currency).Scan(&balance)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil
+		}
+
+		return balance, err
 	}
 
-	if len(orderBy) > 0 {
-		if !validateOrderBy(orderBy) {
-			return nil, fmt.Errorf("%w: invalid orderBy: %s", svcerr.ErrBadField, orderBy)
+	err := r.db.QueryRow(ctx, query, account, currency).Scan(&balance)
+
+	return balance, err
+}
+
+func (r *Repository) GetRound(ctx context.Context, provider, id string) (*models.Round, error) {
+	query := selectRounds + `
+		WHERE provider = $1 AND round_id = $2
+    `
+
+	var round models.Round
+	if err := scanRound(r.db.QueryRow(ctx, query, provider, id), &round); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
 		}
 
-		query += fmt.Sprintf(" ORDER BY %s", orderBy)
+		return nil, err
+	}
+
+	rounds := []models.Round{round}
+	if err := r.attachRoundTransactions(ctx, rounds); err != nil {
+		return nil, err
+	}
+
+	return &rounds[0], nil
+}
+
+// GetOpenRounds returns rounds that are not settled yet, oldest first.
+func (r *Repository) GetOpenRounds(ctx context.Context, filters models.RoundFilter, limit, offset int64) ([]models.Round, error) {
+	query := selectRounds + `
+		WHERE (settled_at IS NULL OR bet_count = 0)
+    `
+
+	cond, args := filters.String()
+	if len(cond) > 0 {
+		query += " AND " + cond
 	}
 
 	args = append(args, limit, offset)
-	query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+	query += fmt.Sprintf(" ORDER BY opened_at, provider, round_id LIMIT $%d OFFSET $%d", len(args)-1, len(args))
 
-	var resp []models.Transaction
 	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
+	var resp []models.Round
 	for rows.Next() {
-		var t models.Transaction
+		var round models.Round
 
-		if err := rows.Scan(&t.ID, &t.UserID, &t.Type, &t.Amount, &t.TransactionTime); err != nil {
+		if err := scanRound(rows, &round); err != nil {
 			return nil, err
 		}
 
-		resp = append(resp, t)
+		resp = append(resp, round)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.attachRoundTransactions(ctx, resp); err != nil {
+		return nil, err
 	}
 
 	return resp, nil
 }
 
+func (r *Repository) attachRoundTransactions(ctx context.Context, rounds []models.Round) error {
+	if len(rounds) == 0 {
+		return nil
+	}
+
+	// Round ids are only unique per provider, so the transactions are matched on both.
+	type roundKey struct{ provider, id string }
+
+	ids := make([]string, 0, len(rounds))
+	byKey := make(map[roundKey]*models.Round, len(rounds))
+	for i := range rounds {
+		ids = append(ids, rounds[i].ID)
+		byKey[roundKey{rounds[i].Provider, rounds[i].ID}] = &rounds[i]
+	}
+
+	query := selectTransactions + `
+		WHERE t.round_id = ANY($1)
+		ORDER BY t.transaction_time, t.id
+    `
+
+	rows, err := r.db.Query(ctx, query, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var t models.Transaction
+
+		if err := scanTransaction(rows, &t); err != nil {
+			return err
+		}
+
+		if round, ok := byKey[roundKey{t.Provider, t.RoundID}]; ok {
+			round.Transactions = append(round.Transactions, t)
+		}
+	}
+
+	return rows.Err()
+}
+
+func (r *Repository) Rehash(ctx context.Context, batchSize int) (int64, int64, error) {
+	selectQuery := `
+		SELECT id, user_id, transaction_type, amount, transaction_time, COALESCE(reference_id, ''),
+			COALESCE(round_id, ''), COALESCE(game_id, ''), COALESCE(provider, ''), COALESCE(session_id, ''), COALESCE(channel, ''),
+			currency, t_hash
+		FROM transactions
+		WHERE event_id IS NULL AND id > $1
+		ORDER BY id
+		LIMIT $2
+    `
+
+	updateQuery := `
+		UPDATE transactions SET t_hash = $2
+		WHERE id = $1 AND NOT EXISTS (SELECT 1 FROM transactions WHERE t_hash = $2)
+    `
+
+	var (
+		updated, skipped int64
+		last             uuid.UUID
+	)
+
+	for {
+		rows, err := r.db.Query(ctx, selectQuery, last, batchSize)
+		if err != nil {
+			return updated, skipped, err
+		}
+
+		type row struct {
+			tx   models.Transaction
+			hash *string
+		}
+
+		var batch []row
+		for rows.Next() {
+			var rw row
+
+			if err := rows.Scan(&rw.tx.ID, &rw.tx.UserID, &rw.tx.Type, &rw.tx.Amount, &rw.tx.TransactionTime, &rw.tx.ReferenceID,
+				&rw.tx.RoundID, &rw.tx.GameID, &rw.tx.Provider, &rw.tx.SessionID, &rw.tx.Channel, &rw.tx.Currency, &rw.hash); err != nil {
+				rows.Close()
+				return updated, skipped, err
+			}
+
+			batch = append(batch, rw)
+		}
+		rows.Close()
+
+		if err := rows.Err(); err != nil {
+			return updated, skipped, err
+		}
+
+		if len(batch) == 0 {
+			return updated, skipped, nil
+		}
+
+		for _, rw := range batch {
+			last = rw.tx.ID
+
+			hash := rw.tx.Hash()
+			if rw.hash != nil && *rw.hash == hash {
+				continue
+			}
+
+			tag, err := r.db.Exec(ctx, updateQuery, rw.tx.ID, hash)
+			if err != nil {
+				return updated, skipped, err
+			}
+
+			if tag.RowsAffected() == 0 {
+				skipped++
+				continue
+			}
+
+			updated++
+		}
+	}
+}
+
+func (r *Repository) Ping(ctx context.Context) error {
+	return r.db.Ping(ctx)
+}
+
 func (r *Repository) Close() {
 	r.db.Close()
 }
 
-func validateOrderBy(orderBy string) bool {
-	parts := strings.Split(orderBy, " ")
-	if len(parts) != 2 {
-		return false
+func scanTransaction(row pgx.Row, t *models.Transaction) error {
+	return row.Scan(&t.ID, &t.UserID, &t.Type, &t.Amount, &t.TransactionTime, &t.ReferenceID, &t.OriginalID, &t.OriginalType, &t.Status,
+		&t.RoundID, &t.GameID, &t.Provider, &t.SessionID, &t.Channel, &t.Currency)
+}
+
+func scanRound(row pgx.Row, r *models.Round) error {
+	return row.Scan(&r.ID, &r.UserID, &r.GameID, &r.Provider, &r.Status, &r.Currency,
+		&r.BetTotal, &r.WinTotal, &r.Net, &r.BetCount, &r.WinCount, &r.OpenedAt, &r.SettledAt)
+}
+
+// balanceDelta returns what the transaction adds to the credit and debit totals of its user.
+func balanceDelta(t models.Transaction) []any {
+	if t.Direction() == models.Debit {
+		return []any{int64(0), t.Amount}
+	}
+
+	return []any{t.Amount, int64(0)}
+}
+
+// postingArgs returns the accounts and amounts of the postings as two arrays.
+func postingArgs(postings []models.Posting) []any {
+	accounts := make([]string, 0, len(postings))
+	amounts := make([]int64, 0, len(postings))
+	for _, p := range postings {
+		accounts = append(accounts, p.Account)
+		amounts = append(amounts, p.Amount)
+	}
+
+	return []any{accounts, amounts}
+}
+
+// roundDelta returns what the transaction adds to its round:
+// bet and win totals, net result, bet and win counts, and whether it settles the round.
+func roundDelta(t models.Transaction) []any {
+	var (
+		bet, win   int64
+		bets, wins int
+	)
+	switch {
+	case t.Type == models.Bet:
+		bet, bets = t.Amount, 1
+	case t.Type.Wins():
+		win, wins = t.Amount, 1
+	}
+
+	return []any{bet, win, int64(t.Sign()) * t.Amount, bets, wins, t.Type.Settles()}
+}
+
+// resolveOriginals sets the id and type of the transaction each rollback voids. It is looked up in the table first
+// and then in the batch. A rollback's direction depends on its original, so one whose original isn't stored yet
+// stays unresolved until it arrives. Rollbacks of other rollbacks and second rollbacks of a transaction are rejected.
+func resolveOriginals(ctx context.Context, tx pgx.Tx, transactions []models.Transaction) error {
+	originalsQuery := `
+		SELECT id, COALESCE(event_id, ''), transaction_type FROM transactions
+		WHERE id = ANY($1) OR event_id = ANY($2)
+    `
+
+	// Rollbacks already stored for the same originals, resolved or not.
+	rollbacksQuery := `
+		SELECT original_id, COALESCE(reference_id, ''), COALESCE(event_id, ''), COALESCE(t_hash, '') FROM transactions
+		WHERE transaction_type = 'rollback' AND (original_id = ANY($1) OR (original_id IS NULL AND reference_id = ANY($2)))
+    `
+
+	type original struct {
+		id  uuid.UUID
+		typ models.TransactionType
+	}
+
+	ids := make([]uuid.UUID, 0)
+	references := make([]string, 0)
+
+	for _, t := range transactions {
+		if t.Type != models.Rollback {
+			continue
+		}
+
+		references = append(references, t.ReferenceID)
+		if id := referencedID(t.ReferenceID); id != nil {
+			ids = append(ids, *id)
+		}
+	}
+
+	if len(references) == 0 {
+		return nil
+	}
+
+	byID := make(map[uuid.UUID]original)
+	byEventID := make(map[string]original)
+
+	rows, err := tx.Query(ctx, originalsQuery, ids, references)
+	if err != nil {
+		return err
+	}
+
+	for rows.Next() {
+		var (
+			o       original
+			eventID string
+		)
+
+		if err := rows.Scan(&o.id, &eventID, &o.typ); err != nil {
+			rows.Close()
+			return err
+		}
+
+		byID[o.id] = o
+		if len(eventID) > 0 {
+			byEventID[eventID] = o
+		}
+	}
+
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	batched := make(map[string]original, len(transactions))
+	for _, t := range transactions {
+		if _, ok := batched[t.EventID]; len(t.EventID) > 0 && !ok {
+			batched[t.EventID] = original{id: t.ID, typ: t.Type}
+		}
+	}
+
+	resolved := make([]uuid.UUID, 0, len(references))
+
+	for i, t := range transactions {
+		if t.Type != models.Rollback {
+			continue
+		}
+
+		o, ok := byEventID[t.ReferenceID]
+		if id := referencedID(t.ReferenceID); !ok && id != nil {
+			o, ok = byID[*id]
+		}
+
+		if !ok {
+			o, ok = batched[t.ReferenceID]
+		}
+
+		switch {
+		case !ok:
+			transactions[i].OriginalID, transactions[i].OriginalType = nil, ""
+			continue
+		case o.typ == models.Rollback:
+			return fmt.Errorf("%w: rollback references another rollback %s", svcerr.ErrRejected, t.ReferenceID)
+		}
+
+		transactions[i].OriginalID, transactions[i].OriginalType = &o.id, o.typ
+		resolved = append(resolved, o.id)
+	}
+
+	// A redelivered rollback is the same event and is deduplicated on insert instead.
+	voids := make(map[string]models.Transaction, len(references))
+	for _, t := range transactions {
+		if t.Type != models.Rollback {
+			continue
+		}
+
+		key := "reference:" + t.ReferenceID
+		if t.OriginalID != nil {
+			key = "original:" + t.OriginalID.String()
+		}
+
+		if prev, ok := voids[key]; ok && !sameEvent(prev, t.EventID, t.DedupHash()) {
+			return fmt.Errorf("%w: transaction %s is already rolled back", svcerr.ErrRejected, t.ReferenceID)
+		}
+
+		voids[key] = t
+	}
+
+	rows, err = tx.Query(ctx, rollbacksQuery, resolved, references)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			originalID         *uuid.UUID
+			reference, eventID string
+			hash               string
+		)
+
+		if err := rows.Scan(&originalID, &reference, &eventID, &hash); err != nil {
+			return err
+		}
+
+		key := "reference:" + reference
+		if originalID != nil {
+			key = "original:" + originalID.String()
+		}
+
+		t, ok := voids[key]
+		if !ok {
+			continue
+		}
+
+		if !sameEvent(t, eventID, &hash) {
+			return fmt.Errorf("%w: transaction %s is already rolled back", svcerr.ErrRejected, t.ReferenceID)
+		}
+	}
+
+	return rows.Err()
+}
+
+// sameEvent tells whether the transaction is the event with the given event id, or hash when it has none.
+func sameEvent(t models.Transaction, eventID string, hash *string) bool {
+	if len(t.EventID) > 0 || len(eventID) > 0 {
+		return t.EventID == eventID
+	}
+
+	own := t.DedupHash()
+	return hash != nil && *own == *hash
+}
+
+// resolvePending takes the stored rollbacks waiting for originals that arrive in the batch out of the table,
+// so they are booked again together with them.
+func resolvePending(ctx context.Context, tx pgx.Tx, transactions []models.Transaction) ([]models.Transaction, error) {
+	query := `
+		DELETE FROM transactions
+		WHERE transaction_type = 'rollback' AND original_id IS NULL AND reference_id = ANY($1)
+		RETURNING id, COALESCE(event_id, ''), user_id, amount, transaction_time, reference_id,
+			COALESCE(round_id, ''), COALESCE(game_id, ''), COALESCE(provider, ''),
+			COALESCE(session_id, ''), COALESCE(channel, ''), currency
+    `
+
+	eventIDs := make([]string, 0, len(transactions))
+	for _, t := range transactions {
+		if t.Type != models.Rollback && len(t.EventID) > 0 {
+			eventIDs = append(eventIDs, t.EventID)
+		}
+	}
+
+	if len(eventIDs) == 0 {
+		return nil, nil
+	}
+
+	rows, err := tx.Query(ctx, query, eventIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pending []models.Transaction
+	for rows.Next() {
+		t := models.Transaction{Type: models.Rollback}
+
+		if err := rows.Scan(&t.ID, &t.EventID, &t.UserID, &t.Amount, &t.TransactionTime, &t.ReferenceID,
+			&t.RoundID, &t.GameID, &t.Provider, &t.SessionID, &t.Channel, &t.Currency); err != nil {
+			return nil, err
+		}
+
+		pending = append(pending, t)
+	}
+
+	return pending, rows.Err()
+}
+
+// referencedID returns the reference as a transaction id, or nil when it is a provider event id.
+func referencedID(reference string) *uuid.UUID {
+	id, err := uuid.Parse(reference)
+	if err != nil {
+		return nil
+	}
+
+	return &id
+}
+
+// classify marks data exceptions and constraint violations as rejected:
+// retrying the same rows can't succeed, unlike connection or server failures.
+// A foreign key violation is transient as well, the referenced row may be committed by a later attempt.
+func classify(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+		return fmt.Errorf("%w: %w: %v", svcerr.ErrRejected, svcerr.ErrTransient, err)
+	}
+
+	if errors.As(err, &pgErr) && (strings.HasPrefix(pgErr.Code, "22") || strings.HasPrefix(pgErr.Code, "23")) {
+		return fmt.Errorf("%w: %v", svcerr.ErrRejected, err)
+	}
+
+	return err
+}
+
+// orderByClause maps sort fields to their columns, only known fields reach the query.
+func orderByClause(sort []models.Sort) (string, error) {
+	if len(sort) == 0 {
+		return keysetOrder, nil
+	}
+
+	terms := make([]string, 0, len(sort)+1)
+	seen := make(map[models.SortField]struct{}, len(sort))
+
+	for _, s := range sort {
+		column, ok := sortColumns[s.Field]
+		if !ok {
+			return "", fmt.Errorf("%w: invalid sort field: %s", svcerr.ErrBadField, s.Field)
+		}
+
+		if _, ok = seen[s.Field]; ok {
+			return "", fmt.Errorf("%w: duplicate sort field: %s", svcerr.ErrBadField, s.Field)
+		}
+		seen[s.Field] = struct{}{}
+
+		if s.Desc {
+			column += " DESC"
+		}
+
+		terms = append(terms, column)
 	}
 
-	_, ok := orderByFields[parts[0]]
-	return ok
+	return strings.Join(append(terms, "t.id"), ", "), nil
 }
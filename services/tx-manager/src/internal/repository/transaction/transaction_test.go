@@ -4,12 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"log"
-	"math/rand"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/models"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/svcerr"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -84,6 +84,7 @@ func TestRepositoryAddIntegrations(t *testing.T) {
 	now := time.Now()
 	repo := NewWithPool(testDB)
 
+	_, _ = testDB.Exec(ctx, "DELETE FROM postings")
 	_, _ = testDB.Exec(ctx, "DELETE FROM transactions")
 
 	tx1 := models.Transaction{
@@ -103,32 +104,597 @@ func TestRepositoryAddIntegrations(t *testing.T) {
 	}
 
 	t.Run("add batch of transactions", func(t *testing.T) {
-		err := repo.Add(ctx, tx1, tx2)
+		err := repo.Add(ctx, nil, tx1, tx2)
 		assert.Nil(t, err)
 
-		resp, err := repo.GetAll(ctx, models.TransactionFilter{}, "", 10, 0)
+		resp, err := repo.GetAll(ctx, models.TransactionFilter{}, nil, 10, 0)
 		assert.Nil(t, err)
 		assert.Len(t, resp, 2)
 	})
 
 	t.Run("add duplicate transactions should not insert again", func(t *testing.T) {
-		err := repo.Add(ctx, tx1)
+		err := repo.Add(ctx, nil, tx1)
 		if err != nil {
 			t.Fatalf("failed to add transaction: %v", err)
 		}
 
-		resp, err := repo.GetAll(ctx, models.TransactionFilter{}, "", 10, 0)
+		resp, err := repo.GetAll(ctx, models.TransactionFilter{}, nil, 10, 0)
 		assert.Nil(t, err)
 		assert.Len(t, resp, 2)
 	})
 }
 
+func TestRepositoryAddEventIDIntegration(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	repo := NewWithPool(testDB)
+
+	_, _ = testDB.Exec(ctx, "DELETE FROM postings")
+	_, _ = testDB.Exec(ctx, "DELETE FROM transactions")
+
+	userID := uuid.New()
+
+	bet1 := models.Transaction{EventID: "evt-1", UserID: userID, Type: models.Bet, Amount: 100, TransactionTime: now}
+	bet2 := models.Transaction{EventID: "evt-2", UserID: userID, Type: models.Bet, Amount: 100, TransactionTime: now}
+
+	t.Run("identical bets with different event ids are both stored", func(t *testing.T) {
+		err := repo.Add(ctx, nil, bet1, bet2)
+		assert.NoError(t, err)
+
+		resp, err := repo.GetAll(ctx, models.TransactionFilter{UserID: &userID}, nil, 10, 0)
+		assert.NoError(t, err)
+		assert.Len(t, resp, 2)
+	})
+
+	t.Run("redelivered event id is not stored again", func(t *testing.T) {
+		redelivered := bet1
+		redelivered.Amount = 500
+
+		err := repo.Add(ctx, nil, redelivered)
+		assert.NoError(t, err)
+
+		resp, err := repo.GetAll(ctx, models.TransactionFilter{UserID: &userID}, nil, 10, 0)
+		assert.NoError(t, err)
+		assert.Len(t, resp, 2)
+	})
+}
+
+func TestRepositoryAddRejectedIntegration(t *testing.T) {
+	ctx := context.Background()
+	repo := NewWithPool(testDB)
+
+	_, _ = testDB.Exec(ctx, "DELETE FROM consumer_offsets WHERE consumer_group = 'rejected'")
+
+	tooLong := models.Transaction{UserID: uuid.New(), Type: models.Bet, Amount: 100, Currency: "EURO", TransactionTime: time.Now()}
+
+	err := repo.Add(ctx, []models.Offset{{Group: "rejected", Topic: "t1", Partition: 0, Offset: 1}}, tooLong)
+	assert.True(t, svcerr.IsRejected(err))
+
+	resp, err := repo.GetOffsets(ctx, "rejected")
+	assert.NoError(t, err)
+	assert.Empty(t, resp)
+}
+
+func TestRepositoryRollbackIntegration(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	repo := NewWithPool(testDB)
+
+	_, _ = testDB.Exec(ctx, "DELETE FROM postings")
+	_, _ = testDB.Exec(ctx, "DELETE FROM transactions")
+
+	userID := uuid.New()
+
+	bet := models.Transaction{EventID: "bet-1", UserID: userID, Type: models.Bet, Amount: 100, TransactionTime: now}
+	rollback := models.Transaction{EventID: "rollback-1", UserID: userID, Type: models.Rollback, Amount: 100, TransactionTime: now.Add(time.Second), ReferenceID: "bet-1"}
+	early := models.Transaction{EventID: "rollback-2", UserID: userID, Type: models.Rollback, Amount: 50, TransactionTime: now, ReferenceID: "bet-2"}
+	late := models.Transaction{EventID: "bet-2", UserID: userID, Type: models.Bet, Amount: 50, TransactionTime: now.Add(time.Second)}
+
+	find := func(t *testing.T, eventID string) models.Transaction {
+		var id uuid.UUID
+		err := testDB.QueryRow(ctx, "SELECT id FROM transactions WHERE event_id = $1", eventID).Scan(&id)
+		assert.NoError(t, err)
+
+		tr, err := repo.GetByIDs(ctx, []uuid.UUID{id})
+		assert.NoError(t, err)
+		assert.Len(t, tr, 1)
+
+		return tr[0]
+	}
+
+	t.Run("rollback voids the referenced transaction", func(t *testing.T) {
+		err := repo.Add(ctx, nil, bet, rollback)
+		assert.NoError(t, err)
+
+		original := find(t, "bet-1")
+		assert.Equal(t, models.Voided, original.Status)
+
+		stored := find(t, "rollback-1")
+		assert.Equal(t, models.Completed, stored.Status)
+		assert.Equal(t, "bet-1", stored.ReferenceID)
+		assert.Equal(t, &original.ID, stored.OriginalID)
+		assert.Equal(t, models.Bet, stored.OriginalType)
+		assert.Equal(t, models.Credit, stored.Direction())
+
+		balance, err := repo.GetBalance(ctx, userID, models.DefaultCurrency)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), balance.Balance)
+
+		chain, err := repo.GetChain(ctx, stored.ID)
+		assert.NoError(t, err)
+		assert.Len(t, chain, 2)
+		assert.Equal(t, original.ID, chain[0].ID)
+		assert.Equal(t, stored.ID, chain[1].ID)
+	})
+
+	t.Run("rollback of an unknown transaction waits for it", func(t *testing.T) {
+		err := repo.Add(ctx, nil, early)
+		assert.NoError(t, err)
+
+		stored := find(t, "rollback-2")
+		assert.Nil(t, stored.OriginalID)
+		assert.Equal(t, models.Direction(""), stored.Direction())
+
+		balance, err := repo.GetBalance(ctx, userID, models.DefaultCurrency)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), balance.Balance)
+		assert.Equal(t, 2, balance.TransactionCount)
+
+		err = repo.Add(ctx, nil, late)
+		assert.NoError(t, err)
+
+		original := find(t, "bet-2")
+		assert.Equal(t, models.Voided, original.Status)
+
+		stored = find(t, "rollback-2")
+		assert.Equal(t, &original.ID, stored.OriginalID)
+		assert.Equal(t, models.Credit, stored.Direction())
+
+		balance, err = repo.GetBalance(ctx, userID, models.DefaultCurrency)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), balance.Balance)
+		assert.Equal(t, 4, balance.TransactionCount)
+	})
+
+	t.Run("rollback of a rollback is rejected", func(t *testing.T) {
+		rollbackOfRollback := models.Transaction{EventID: "rollback-4", UserID: userID, Type: models.Rollback, Amount: 100, TransactionTime: now, ReferenceID: "rollback-1"}
+		err := repo.Add(ctx, nil, rollbackOfRollback)
+		assert.ErrorIs(t, err, svcerr.ErrRejected)
+	})
+
+	t.Run("second rollback of a transaction is rejected", func(t *testing.T) {
+		err := repo.Add(ctx, nil, rollback)
+		assert.NoError(t, err)
+
+		original := find(t, "bet-2")
+		byID := models.Transaction{EventID: "rollback-3", UserID: userID, Type: models.Rollback, Amount: 50, TransactionTime: now, ReferenceID: original.ID.String()}
+		err = repo.Add(ctx, nil, byID)
+		assert.ErrorIs(t, err, svcerr.ErrRejected)
+
+		first := models.Transaction{EventID: "rollback-6", UserID: userID, Type: models.Rollback, Amount: 10, TransactionTime: now, ReferenceID: "bet-9"}
+		second := models.Transaction{EventID: "rollback-7", UserID: userID, Type: models.Rollback, Amount: 10, TransactionTime: now, ReferenceID: "bet-9"}
+		err = repo.Add(ctx, nil, first, second)
+		assert.ErrorIs(t, err, svcerr.ErrRejected)
+	})
+
+	t.Run("rollback queued before the original in a batch is linked", func(t *testing.T) {
+		bet := models.Transaction{EventID: "bet-4", UserID: userID, Type: models.Bet, Amount: 30, TransactionTime: now}
+		voided := models.Transaction{EventID: "rollback-8", UserID: userID, Type: models.Rollback, Amount: 30, TransactionTime: now, ReferenceID: "bet-4"}
+
+		err := repo.Add(ctx, nil, voided, bet)
+		assert.NoError(t, err)
+
+		original := find(t, "bet-4")
+		assert.Equal(t, models.Voided, original.Status)
+		assert.Equal(t, &original.ID, find(t, "rollback-8").OriginalID)
+	})
+
+	t.Run("rollback of a win debits the player", func(t *testing.T) {
+		winner := uuid.New()
+		win := models.Transaction{EventID: "win-1", UserID: winner, Type: models.Win, Amount: 70, TransactionTime: now}
+		voided := models.Transaction{EventID: "rollback-5", UserID: winner, Type: models.Rollback, Amount: 70, TransactionTime: now.Add(time.Second), ReferenceID: "win-1"}
+
+		err := repo.Add(ctx, nil, win)
+		assert.NoError(t, err)
+
+		err = repo.Add(ctx, nil, voided)
+		assert.NoError(t, err)
+		assert.Equal(t, models.Debit, find(t, "rollback-5").Direction())
+
+		balance, err := repo.GetBalance(ctx, winner, models.DefaultCurrency)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), balance.Balance)
+		assert.Equal(t, int64(70), balance.DebitTotal)
+	})
+
+	t.Run("rollback may reference the transaction id", func(t *testing.T) {
+		bet := models.Transaction{EventID: "bet-5", UserID: userID, Type: models.Bet, Amount: 20, TransactionTime: now}
+		err := repo.Add(ctx, nil, bet)
+		assert.NoError(t, err)
+
+		original := find(t, "bet-5")
+		byID := models.Transaction{EventID: "rollback-9", UserID: userID, Type: models.Rollback, Amount: 20, TransactionTime: now, ReferenceID: original.ID.String()}
+
+		err = repo.Add(ctx, nil, byID)
+		assert.NoError(t, err)
+		assert.Equal(t, &original.ID, find(t, "rollback-9").OriginalID)
+
+		chain, err := repo.GetChain(ctx, original.ID)
+		assert.NoError(t, err)
+		assert.Len(t, chain, 2)
+	})
+
+	t.Run("only rollbacks are linked to their reference", func(t *testing.T) {
+		refund := models.Transaction{EventID: "refund-1", UserID: userID, Type: models.Refund, Amount: 10, TransactionTime: now, ReferenceID: "bet-1"}
+		early := models.Transaction{EventID: "refund-2", UserID: userID, Type: models.Refund, Amount: 10, TransactionTime: now, ReferenceID: "bet-3"}
+		late := models.Transaction{EventID: "bet-3", UserID: userID, Type: models.Bet, Amount: 10, TransactionTime: now}
+
+		err := repo.Add(ctx, nil, refund, early, late)
+		assert.NoError(t, err)
+
+		assert.Nil(t, find(t, "refund-1").OriginalID)
+		assert.Nil(t, find(t, "refund-2").OriginalID)
+		assert.Equal(t, models.Completed, find(t, "bet-3").Status)
+	})
+}
+
+func TestRepositoryRoundsIntegration(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	repo := NewWithPool(testDB)
+
+	_, _ = testDB.Exec(ctx, "DELETE FROM rounds")
+	_, _ = testDB.Exec(ctx, "DELETE FROM postings")
+	_, _ = testDB.Exec(ctx, "DELETE FROM transactions")
+
+	userID := uuid.New()
+
+	bet := models.Transaction{EventID: "round-bet-1", UserID: userID, Type: models.Bet, Amount: 100, TransactionTime: now, RoundID: "round-1", GameID: "book-of-dead"}
+	win := models.Transaction{EventID: "round-win-1", UserID: userID, Type: models.Win, Amount: 250, TransactionTime: now.Add(time.Second), RoundID: "round-1"}
+	open := models.Transaction{EventID: "round-bet-2", UserID: userID, Type: models.Bet, Amount: 50, TransactionTime: now, RoundID: "round-2"}
+	unmatched := models.Transaction{EventID: "round-win-3", UserID: userID, Type: models.Win, Amount: 70, TransactionTime: now, RoundID: "round-3"}
+
+	t.Run("round is settled by a win", func(t *testing.T) {
+		err := repo.Add(ctx, nil, bet, win)
+		assert.NoError(t, err)
+
+		round, err := repo.GetRound(ctx, "", "round-1")
+		assert.NoError(t, err)
+		assert.Equal(t, models.RoundSettled, round.Status)
+		assert.Equal(t, "book-of-dead", round.GameID)
+		assert.Equal(t, int64(100), round.BetTotal)
+		assert.Equal(t, int64(250), round.WinTotal)
+		assert.Equal(t, int64(150), round.Net)
+		assert.True(t, now.Equal(round.OpenedAt))
+		assert.Len(t, round.Transactions, 2)
+	})
+
+	t.Run("redelivered transaction is not counted twice", func(t *testing.T) {
+		err := repo.Add(ctx, nil, win)
+		assert.NoError(t, err)
+
+		round, err := repo.GetRound(ctx, "", "round-1")
+		assert.NoError(t, err)
+		assert.Equal(t, 1, round.WinCount)
+		assert.Equal(t, int64(250), round.WinTotal)
+	})
+
+	t.Run("open rounds include bets without a win and wins without a bet", func(t *testing.T) {
+		err := repo.Add(ctx, nil, open, unmatched)
+		assert.NoError(t, err)
+
+		rounds, err := repo.GetOpenRounds(ctx, models.RoundFilter{UserID: &userID}, 10, 0)
+		assert.NoError(t, err)
+		assert.Len(t, rounds, 2)
+
+		for _, round := range rounds {
+			assert.Equal(t, models.RoundOpen, round.Status)
+			assert.Len(t, round.Transactions, 1)
+		}
+
+		before := now
+		rounds, err = repo.GetOpenRounds(ctx, models.RoundFilter{OpenedBefore: &before}, 10, 0)
+		assert.NoError(t, err)
+		assert.Empty(t, rounds)
+	})
+
+	t.Run("round ids are scoped by provider", func(t *testing.T) {
+		first := models.Transaction{EventID: "round-bet-4", UserID: userID, Type: models.Bet, Amount: 10, TransactionTime: now, RoundID: "round-4", Provider: "pragmatic"}
+		second := models.Transaction{EventID: "round-bet-5", UserID: uuid.New(), Type: models.Bet, Amount: 20, TransactionTime: now, RoundID: "round-4", Provider: "netent"}
+
+		err := repo.Add(ctx, nil, first, second)
+		assert.NoError(t, err)
+
+		round, err := repo.GetRound(ctx, "pragmatic", "round-4")
+		assert.NoError(t, err)
+		assert.Equal(t, userID, round.UserID)
+		assert.Equal(t, int64(10), round.BetTotal)
+		assert.Len(t, round.Transactions, 1)
+
+		round, err = repo.GetRound(ctx, "netent", "round-4")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(20), round.BetTotal)
+		assert.Len(t, round.Transactions, 1)
+
+		round, err = repo.GetRound(ctx, "", "round-4")
+		assert.NoError(t, err)
+		assert.Nil(t, round)
+	})
+
+	t.Run("unknown round", func(t *testing.T) {
+		round, err := repo.GetRound(ctx, "", "missing")
+		assert.NoError(t, err)
+		assert.Nil(t, round)
+	})
+}
+
+func TestRepositoryBalancesIntegration(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	repo := NewWithPool(testDB)
+
+	_, _ = testDB.Exec(ctx, "DELETE FROM balances")
+
+	userID := uuid.New()
+
+	deposit := models.Transaction{EventID: "balance-deposit-1", UserID: userID, Type: models.Deposit, Amount: 1000, TransactionTime: now}
+	bet := models.Transaction{EventID: "balance-bet-1", UserID: userID, Type: models.Bet, Amount: 300, TransactionTime: now.Add(time.Minute), RoundID: "balance-round-1"}
+	win := models.Transaction{UserID: userID, Type: models.Win, Amount: 50, TransactionTime: now.Add(2 * time.Minute)}
+
+	t.Run("out of order transactions", func(t *testing.T) {
+		err := repo.Add(ctx, nil, win, bet, deposit)
+		assert.NoError(t, err)
+
+		balance, err := repo.GetBalance(ctx, userID, models.DefaultCurrency)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(750), balance.Balance)
+		assert.Equal(t, int64(1050), balance.CreditTotal)
+		assert.Equal(t, int64(300), balance.DebitTotal)
+		assert.Equal(t, 3, balance.TransactionCount)
+		assert.True(t, now.Add(2*time.Minute).Equal(balance.LastTransactionTime))
+	})
+
+	t.Run("redelivered transactions are not counted twice", func(t *testing.T) {
+		err := repo.Add(ctx, nil, deposit, bet, win)
+		assert.NoError(t, err)
+
+		balance, err := repo.GetBalance(ctx, userID, models.DefaultCurrency)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(750), balance.Balance)
+		assert.Equal(t, 3, balance.TransactionCount)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		balance, err := repo.GetBalance(ctx, uuid.New(), models.DefaultCurrency)
+		assert.NoError(t, err)
+		assert.Nil(t, balance)
+	})
+
+	t.Run("balance at a point in time is replayed from the nearest snapshot", func(t *testing.T) {
+		_, _ = testDB.Exec(ctx, "DELETE FROM balance_snapshots")
+
+		taken, err := repo.SnapshotBalances(ctx, now.Add(90*time.Second))
+		assert.NoError(t, err)
+		assert.Positive(t, taken)
+
+		point, err := repo.GetBalanceAt(ctx, userID, models.DefaultCurrency, now.Add(90*time.Second))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(700), point.Balance)
+		assert.Equal(t, 2, point.TransactionCount)
+
+		point, err = repo.GetBalanceAt(ctx, userID, models.DefaultCurrency, now.Add(time.Hour))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(750), point.Balance)
+
+		point, err = repo.GetBalanceAt(ctx, userID, models.DefaultCurrency, now.Add(-time.Second))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), point.Balance)
+	})
+
+	t.Run("late transaction shifts later snapshots", func(t *testing.T) {
+		late := models.Transaction{EventID: "balance-late-1", UserID: userID, Type: models.Withdrawal, Amount: 100, TransactionTime: now.Add(30 * time.Second)}
+		err := repo.Add(ctx, nil, late)
+		assert.NoError(t, err)
+
+		point, err := repo.GetBalanceAt(ctx, userID, models.DefaultCurrency, now.Add(90*time.Second))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(600), point.Balance)
+		assert.Equal(t, 3, point.TransactionCount)
+	})
+
+	t.Run("balance changes per step", func(t *testing.T) {
+		changes, err := repo.GetBalanceChanges(ctx, userID, models.DefaultCurrency, now.Add(-time.Second), now.Add(2*time.Minute), time.Minute)
+		assert.NoError(t, err)
+		assert.Len(t, changes, 2)
+		assert.Equal(t, int64(900), changes[0].Balance)
+		assert.Equal(t, int64(-300), changes[1].Balance)
+	})
+
+	t.Run("balance changes end at to when it is off the step grid", func(t *testing.T) {
+		changes, err := repo.GetBalanceChanges(ctx, userID, models.DefaultCurrency, now.Add(-time.Second), now.Add(90*time.Second), time.Minute)
+		assert.NoError(t, err)
+		assert.Len(t, changes, 2)
+		assert.True(t, now.Add(59*time.Second).Equal(changes[0].At))
+		assert.Equal(t, int64(900), changes[0].Balance)
+		assert.True(t, now.Add(90*time.Second).Equal(changes[1].At))
+		assert.Equal(t, int64(-300), changes[1].Balance)
+	})
+
+	t.Run("snapshot waits for a transaction being added", func(t *testing.T) {
+		at := now.Add(100 * time.Second)
+		deposit := models.Transaction{EventID: "balance-deposit-2", UserID: userID, Type: models.Deposit, Amount: 10, TransactionTime: now.Add(92 * time.Second)}
+		assert.NoError(t, repo.Add(ctx, nil, deposit))
+
+		tx, err := testDB.Begin(ctx)
+		assert.NoError(t, err)
+
+		_, err = tx.Exec(ctx, "UPDATE balances SET updated_at = now() WHERE user_id = $1", userID)
+		assert.NoError(t, err)
+
+		_, err = tx.Exec(ctx, `INSERT INTO transactions (user_id, transaction_type, direction, amount, transaction_time, event_id, currency)
+			VALUES ($1, 'deposit', 'credit', 40, $2, 'balance-inflight-1', 'EUR')`, userID, now.Add(95*time.Second))
+		assert.NoError(t, err)
+
+		done := make(chan error, 1)
+		go func() {
+			_, err := repo.SnapshotBalances(ctx, at)
+			done <- err
+		}()
+
+		time.Sleep(200 * time.Millisecond)
+		assert.NoError(t, tx.Commit(ctx))
+		assert.NoError(t, <-done)
+
+		var balance int64
+		err = testDB.QueryRow(ctx, "SELECT balance FROM balance_snapshots WHERE user_id = $1 AND taken_at = $2", userID, at).Scan(&balance)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(650), balance)
+	})
+
+	t.Run("only one instance takes snapshots", func(t *testing.T) {
+		conn, err := testDB.Acquire(ctx)
+		assert.NoError(t, err)
+		defer conn.Release()
+
+		_, err = conn.Exec(ctx, "SELECT pg_advisory_lock($1)", snapshotLockKey)
+		assert.NoError(t, err)
+		defer conn.Exec(ctx, "SELECT pg_advisory_unlock($1)", snapshotLockKey)
+
+		taken, err := repo.SnapshotBalances(ctx, now.Add(time.Hour))
+		assert.NoError(t, err)
+		assert.Zero(t, taken)
+	})
+}
+
+func TestRepositoryLedgerIntegration(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	repo := NewWithPool(testDB)
+
+	_, _ = testDB.Exec(ctx, "DELETE FROM postings")
+
+	userID := uuid.New()
+
+	deposit := models.Transaction{EventID: "ledger-deposit-1", UserID: userID, Type: models.Deposit, Amount: 1000, TransactionTime: now}
+	bet := models.Transaction{EventID: "ledger-bet-1", UserID: userID, Type: models.Bet, Amount: 300, TransactionTime: now.Add(time.Minute)}
+	jackpot := models.Transaction{EventID: "ledger-jackpot-1", UserID: userID, Type: models.JackpotWin, Amount: 5000, TransactionTime: now.Add(2 * time.Minute)}
+
+	t.Run("every transaction is posted to both accounts", func(t *testing.T) {
+		err := repo.Add(ctx, nil, deposit, bet, jackpot, bet)
+		assert.NoError(t, err)
+
+		postings, err := repo.GetPostings(ctx, models.PlayerAccount(userID), models.DefaultCurrency, 10, 0)
+		assert.NoError(t, err)
+		assert.Len(t, postings, 3)
+		assert.Equal(t, int64(5000), postings[0].Amount)
+
+		balance, err := repo.GetAccountBalance(ctx, models.PlayerAccount(userID), models.DefaultCurrency)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5700), balance)
+
+		for account, expected := range map[string]int64{models.BankAccount: -1000, models.HouseAccount: 300, models.JackpotPoolAccount: -5000} {
+			balance, err := repo.GetAccountBalance(ctx, account, models.DefaultCurrency)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, balance, account)
+		}
+
+		balance, err = repo.GetAccountBalance(ctx, models.PlayerAccount(uuid.New()), models.DefaultCurrency)
+		assert.NoError(t, err)
+		assert.Zero(t, balance)
+	})
+
+	t.Run("unbalanced postings are rejected", func(t *testing.T) {
+		_, err := testDB.Exec(ctx, `INSERT INTO postings (transaction_id, account, amount, transaction_time)
+			SELECT transaction_id, 'house', 1, now() FROM postings LIMIT 1`)
+		assert.Error(t, err)
+	})
+}
+
+func TestRepositoryRehashIntegration(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	repo := NewWithPool(testDB)
+
+	_, _ = testDB.Exec(ctx, "DELETE FROM postings")
+	_, _ = testDB.Exec(ctx, "DELETE FROM transactions")
+
+	tx := models.Transaction{UserID: uuid.New(), Type: models.Bet, Amount: 100, TransactionTime: now}
+	withEventID := models.Transaction{EventID: "evt-rehash", UserID: uuid.New(), Type: models.Win, Amount: 10, TransactionTime: now}
+
+	err := repo.Add(ctx, nil, tx, withEventID)
+	assert.NoError(t, err)
+
+	_, err = testDB.Exec(ctx, "UPDATE transactions SET t_hash = 'legacy' WHERE event_id IS NULL")
+	assert.NoError(t, err)
+
+	updated, skipped, err := repo.Rehash(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), updated)
+	assert.Equal(t, int64(0), skipped)
+
+	var hash string
+	err = testDB.QueryRow(ctx, "SELECT t_hash FROM transactions WHERE event_id IS NULL").Scan(&hash)
+	assert.NoError(t, err)
+	assert.Equal(t, tx.Hash(), hash)
+
+	updated, _, err = repo.Rehash(ctx, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), updated)
+}
+
+func TestRepositoryOffsetsIntegration(t *testing.T) {
+	ctx := context.Background()
+	repo := NewWithPool(testDB)
+
+	_, _ = testDB.Exec(ctx, "DELETE FROM consumer_offsets")
+
+	tx := models.Transaction{
+		UserID:          uuid.New(),
+		Type:            models.Bet,
+		Amount:          100,
+		TransactionTime: time.Now(),
+	}
+
+	t.Run("offsets are stored with the batch", func(t *testing.T) {
+		err := repo.Add(ctx, []models.Offset{
+			{Group: "g1", Topic: "t1", Partition: 0, Offset: 10},
+			{Group: "g1", Topic: "t1", Partition: 1, Offset: 3},
+		}, tx)
+		assert.NoError(t, err)
+
+		resp, err := repo.GetOffsets(ctx, "g1")
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []models.Offset{
+			{Group: "g1", Topic: "t1", Partition: 0, Offset: 10},
+			{Group: "g1", Topic: "t1", Partition: 1, Offset: 3},
+		}, resp)
+	})
+
+	t.Run("stored offsets never move backwards", func(t *testing.T) {
+		err := repo.Add(ctx, []models.Offset{
+			{Group: "g1", Topic: "t1", Partition: 0, Offset: 5},
+		})
+		assert.NoError(t, err)
+
+		resp, err := repo.GetOffsets(ctx, "g1")
+		assert.NoError(t, err)
+		assert.Contains(t, resp, models.Offset{Group: "g1", Topic: "t1", Partition: 0, Offset: 10})
+	})
+
+	t.Run("unknown group has no offsets", func(t *testing.T) {
+		resp, err := repo.GetOffsets(ctx, "unknown")
+		assert.NoError(t, err)
+		assert.Empty(t, resp)
+	})
+}
+
 func TestRepositoryGetAllIntegration(t *testing.T) {
 	ctx := context.Background()
 	now := time.Now()
 
 	repo := NewWithPool(testDB)
 
+	_, _ = testDB.Exec(ctx, "DELETE FROM postings")
 	_, _ = testDB.Exec(ctx, "DELETE FROM transactions")
 
 	tx1 := models.Transaction{
@@ -151,28 +717,52 @@ func TestRepositoryGetAllIntegration(t *testing.T) {
 		Type:            models.Bet,
 		Amount:          300,
 		TransactionTime: now,
+		RoundID:         "round-1",
+		GameID:          "book-of-dead",
+		Provider:        "playngo",
+		SessionID:       "session-1",
+		Channel:         "mobile",
 	}
 
-	err := repo.Add(ctx, tx1, tx2, tx3)
+	err := repo.Add(ctx, nil, tx1, tx2, tx3)
 	assert.NoError(t, err)
 
+	hourAgo, inHour := now.Add(-time.Hour), now.Add(time.Hour)
+	minAmount, maxAmount := int64(150), int64(300)
+
 	tests := []struct {
 		name          string
 		filter        models.TransactionFilter
 		expectedCount int
-		orderBy       string
+		sort          []models.Sort
 		expectedErr   bool
 	}{
-		{"all transactions", models.TransactionFilter{}, 3, "id desc", true},
-		{"filter by user", models.TransactionFilter{UserID: &tx1.UserID}, 2, "id", true},
-		{"filter by type", models.TransactionFilter{Type: &tx3.Type}, 2, "user_id desc", false},
-		{"filter by user and type", models.TransactionFilter{UserID: &tx1.UserID, Type: &tx1.Type}, 1, "amount", true},
-		{"non-existing filter", models.TransactionFilter{UserID: &uuid.UUID{}}, 0, "", false},
+		{"all transactions", models.TransactionFilter{}, 3, []models.Sort{{Field: "id", Desc: true}}, true},
+		{"filter by user", models.TransactionFilter{UserID: &tx1.UserID}, 2, []models.Sort{{Field: "amount; DROP TABLE transactions"}}, true},
+		{"filter by type", models.TransactionFilter{Type: &tx3.Type}, 2, []models.Sort{{Field: models.SortByUserID, Desc: true}}, false},
+		{"filter by user and type", models.TransactionFilter{UserID: &tx1.UserID, Type: &tx1.Type}, 1, []models.Sort{{Field: models.SortByAmount}, {Field: models.SortByAmount, Desc: true}}, true},
+		{"sort by several fields", models.TransactionFilter{}, 3, []models.Sort{{Field: models.SortByType}, {Field: models.SortByTime, Desc: true}, {Field: models.SortByAmount}}, false},
+		{"non-existing filter", models.TransactionFilter{UserID: &uuid.UUID{}}, 0, nil, false},
+		{"filter by round", models.TransactionFilter{RoundID: &tx3.RoundID}, 1, nil, false},
+		{"filter by game and provider", models.TransactionFilter{GameID: &tx3.GameID, Provider: &tx3.Provider}, 1, nil, false},
+		{"filter by session and channel", models.TransactionFilter{SessionID: &tx3.SessionID, Channel: &tx3.Channel}, 1, nil, false},
+		{"filter by time range", models.TransactionFilter{From: &hourAgo, To: &inHour}, 3, nil, false},
+		{"filter by time range ending before", models.TransactionFilter{To: &hourAgo}, 0, nil, false},
+		{"filter by amount range", models.TransactionFilter{MinAmount: &minAmount, MaxAmount: &maxAmount}, 2, nil, false},
+		{"filter by type and min amount", models.TransactionFilter{Type: &tx1.Type, MinAmount: &minAmount}, 1, nil, false},
+		{"filter by expression", models.TransactionFilter{Expr: &models.FilterExpr{Or: []models.FilterExpr{
+			{Comparison: &models.Comparison{Field: models.FilterType, Op: models.OpEqual, Values: []any{models.Win}}},
+			{Comparison: &models.Comparison{Field: models.FilterAmount, Op: models.OpGreater, Values: []any{int64(250)}}},
+		}}}, 2, nil, false},
+		{"filter by channel not in", models.TransactionFilter{Expr: &models.FilterExpr{
+			Comparison: &models.Comparison{Field: models.FilterChannel, Op: models.OpNotIn, Values: []any{"mobile"}},
+		}}, 2, nil, false},
+		{"invalid expression", models.TransactionFilter{Expr: &models.FilterExpr{}}, 0, nil, true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			results, err := repo.GetAll(ctx, tt.filter, tt.orderBy, 100, 0)
+			results, err := repo.GetAll(ctx, tt.filter, tt.sort, 100, 0)
 			if tt.expectedErr {
 				assert.Error(t, err)
 				return
@@ -185,79 +775,98 @@ func TestRepositoryGetAllIntegration(t *testing.T) {
 	}
 }
 
-func TestRepositoryGetByID(t *testing.T) {
+func TestRepositoryGetPageIntegration(t *testing.T) {
 	ctx := context.Background()
-	now := time.Now()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
 	repo := NewWithPool(testDB)
-	n := int64(10)
 
-	_, err := testDB.Exec(ctx, "DELETE FROM transactions")
-	assert.NoError(t, err)
+	_, _ = testDB.Exec(ctx, "DELETE FROM postings")
+	_, _ = testDB.Exec(ctx, "DELETE FROM transactions")
 
-	for range n {
-		err = repo.Add(ctx, models.Transaction{
-			ID:              uuid.New(),
-			UserID:          uuid.New(),
-			Type:            models.Bet,
-			Amount:          rand.Intn(100),
-			TransactionTime: now,
-		})
+	userID := uuid.New()
+	older := models.Transaction{ID: uuid.New(), UserID: userID, Type: models.Bet, Amount: 100, TransactionTime: now.Add(-time.Minute)}
+	sameTime1 := models.Transaction{ID: uuid.New(), UserID: userID, Type: models.Bet, Amount: 200, TransactionTime: now}
+	sameTime2 := models.Transaction{ID: uuid.New(), UserID: userID, Type: models.Win, Amount: 300, TransactionTime: now}
+
+	assert.NoError(t, repo.Add(ctx, nil, older, sameTime1, sameTime2))
+
+	var seen []uuid.UUID
+	var after *models.Cursor
+	for page := 0; page < 3; page++ {
+		resp, err := repo.GetPage(ctx, models.TransactionFilter{UserID: &userID}, after, 2)
 		assert.NoError(t, err)
-	}
 
-	resp, err := repo.GetAll(ctx, models.TransactionFilter{}, "", n, 0)
-	assert.NoError(t, err)
-	assert.Len(t, resp, int(n))
+		for _, tr := range resp {
+			seen = append(seen, tr.ID)
+		}
 
-	type tCase struct {
-		id        uuid.UUID
-		toBeFound bool
-		dropConn  bool
-	}
+		if len(resp) < 2 {
+			break
+		}
 
-	tests := []tCase{
-		{id: uuid.New(), toBeFound: false, dropConn: true},
+		cursor := models.CursorOf(resp[len(resp)-1])
+		after = &cursor
 	}
 
+	assert.Len(t, seen, 3)
+	assert.ElementsMatch(t, []uuid.UUID{sameTime1.ID, sameTime2.ID}, seen[:2])
+	assert.Equal(t, older.ID, seen[2])
+
+	resp, err := repo.GetAll(ctx, models.TransactionFilter{UserID: &userID}, nil, 2, 1)
+	assert.NoError(t, err)
+
+	offsetPage := make([]uuid.UUID, 0, len(resp))
 	for _, tr := range resp {
-		tests = append(tests, tCase{
-			id:        tr.ID,
-			toBeFound: true,
-		})
+		offsetPage = append(offsetPage, tr.ID)
 	}
 
-	for range n {
-		tests = append(tests, tCase{
-			id:        uuid.New(),
-			toBeFound: false,
-		})
-	}
+	assert.Equal(t, seen[1:], offsetPage)
+}
 
-	for _, tt := range tests {
-		t.Run(t.Name(), func(t *testing.T) {
-			if tt.dropConn {
-				repo.db, _ = pgxpool.New(ctx, "")
-				defer func() {
-					repo.db = testDB
-				}()
-			}
+func TestRepositoryCountIntegration(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
 
-			tr, err := repo.GetByID(ctx, tt.id)
-			if tt.toBeFound {
-				assert.NoError(t, err)
-				assert.Equal(t, tr.ID, tt.id)
+	repo := NewWithPool(testDB)
 
-				return
-			}
+	_, _ = testDB.Exec(ctx, "DELETE FROM postings")
+	_, _ = testDB.Exec(ctx, "DELETE FROM transactions")
 
-			if tt.dropConn {
-				assert.Error(t, err)
-				assert.Nil(t, tr)
-				return
-			}
+	userID := uuid.New()
+	bet := models.Bet
+	assert.NoError(t, repo.Add(ctx, nil,
+		models.Transaction{ID: uuid.New(), UserID: userID, Type: models.Bet, Amount: 100, TransactionTime: now},
+		models.Transaction{ID: uuid.New(), UserID: userID, Type: models.Bet, Amount: 200, TransactionTime: now},
+		models.Transaction{ID: uuid.New(), UserID: userID, Type: models.Win, Amount: 300, TransactionTime: now},
+	))
 
-			assert.NoError(t, err)
-			assert.Nil(t, tr)
-		})
+	n, err := repo.Count(ctx, models.TransactionFilter{UserID: &userID, Type: &bet})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	n, err = repo.EstimateCount(ctx, models.TransactionFilter{UserID: &userID})
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, n, int64(1))
+}
+
+func TestRepositoryGetByIDsIntegration(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	repo := NewWithPool(testDB)
+
+	tx1 := models.Transaction{ID: uuid.New(), UserID: uuid.New(), Type: models.Bet, Amount: 100, TransactionTime: now}
+	tx2 := models.Transaction{ID: uuid.New(), UserID: tx1.UserID, Type: models.Win, Amount: 200, TransactionTime: now}
+	assert.NoError(t, repo.Add(ctx, nil, tx1, tx2))
+
+	resp, err := repo.GetByIDs(ctx, []uuid.UUID{tx1.ID, uuid.New(), tx2.ID})
+	assert.NoError(t, err)
+
+	var ids []uuid.UUID
+	for _, tr := range resp {
+		ids = append(ids, tr.ID)
 	}
+
+	assert.ElementsMatch(t, []uuid.UUID{tx1.ID, tx2.ID}, ids)
 }
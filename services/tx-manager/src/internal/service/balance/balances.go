@@ -0,0 +1,145 @@
+package balance
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/models"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/svcerr"
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	GetBalance(ctx context.Context, userID uuid.UUID, currency string) (*models.Balance, error)
+	GetBalanceAt(ctx context.Context, userID uuid.UUID, currency string, at time.Time) (*models.BalancePoint, error)
+	GetBalanceChanges(ctx context.Context, userID uuid.UUID, currency string, from, to time.Time, step time.Duration) ([]models.BalancePoint, error)
+	SnapshotBalances(ctx context.Context, at time.Time) (int64, error)
+}
+
+type FXService interface {
+	Convert(amount int64, from, to string, at time.Time) (*models.Conversion, error)
+}
+
+type Service struct {
+	repo             Repository
+	fx               FXService
+	maxHistoryPoints int
+	now              func() time.Time
+}
+
+func New(repo Repository, fx FXService, maxHistoryPoints int) *Service {
+	return &Service{
+		repo:             repo,
+		fx:               fx,
+		maxHistoryPoints: maxHistoryPoints,
+		now:              time.Now,
+	}
+}
+
+// GetUserBalance returns the current balance, converted with the latest rates when a reporting currency is given.
+func (s *Service) GetUserBalance(ctx context.Context, userID uuid.UUID, currency, reportingCurrency string) (*models.Balance, error) {
+	resp, err := s.repo.GetBalance(ctx, userID, currency)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user balance: %w", err)
+	}
+
+	if resp == nil {
+		return nil, fmt.Errorf("user has no transactions in %s: %w", currency, svcerr.ErrNotFound)
+	}
+
+	if len(reportingCurrency) > 0 {
+		resp.Reporting, err = s.fx.Convert(resp.Balance, resp.Currency, reportingCurrency, s.now())
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert user balance: %w", err)
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *Service) GetBalanceAt(ctx context.Context, userID uuid.UUID, currency string, at time.Time, reportingCurrency string) (*models.BalancePoint, error) {
+	resp, err := s.repo.GetBalanceAt(ctx, userID, currency, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user balance at %s: %w", at.Format(time.RFC3339), err)
+	}
+
+	if len(reportingCurrency) > 0 {
+		resp.Reporting, err = s.fx.Convert(resp.Balance, resp.Currency, reportingCurrency, resp.At)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert user balance: %w", err)
+		}
+	}
+
+	return resp, nil
+}
+
+// GetBalanceHistory returns the balance at from, after every step and at to.
+func (s *Service) GetBalanceHistory(ctx context.Context, userID uuid.UUID, currency string, from, to time.Time, step time.Duration, reportingCurrency string) ([]models.BalancePoint, error) {
+	if step <= 0 || !from.Before(to) {
+		return nil, fmt.Errorf("%w: history needs a positive step and from before to", svcerr.ErrBadField)
+	}
+
+	// A last step shorter than the others still adds a point.
+	points := to.Sub(from) / step
+	if to.Sub(from)%step != 0 {
+		points++
+	}
+
+	if points >= time.Duration(s.maxHistoryPoints) {
+		return nil, fmt.Errorf("%w: history is limited to %d points", svcerr.ErrBadField, s.maxHistoryPoints)
+	}
+
+	start, err := s.repo.GetBalanceAt(ctx, userID, currency, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user balance at %s: %w", from.Format(time.RFC3339), err)
+	}
+
+	changes, err := s.repo.GetBalanceChanges(ctx, userID, currency, from, to, step)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user balance changes: %w", err)
+	}
+
+	resp := make([]models.BalancePoint, 0, len(changes)+1)
+	resp = append(resp, *start)
+
+	current := *start
+	for _, c := range changes {
+		current.At = c.At
+		current.Balance += c.Balance
+		current.TransactionCount += c.TransactionCount
+
+		resp = append(resp, current)
+	}
+
+	if len(reportingCurrency) == 0 {
+		return resp, nil
+	}
+
+	for i, p := range resp {
+		resp[i].Reporting, err = s.fx.Convert(p.Balance, p.Currency, reportingCurrency, p.At)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert user balance at %s: %w", p.At.Format(time.RFC3339), err)
+		}
+	}
+
+	return resp, nil
+}
+
+// RunSnapshots takes balance snapshots every interval until ctx is done.
+func (s *Service) RunSnapshots(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.repo.SnapshotBalances(ctx, s.now()); err != nil {
+				log.Printf("failed to snapshot balances: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package balance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/models"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/service/balance/mocks"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/svcerr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestServiceGetUserBalance(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name        string
+		repoResp    *models.Balance
+		repoErr     error
+		expectedErr error
+	}{
+		{
+			name:     "balance found",
+			repoResp: &models.Balance{UserID: userID, Currency: "EUR", Balance: 750, CreditTotal: 1050, DebitTotal: 300, TransactionCount: 3},
+		},
+		{
+			name:        "user without transactions",
+			expectedErr: svcerr.ErrNotFound,
+		},
+		{
+			name:        "repository error",
+			repoErr:     errors.New("some error"),
+			expectedErr: errors.New("some error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoMock := mocks.NewMockRepository(t)
+			svc := New(repoMock, nil, 100)
+
+			repoMock.On("GetBalance", mock.Anything, userID, "EUR").Return(tt.repoResp, tt.repoErr)
+
+			resp, err := svc.GetUserBalance(context.Background(), userID, "EUR", "")
+			if tt.expectedErr != nil {
+				assert.Error(t, err)
+				if errors.Is(tt.expectedErr, svcerr.ErrNotFound) {
+					assert.ErrorIs(t, err, svcerr.ErrNotFound)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.repoResp, resp)
+		})
+	}
+}
+
+func TestServiceGetBalanceHistory(t *testing.T) {
+	userID := uuid.New()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		to          time.Time
+		step        time.Duration
+		mockSetup   func(repo *mocks.MockRepository)
+		expected    []models.BalancePoint
+		expectedErr error
+	}{
+		{
+			name: "running balance from the start point",
+			to:   from.Add(2 * time.Hour),
+			step: time.Hour,
+			mockSetup: func(repo *mocks.MockRepository) {
+				repo.On("GetBalanceAt", mock.Anything, userID, "EUR", from).
+					Return(&models.BalancePoint{At: from, Balance: 100, TransactionCount: 1}, nil)
+				repo.On("GetBalanceChanges", mock.Anything, userID, "EUR", from, from.Add(2*time.Hour), time.Hour).
+					Return([]models.BalancePoint{
+						{At: from.Add(time.Hour), Balance: -30, TransactionCount: 2},
+						{At: from.Add(2 * time.Hour), Balance: 50, TransactionCount: 1},
+					}, nil)
+			},
+			expected: []models.BalancePoint{
+				{At: from, Balance: 100, TransactionCount: 1},
+				{At: from.Add(time.Hour), Balance: 70, TransactionCount: 3},
+				{At: from.Add(2 * time.Hour), Balance: 120, TransactionCount: 4},
+			},
+		},
+		{
+			name:        "to before from",
+			to:          from.Add(-time.Hour),
+			step:        time.Hour,
+			mockSetup:   func(repo *mocks.MockRepository) {},
+			expectedErr: svcerr.ErrBadField,
+		},
+		{
+			name:        "zero step",
+			to:          from.Add(time.Hour),
+			mockSetup:   func(repo *mocks.MockRepository) {},
+			expectedErr: svcerr.ErrBadField,
+		},
+		{
+			name:        "too many points",
+			to:          from.Add(100 * time.Hour),
+			step:        time.Hour,
+			mockSetup:   func(repo *mocks.MockRepository) {},
+			expectedErr: svcerr.ErrBadField,
+		},
+		{
+			name:        "too many points with a shorter last step",
+			to:          from.Add(99*time.Hour + 30*time.Minute),
+			step:        time.Hour,
+			mockSetup:   func(repo *mocks.MockRepository) {},
+			expectedErr: svcerr.ErrBadField,
+		},
+		{
+			name: "repository error",
+			to:   from.Add(time.Hour),
+			step: time.Hour,
+			mockSetup: func(repo *mocks.MockRepository) {
+				repo.On("GetBalanceAt", mock.Anything, userID, "EUR", from).Return(nil, errors.New("some error"))
+			},
+			expectedErr: errors.New("some error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoMock := mocks.NewMockRepository(t)
+			tt.mockSetup(repoMock)
+
+			resp, err := New(repoMock, nil, 100).GetBalanceHistory(context.Background(), userID, "EUR", from, tt.to, tt.step, "")
+			if tt.expectedErr != nil {
+				assert.Error(t, err)
+				if errors.Is(tt.expectedErr, svcerr.ErrBadField) {
+					assert.ErrorIs(t, err, svcerr.ErrBadField)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, resp)
+		})
+	}
+}
+
+func TestServiceGetBalanceHistoryReportingCurrency(t *testing.T) {
+	userID := uuid.New()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+
+	repoMock := mocks.NewMockRepository(t)
+	repoMock.On("GetBalanceAt", mock.Anything, userID, "JPY", from).Return(&models.BalancePoint{At: from, Currency: "JPY", Balance: 1625}, nil)
+	repoMock.On("GetBalanceChanges", mock.Anything, userID, "JPY", from, to, time.Hour).Return([]models.BalancePoint{{At: to}}, nil)
+
+	fxMock := mocks.NewMockFXService(t)
+	fxMock.On("Convert", int64(1625), "JPY", "EUR", from).Return(&models.Conversion{Currency: "EUR", Amount: 1000, Rate: big.NewRat(2, 325)}, nil)
+	fxMock.On("Convert", int64(1625), "JPY", "EUR", to).Return(nil, fmt.Errorf("%w: no JPY rate", svcerr.ErrNotFound))
+
+	_, err := New(repoMock, fxMock, 100).GetBalanceHistory(context.Background(), userID, "JPY", from, to, time.Hour, "EUR")
+	assert.ErrorIs(t, err, svcerr.ErrNotFound)
+}
+
+func TestServiceRunSnapshots(t *testing.T) {
+	now := time.Date(2025, 1, 1, 15, 0, 0, 0, time.UTC)
+	repoMock := mocks.NewMockRepository(t)
+	svc := New(repoMock, nil, 100)
+	svc.now = func() time.Time { return now }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	repoMock.On("SnapshotBalances", mock.Anything, now).Return(int64(1), nil).Run(func(mock.Arguments) { cancel() }).Once()
+
+	svc.RunSnapshots(ctx, time.Millisecond)
+}
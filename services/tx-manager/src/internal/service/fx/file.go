@@ -0,0 +1,97 @@
+package fx
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/models"
+)
+
+type rateEntry struct {
+	Date     string      `json:"date"`
+	Currency string      `json:"currency"`
+	Rate     json.Number `json:"rate"`
+}
+
+// LoadFile reads rates from a .csv file of date,currency,rate rows or a .json file in the format of ParseJSON.
+func LoadFile(path string) ([]models.Rate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rates file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return ParseCSV(bytes.NewReader(data))
+	case ".json":
+		return ParseJSON(data)
+	default:
+		return nil, fmt.Errorf("unsupported rates file: %s", path)
+	}
+}
+
+// ParseCSV reads date,currency,rate rows, e.g. 2025-01-02,USD,1.0321, with an optional header.
+func ParseCSV(r io.Reader) ([]models.Rate, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 3
+	reader.TrimLeadingSpace = true
+
+	var rates []models.Rate
+
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rates, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read rates: %w", err)
+		}
+
+		if line == 1 && strings.EqualFold(record[0], "date") {
+			continue
+		}
+
+		rate, err := models.ParseRate(record[0], record[1], record[2])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		rates = append(rates, rate)
+	}
+}
+
+// ParseJSON reads a {"date": "2025-01-02", "currency": "USD", "rate": "1.0321"} object or an array of them.
+// The rate may also be a JSON number.
+func ParseJSON(data []byte) ([]models.Rate, error) {
+	var entries []rateEntry
+
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		entries = make([]rateEntry, 1)
+		if err := json.Unmarshal(data, &entries[0]); err != nil {
+			return nil, fmt.Errorf("failed to decode rate: %w", err)
+		}
+	} else if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to decode rates: %w", err)
+	}
+
+	rates := make([]models.Rate, 0, len(entries))
+	for i, e := range entries {
+		rate, err := models.ParseRate(e.Date, e.Currency, e.Rate.String())
+		if err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i, err)
+		}
+
+		rates = append(rates, rate)
+	}
+
+	return rates, nil
+}
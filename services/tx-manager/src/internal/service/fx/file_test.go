@@ -0,0 +1,116 @@
+package fx
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCSV(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		expectedCount int
+		expectedErr   bool
+	}{
+		{
+			name:          "with header",
+			input:         "date,currency,rate\n2025-01-02,USD,1.0321\n2025-01-02,JPY,162.5\n",
+			expectedCount: 2,
+		},
+		{
+			name:          "without header",
+			input:         "2025-01-02, USD, 1.0321\n",
+			expectedCount: 1,
+		},
+		{
+			name:        "invalid date",
+			input:       "02.01.2025,USD,1.0321\n",
+			expectedErr: true,
+		},
+		{
+			name:        "non positive rate",
+			input:       "2025-01-02,USD,0\n",
+			expectedErr: true,
+		},
+		{
+			name:        "missing column",
+			input:       "2025-01-02,USD\n",
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := ParseCSV(strings.NewReader(tt.input))
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, resp, tt.expectedCount)
+		})
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		expectedCount int
+		expectedErr   bool
+	}{
+		{
+			name:          "single update",
+			input:         `{"date": "2025-01-02", "currency": "USD", "rate": "1.0321"}`,
+			expectedCount: 1,
+		},
+		{
+			name:          "list with numeric rate",
+			input:         `[{"date": "2025-01-02", "currency": "USD", "rate": 1.0321}, {"date": "2025-01-02", "currency": "GBP", "rate": "0.83"}]`,
+			expectedCount: 2,
+		},
+		{
+			name:        "invalid currency",
+			input:       `{"date": "2025-01-02", "currency": "usd", "rate": "1.0321"}`,
+			expectedErr: true,
+		},
+		{
+			name:        "malformed",
+			input:       `{"date": "2025-01-02"`,
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := ParseJSON([]byte(tt.input))
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, resp, tt.expectedCount)
+		})
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	dir := t.TempDir()
+
+	csvPath := filepath.Join(dir, "rates.csv")
+	require.NoError(t, os.WriteFile(csvPath, []byte("2025-01-02,USD,1.0321\n"), 0o600))
+
+	resp, err := LoadFile(csvPath)
+	require.NoError(t, err)
+	assert.Len(t, resp, 1)
+
+	_, err = LoadFile(filepath.Join(dir, "rates.xml"))
+	assert.Error(t, err)
+}
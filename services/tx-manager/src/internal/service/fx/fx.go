@@ -0,0 +1,133 @@
+package fx
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/models"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/svcerr"
+)
+
+// base is the currency rates are quoted against.
+const base = "EUR"
+
+// Service keeps FX rates in memory, per currency and oldest first.
+type Service struct {
+	mu    sync.RWMutex
+	rates map[string][]models.Rate
+}
+
+func New() *Service {
+	return &Service{rates: make(map[string][]models.Rate)}
+}
+
+// Set adds rates, replacing the ones of the same currency and date.
+func (s *Service) Set(rates ...models.Rate) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, r := range rates {
+		current := s.rates[r.Currency]
+
+		i := sort.Search(len(current), func(i int) bool { return !current[i].Date.Before(r.Date) })
+		if i < len(current) && current[i].Date.Equal(r.Date) {
+			current[i] = r
+			continue
+		}
+
+		current = append(current, models.Rate{})
+		copy(current[i+1:], current[i:])
+		current[i] = r
+
+		s.rates[r.Currency] = current
+	}
+}
+
+// Convert converts an amount in minor units of from to minor units of to with the rates valid at at,
+// that is the latest ones dated on or before it. Halves are rounded away from zero.
+func (s *Service) Convert(amount int64, from, to string, at time.Time) (*models.Conversion, error) {
+	rate, date, err := s.rate(from, to, at)
+	if err != nil {
+		return nil, err
+	}
+
+	v := new(big.Rat).Mul(new(big.Rat).SetInt64(amount), rate)
+	v.Mul(v, pow10(models.Exponent(to)-models.Exponent(from)))
+
+	converted, ok := round(v)
+	if !ok {
+		return nil, fmt.Errorf("%w: %d %s doesn't fit in %s", svcerr.ErrBadField, amount, from, to)
+	}
+
+	return &models.Conversion{
+		Currency: to,
+		Amount:   converted,
+		Rate:     rate,
+		RateDate: date,
+	}, nil
+}
+
+// rate returns the units of to per unit of from and the date of the oldest rate it was derived from.
+// The date is zero when no rate was needed.
+func (s *Service) rate(from, to string, at time.Time) (*big.Rat, time.Time, error) {
+	if from == to {
+		return big.NewRat(1, 1), time.Time{}, nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	fromRate, fromDate, err := s.baseRate(from, at)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	toRate, toDate, err := s.baseRate(to, at)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	date := fromDate
+	if date.IsZero() || (!toDate.IsZero() && toDate.Before(date)) {
+		date = toDate
+	}
+
+	return new(big.Rat).Quo(toRate, fromRate), date, nil
+}
+
+func (s *Service) baseRate(currency string, at time.Time) (*big.Rat, time.Time, error) {
+	if currency == base {
+		return big.NewRat(1, 1), time.Time{}, nil
+	}
+
+	rates := s.rates[currency]
+
+	i := sort.Search(len(rates), func(i int) bool { return rates[i].Date.After(at) })
+	if i == 0 {
+		return nil, time.Time{}, fmt.Errorf("%w: no %s rate on %s", svcerr.ErrNotFound, currency, at.Format(models.RateDateLayout))
+	}
+
+	return rates[i-1].Value, rates[i-1].Date, nil
+}
+
+func pow10(exp int) *big.Rat {
+	p := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(max(exp, -exp))), nil)
+	if exp < 0 {
+		return new(big.Rat).SetFrac(big.NewInt(1), p)
+	}
+
+	return new(big.Rat).SetInt(p)
+}
+
+func round(v *big.Rat) (int64, bool) {
+	q, r := new(big.Int).QuoRem(v.Num(), v.Denom(), new(big.Int))
+
+	if r.Lsh(r.Abs(r), 1).Cmp(v.Denom()) >= 0 {
+		q.Add(q, big.NewInt(int64(v.Sign())))
+	}
+
+	return q.Int64(), q.IsInt64()
+}
@@ -0,0 +1,174 @@
+package fx
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/models"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/svcerr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustRate(t *testing.T, date, currency, value string) models.Rate {
+	t.Helper()
+
+	r, err := models.ParseRate(date, currency, value)
+	require.NoError(t, err)
+
+	return r
+}
+
+func TestService_Convert(t *testing.T) {
+	svc := New()
+	svc.Set(
+		mustRate(t, "2025-01-03", "USD", "1.03"),
+		mustRate(t, "2025-01-01", "USD", "1.04"),
+		mustRate(t, "2025-01-02", "JPY", "162.5"),
+		mustRate(t, "2025-01-01", "GBP", "0.83"),
+	)
+
+	day := func(d int, hour int) time.Time {
+		return time.Date(2025, time.January, d, hour, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name         string
+		amount       int64
+		from, to     string
+		at           time.Time
+		expectedResp *models.Conversion
+		expectedErr  error
+	}{
+		{
+			name:   "same currency",
+			amount: 1050,
+			from:   "EUR",
+			to:     "EUR",
+			at:     day(1, 0),
+			expectedResp: &models.Conversion{
+				Currency: "EUR",
+				Amount:   1050,
+				Rate:     big.NewRat(1, 1),
+			},
+		},
+		{
+			name:   "latest rate on or before the time is used",
+			amount: 10400,
+			from:   "USD",
+			to:     "EUR",
+			at:     day(2, 12),
+			expectedResp: &models.Conversion{
+				Currency: "EUR",
+				Amount:   10000,
+				Rate:     big.NewRat(100, 104),
+				RateDate: day(1, 0),
+			},
+		},
+		{
+			name:   "rate published on the day",
+			amount: 10000,
+			from:   "EUR",
+			to:     "USD",
+			at:     day(3, 0),
+			expectedResp: &models.Conversion{
+				Currency: "USD",
+				Amount:   10300,
+				Rate:     big.NewRat(103, 100),
+				RateDate: day(3, 0),
+			},
+		},
+		{
+			name:   "exponents differ",
+			amount: 1000,
+			from:   "EUR",
+			to:     "JPY",
+			at:     day(2, 0),
+			expectedResp: &models.Conversion{
+				Currency: "JPY",
+				Amount:   1625,
+				Rate:     big.NewRat(325, 2),
+				RateDate: day(2, 0),
+			},
+		},
+		{
+			name:   "cross rate is dated by its oldest rate",
+			amount: -1,
+			from:   "GBP",
+			to:     "USD",
+			at:     day(3, 0),
+			expectedResp: &models.Conversion{
+				Currency: "USD",
+				Amount:   -1,
+				Rate:     big.NewRat(103, 83),
+				RateDate: day(1, 0),
+			},
+		},
+		{
+			name:   "halves round away from zero",
+			amount: -20,
+			from:   "EUR",
+			to:     "JPY",
+			at:     day(2, 0),
+			expectedResp: &models.Conversion{
+				Currency: "JPY",
+				Amount:   -33,
+				Rate:     big.NewRat(325, 2),
+				RateDate: day(2, 0),
+			},
+		},
+		{
+			name:        "no rate yet",
+			amount:      100,
+			from:        "JPY",
+			to:          "EUR",
+			at:          day(1, 23),
+			expectedErr: svcerr.ErrNotFound,
+		},
+		{
+			name:        "unknown currency",
+			amount:      100,
+			from:        "EUR",
+			to:          "CHF",
+			at:          day(3, 0),
+			expectedErr: svcerr.ErrNotFound,
+		},
+		{
+			name:        "converted amount overflows",
+			amount:      math.MaxInt64,
+			from:        "EUR",
+			to:          "USD",
+			at:          day(3, 0),
+			expectedErr: svcerr.ErrBadField,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := svc.Convert(tt.amount, tt.from, tt.to, tt.at)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedResp.Currency, resp.Currency)
+			assert.Equal(t, tt.expectedResp.Amount, resp.Amount)
+			assert.Zero(t, tt.expectedResp.Rate.Cmp(resp.Rate), resp.Rate.String())
+			assert.True(t, tt.expectedResp.RateDate.Equal(resp.RateDate))
+		})
+	}
+}
+
+func TestService_SetReplacesSameDate(t *testing.T) {
+	svc := New()
+	svc.Set(mustRate(t, "2025-01-01", "USD", "1.04"))
+	svc.Set(mustRate(t, "2025-01-01", "USD", "1.05"))
+
+	resp, err := svc.Convert(10000, "EUR", "USD", time.Date(2025, time.January, 5, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, int64(10500), resp.Amount)
+}
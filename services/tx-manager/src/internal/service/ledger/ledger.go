@@ -0,0 +1,66 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/models"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/svcerr"
+)
+
+type Repository interface {
+	GetPostings(ctx context.Context, account, currency string, limit, offset int64) ([]models.Posting, error)
+	GetAccountBalance(ctx context.Context, account, currency string) (int64, error)
+}
+
+type FXService interface {
+	Convert(amount int64, from, to string, at time.Time) (*models.Conversion, error)
+}
+
+type Service struct {
+	repo Repository
+	fx   FXService
+	now  func() time.Time
+}
+
+func New(repo Repository, fx FXService) *Service {
+	return &Service{repo: repo, fx: fx, now: time.Now}
+}
+
+// ListPostings returns a page of the postings of an account in a currency, newest first, and the account balance.
+// With a reporting currency the postings are also converted at their time and the balance at the latest rates.
+func (s *Service) ListPostings(ctx context.Context, account, currency string, limit, offset int64, reportingCurrency string) ([]models.Posting, models.AccountBalance, error) {
+	if !models.ValidAccount(account) {
+		return nil, models.AccountBalance{}, fmt.Errorf("%w: unknown account: %s", svcerr.ErrBadField, account)
+	}
+
+	postings, err := s.repo.GetPostings(ctx, account, currency, limit, offset)
+	if err != nil {
+		return nil, models.AccountBalance{}, fmt.Errorf("failed to get postings: %w", err)
+	}
+
+	var balance models.AccountBalance
+	balance.Balance, err = s.repo.GetAccountBalance(ctx, account, currency)
+	if err != nil {
+		return nil, models.AccountBalance{}, fmt.Errorf("failed to get account balance: %w", err)
+	}
+
+	if len(reportingCurrency) == 0 {
+		return postings, balance, nil
+	}
+
+	for i, p := range postings {
+		postings[i].Reporting, err = s.fx.Convert(p.Amount, p.Currency, reportingCurrency, p.TransactionTime)
+		if err != nil {
+			return nil, models.AccountBalance{}, fmt.Errorf("failed to convert posting %s: %w", p.ID, err)
+		}
+	}
+
+	balance.Reporting, err = s.fx.Convert(balance.Balance, currency, reportingCurrency, s.now())
+	if err != nil {
+		return nil, models.AccountBalance{}, fmt.Errorf("failed to convert account balance: %w", err)
+	}
+
+	return postings, balance, nil
+}
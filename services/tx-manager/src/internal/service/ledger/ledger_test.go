@@ -0,0 +1,102 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/models"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/service/ledger/mocks"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/svcerr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestServiceListPostings(t *testing.T) {
+	tests := []struct {
+		name        string
+		account     string
+		mockSetup   func(repo *mocks.MockRepository)
+		wantLen     int
+		wantBalance int64
+		expectedErr error
+	}{
+		{
+			name:    "house account",
+			account: models.HouseAccount,
+			mockSetup: func(repo *mocks.MockRepository) {
+				repo.On("GetPostings", mock.Anything, models.HouseAccount, "EUR", int64(10), int64(0)).
+					Return([]models.Posting{{Account: models.HouseAccount, Amount: 100}, {Account: models.HouseAccount, Amount: -30}}, nil)
+				repo.On("GetAccountBalance", mock.Anything, models.HouseAccount, "EUR").Return(int64(70), nil)
+			},
+			wantLen:     2,
+			wantBalance: 70,
+		},
+		{
+			name:        "unknown account",
+			account:     "casino",
+			mockSetup:   func(repo *mocks.MockRepository) {},
+			expectedErr: svcerr.ErrBadField,
+		},
+		{
+			name:    "repository error",
+			account: models.PlayerAccount(uuid.New()),
+			mockSetup: func(repo *mocks.MockRepository) {
+				repo.On("GetPostings", mock.Anything, mock.Anything, "EUR", int64(10), int64(0)).Return(nil, errors.New("some error"))
+			},
+			expectedErr: errors.New("some error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoMock := mocks.NewMockRepository(t)
+			tt.mockSetup(repoMock)
+
+			postings, balance, err := New(repoMock, nil).ListPostings(context.Background(), tt.account, "EUR", 10, 0, "")
+			if tt.expectedErr != nil {
+				assert.Error(t, err)
+				if errors.Is(tt.expectedErr, svcerr.ErrBadField) {
+					assert.ErrorIs(t, err, svcerr.ErrBadField)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, postings, tt.wantLen)
+			assert.Equal(t, tt.wantBalance, balance.Balance)
+		})
+	}
+}
+
+func TestServiceListPostingsReportingCurrency(t *testing.T) {
+	now := time.Date(2025, 1, 3, 12, 0, 0, 0, time.UTC)
+	at := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	repoMock := mocks.NewMockRepository(t)
+	repoMock.On("GetPostings", mock.Anything, models.HouseAccount, "USD", int64(10), int64(0)).
+		Return([]models.Posting{{Account: models.HouseAccount, Amount: 104, Currency: "USD", TransactionTime: at}}, nil)
+	repoMock.On("GetAccountBalance", mock.Anything, models.HouseAccount, "USD").Return(int64(208), nil)
+
+	fxMock := mocks.NewMockFXService(t)
+	fxMock.On("Convert", int64(104), "USD", "EUR", at).Return(&models.Conversion{Currency: "EUR", Amount: 100, Rate: big.NewRat(100, 104)}, nil).Once()
+	fxMock.On("Convert", int64(208), "USD", "EUR", now).Return(&models.Conversion{Currency: "EUR", Amount: 200, Rate: big.NewRat(100, 104)}, nil).Once()
+
+	svc := New(repoMock, fxMock)
+	svc.now = func() time.Time { return now }
+
+	postings, balance, err := svc.ListPostings(context.Background(), models.HouseAccount, "USD", 10, 0, "EUR")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), postings[0].Reporting.Amount)
+	assert.Equal(t, int64(208), balance.Balance)
+	assert.Equal(t, int64(200), balance.Reporting.Amount)
+
+	fxMock.On("Convert", int64(104), "USD", "EUR", at).Return(nil, fmt.Errorf("%w: no USD rate", svcerr.ErrNotFound)).Once()
+
+	_, _, err = svc.ListPostings(context.Background(), models.HouseAccount, "USD", 10, 0, "EUR")
+	assert.ErrorIs(t, err, svcerr.ErrNotFound)
+}
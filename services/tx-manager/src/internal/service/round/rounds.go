@@ -0,0 +1,67 @@
+package round
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/models"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/svcerr"
+)
+
+type Repository interface {
+	GetRound(ctx context.Context, provider, id string) (*models.Round, error)
+	GetOpenRounds(ctx context.Context, filters models.RoundFilter, limit, offset int64) ([]models.Round, error)
+}
+
+type Service struct {
+	repo          Repository
+	orphanTimeout time.Duration
+	now           func() time.Time
+}
+
+func New(repo Repository, orphanTimeout time.Duration) *Service {
+	return &Service{
+		repo:          repo,
+		orphanTimeout: orphanTimeout,
+		now:           time.Now,
+	}
+}
+
+// GetRound returns the round with the given id. Round ids are only unique per
+// provider, rounds without a provider are looked up with an empty one.
+func (s *Service) GetRound(ctx context.Context, provider, id string) (*models.Round, error) {
+	resp, err := s.repo.GetRound(ctx, provider, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get round: %w", err)
+	}
+
+	if resp == nil {
+		return nil, fmt.Errorf("round with such id was not found: %w", svcerr.ErrNotFound)
+	}
+
+	resp.Flag(s.now(), s.orphanTimeout)
+
+	return resp, nil
+}
+
+// ListOpenRounds returns rounds that are not settled yet. With orphanedOnly
+// only the ones left open for longer than the orphan timeout are returned.
+func (s *Service) ListOpenRounds(ctx context.Context, filters models.RoundFilter, orphanedOnly bool, limit, offset int64) ([]models.Round, error) {
+	now := s.now()
+	if orphanedOnly {
+		before := now.Add(-s.orphanTimeout)
+		filters.OpenedBefore = &before
+	}
+
+	resp, err := s.repo.GetOpenRounds(ctx, filters, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open rounds: %w", err)
+	}
+
+	for i := range resp {
+		resp[i].Flag(now, s.orphanTimeout)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,106 @@
+package round
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/models"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/service/round/mocks"
+	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/svcerr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestServiceGetRound(t *testing.T) {
+	now := time.Date(2025, 1, 1, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		repoResp    *models.Round
+		repoErr     error
+		expectedErr error
+		orphaned    bool
+	}{
+		{
+			name:        "round not found",
+			expectedErr: svcerr.ErrNotFound,
+		},
+		{
+			name:     "orphaned round",
+			repoResp: &models.Round{ID: "round-1", Status: models.RoundOpen, BetCount: 1, OpenedAt: now.Add(-2 * time.Hour)},
+			orphaned: true,
+		},
+		{
+			name:     "recent round",
+			repoResp: &models.Round{ID: "round-1", Status: models.RoundOpen, BetCount: 1, OpenedAt: now.Add(-time.Minute)},
+		},
+		{
+			name:        "repository error",
+			repoErr:     errors.New("some error"),
+			expectedErr: errors.New("some error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoMock := mocks.NewMockRepository(t)
+			svc := New(repoMock, time.Hour)
+			svc.now = func() time.Time { return now }
+
+			repoMock.On("GetRound", mock.Anything, "pragmatic", "round-1").Return(tt.repoResp, tt.repoErr)
+
+			resp, err := svc.GetRound(context.Background(), "pragmatic", "round-1")
+			if tt.expectedErr != nil {
+				assert.Error(t, err)
+				if errors.Is(tt.expectedErr, svcerr.ErrNotFound) {
+					assert.ErrorIs(t, err, svcerr.ErrNotFound)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.orphaned, resp.Orphaned)
+		})
+	}
+}
+
+func TestServiceListOpenRounds(t *testing.T) {
+	now := time.Date(2025, 1, 1, 15, 0, 0, 0, time.UTC)
+	userID := uuid.New()
+
+	repoMock := mocks.NewMockRepository(t)
+	svc := New(repoMock, time.Hour)
+	svc.now = func() time.Time { return now }
+
+	t.Run("orphaned only filters by the timeout", func(t *testing.T) {
+		before := now.Add(-time.Hour)
+		repoMock.On("GetOpenRounds", mock.Anything, models.RoundFilter{UserID: &userID, OpenedBefore: &before}, int64(10), int64(0)).
+			Return([]models.Round{{ID: "round-1", Status: models.RoundOpen, WinCount: 1, OpenedAt: now.Add(-2 * time.Hour)}}, nil).Once()
+
+		resp, err := svc.ListOpenRounds(context.Background(), models.RoundFilter{UserID: &userID}, true, 10, 0)
+		assert.NoError(t, err)
+		assert.Len(t, resp, 1)
+		assert.True(t, resp[0].Orphaned)
+		assert.True(t, resp[0].UnmatchedWin)
+	})
+
+	t.Run("all open rounds", func(t *testing.T) {
+		repoMock.On("GetOpenRounds", mock.Anything, models.RoundFilter{}, int64(10), int64(0)).
+			Return([]models.Round{{ID: "round-1", Status: models.RoundOpen, BetCount: 1, OpenedAt: now}}, nil).Once()
+
+		resp, err := svc.ListOpenRounds(context.Background(), models.RoundFilter{}, false, 10, 0)
+		assert.NoError(t, err)
+		assert.False(t, resp[0].Orphaned)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repoMock.On("GetOpenRounds", mock.Anything, models.RoundFilter{}, int64(10), int64(0)).
+			Return(nil, errors.New("some error")).Once()
+
+		_, err := svc.ListOpenRounds(context.Background(), models.RoundFilter{}, false, 10, 0)
+		assert.Error(t, err)
+	})
+}
@@ -40,12 +40,12 @@ func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
 }
 
 // Add provides a mock function for the type MockRepository
-func (_mock *MockRepository) Add(ctx context.Context, transactions ...models.Transaction) error {
+func (_mock *MockRepository) Add(ctx context.Context, offsets []models.Offset, transactions ...models.Transaction) error {
 	var tmpRet mock.Arguments
 	if len(transactions) > 0 {
-		tmpRet = _mock.Called(ctx, transactions)
+		tmpRet = _mock.Called(ctx, offsets, transactions)
 	} else {
-		tmpRet = _mock.Called(ctx)
+		tmpRet = _mock.Called(ctx, offsets)
 	}
 	ret := tmpRet
 
@@ -54,8 +54,8 @@ func (_mock *MockRepository) Add(ctx context.Context, transactions ...models.Tra
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, ...models.Transaction) error); ok {
-		r0 = returnFunc(ctx, transactions...)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []models.Offset, ...models.Transaction) error); ok {
+		r0 = returnFunc(ctx, offsets, transactions...)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -69,27 +69,33 @@ type MockRepository_Add_Call struct {
 
 // Add is a helper method to define mock.On call
 //   - ctx context.Context
+//   - offsets []models.Offset
 //   - transactions ...models.Transaction
-func (_e *MockRepository_Expecter) Add(ctx interface{}, transactions ...interface{}) *MockRepository_Add_Call {
+func (_e *MockRepository_Expecter) Add(ctx interface{}, offsets interface{}, transactions ...interface{}) *MockRepository_Add_Call {
 	return &MockRepository_Add_Call{Call: _e.mock.On("Add",
-		append([]interface{}{ctx}, transactions...)...)}
+		append([]interface{}{ctx, offsets}, transactions...)...)}
 }
 
-func (_c *MockRepository_Add_Call) Run(run func(ctx context.Context, transactions ...models.Transaction)) *MockRepository_Add_Call {
+func (_c *MockRepository_Add_Call) Run(run func(ctx context.Context, offsets []models.Offset, transactions ...models.Transaction)) *MockRepository_Add_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 []models.Transaction
+		var arg1 []models.Offset
+		if args[1] != nil {
+			arg1 = args[1].([]models.Offset)
+		}
+		var arg2 []models.Transaction
 		var variadicArgs []models.Transaction
-		if len(args) > 1 {
-			variadicArgs = args[1].([]models.Transaction)
+		if len(args) > 2 {
+			variadicArgs = args[2].([]models.Transaction)
 		}
-		arg1 = variadicArgs
+		arg2 = variadicArgs
 		run(
 			arg0,
-			arg1...,
+			arg1,
+			arg2...,
 		)
 	})
 	return _c
@@ -100,14 +106,146 @@ func (_c *MockRepository_Add_Call) Return(err error) *MockRepository_Add_Call {
 	return _c
 }
 
-func (_c *MockRepository_Add_Call) RunAndReturn(run func(ctx context.Context, transactions ...models.Transaction) error) *MockRepository_Add_Call {
+func (_c *MockRepository_Add_Call) RunAndReturn(run func(ctx context.Context, offsets []models.Offset, transactions ...models.Transaction) error) *MockRepository_Add_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Count provides a mock function for the type MockRepository
+func (_mock *MockRepository) Count(ctx context.Context, filters models.TransactionFilter) (int64, error) {
+	ret := _mock.Called(ctx, filters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.TransactionFilter) (int64, error)); ok {
+		return returnFunc(ctx, filters)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.TransactionFilter) int64); ok {
+		r0 = returnFunc(ctx, filters)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.TransactionFilter) error); ok {
+		r1 = returnFunc(ctx, filters)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_Count_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Count'
+type MockRepository_Count_Call struct {
+	*mock.Call
+}
+
+// Count is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filters models.TransactionFilter
+func (_e *MockRepository_Expecter) Count(ctx interface{}, filters interface{}) *MockRepository_Count_Call {
+	return &MockRepository_Count_Call{Call: _e.mock.On("Count", ctx, filters)}
+}
+
+func (_c *MockRepository_Count_Call) Run(run func(ctx context.Context, filters models.TransactionFilter)) *MockRepository_Count_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.TransactionFilter
+		if args[1] != nil {
+			arg1 = args[1].(models.TransactionFilter)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_Count_Call) Return(n int64, err error) *MockRepository_Count_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockRepository_Count_Call) RunAndReturn(run func(ctx context.Context, filters models.TransactionFilter) (int64, error)) *MockRepository_Count_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EstimateCount provides a mock function for the type MockRepository
+func (_mock *MockRepository) EstimateCount(ctx context.Context, filters models.TransactionFilter) (int64, error) {
+	ret := _mock.Called(ctx, filters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EstimateCount")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.TransactionFilter) (int64, error)); ok {
+		return returnFunc(ctx, filters)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.TransactionFilter) int64); ok {
+		r0 = returnFunc(ctx, filters)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.TransactionFilter) error); ok {
+		r1 = returnFunc(ctx, filters)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_EstimateCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EstimateCount'
+type MockRepository_EstimateCount_Call struct {
+	*mock.Call
+}
+
+// EstimateCount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filters models.TransactionFilter
+func (_e *MockRepository_Expecter) EstimateCount(ctx interface{}, filters interface{}) *MockRepository_EstimateCount_Call {
+	return &MockRepository_EstimateCount_Call{Call: _e.mock.On("EstimateCount", ctx, filters)}
+}
+
+func (_c *MockRepository_EstimateCount_Call) Run(run func(ctx context.Context, filters models.TransactionFilter)) *MockRepository_EstimateCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.TransactionFilter
+		if args[1] != nil {
+			arg1 = args[1].(models.TransactionFilter)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_EstimateCount_Call) Return(n int64, err error) *MockRepository_EstimateCount_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockRepository_EstimateCount_Call) RunAndReturn(run func(ctx context.Context, filters models.TransactionFilter) (int64, error)) *MockRepository_EstimateCount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // GetAll provides a mock function for the type MockRepository
-func (_mock *MockRepository) GetAll(ctx context.Context, filters models.TransactionFilter, orderBy string, limit int64, offset int64) ([]models.Transaction, error) {
-	ret := _mock.Called(ctx, filters, orderBy, limit, offset)
+func (_mock *MockRepository) GetAll(ctx context.Context, filters models.TransactionFilter, sort []models.Sort, limit int64, offset int64) ([]models.Transaction, error) {
+	ret := _mock.Called(ctx, filters, sort, limit, offset)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAll")
@@ -115,18 +253,18 @@ func (_mock *MockRepository) GetAll(ctx context.Context, filters models.Transact
 
 	var r0 []models.Transaction
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, models.TransactionFilter, string, int64, int64) ([]models.Transaction, error)); ok {
-		return returnFunc(ctx, filters, orderBy, limit, offset)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.TransactionFilter, []models.Sort, int64, int64) ([]models.Transaction, error)); ok {
+		return returnFunc(ctx, filters, sort, limit, offset)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, models.TransactionFilter, string, int64, int64) []models.Transaction); ok {
-		r0 = returnFunc(ctx, filters, orderBy, limit, offset)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.TransactionFilter, []models.Sort, int64, int64) []models.Transaction); ok {
+		r0 = returnFunc(ctx, filters, sort, limit, offset)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.Transaction)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, models.TransactionFilter, string, int64, int64) error); ok {
-		r1 = returnFunc(ctx, filters, orderBy, limit, offset)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.TransactionFilter, []models.Sort, int64, int64) error); ok {
+		r1 = returnFunc(ctx, filters, sort, limit, offset)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -141,14 +279,14 @@ type MockRepository_GetAll_Call struct {
 // GetAll is a helper method to define mock.On call
 //   - ctx context.Context
 //   - filters models.TransactionFilter
-//   - orderBy string
+//   - sort []models.Sort
 //   - limit int64
 //   - offset int64
-func (_e *MockRepository_Expecter) GetAll(ctx interface{}, filters interface{}, orderBy interface{}, limit interface{}, offset interface{}) *MockRepository_GetAll_Call {
-	return &MockRepository_GetAll_Call{Call: _e.mock.On("GetAll", ctx, filters, orderBy, limit, offset)}
+func (_e *MockRepository_Expecter) GetAll(ctx interface{}, filters interface{}, sort interface{}, limit interface{}, offset interface{}) *MockRepository_GetAll_Call {
+	return &MockRepository_GetAll_Call{Call: _e.mock.On("GetAll", ctx, filters, sort, limit, offset)}
 }
 
-func (_c *MockRepository_GetAll_Call) Run(run func(ctx context.Context, filters models.TransactionFilter, orderBy string, limit int64, offset int64)) *MockRepository_GetAll_Call {
+func (_c *MockRepository_GetAll_Call) Run(run func(ctx context.Context, filters models.TransactionFilter, sort []models.Sort, limit int64, offset int64)) *MockRepository_GetAll_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -158,9 +296,9 @@ func (_c *MockRepository_GetAll_Call) Run(run func(ctx context.Context, filters
 		if args[1] != nil {
 			arg1 = args[1].(models.TransactionFilter)
 		}
-		var arg2 string
+		var arg2 []models.Sort
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].([]models.Sort)
 		}
 		var arg3 int64
 		if args[3] != nil {
@@ -186,29 +324,97 @@ func (_c *MockRepository_GetAll_Call) Return(transactions []models.Transaction,
 	return _c
 }
 
-func (_c *MockRepository_GetAll_Call) RunAndReturn(run func(ctx context.Context, filters models.TransactionFilter, orderBy string, limit int64, offset int64) ([]models.Transaction, error)) *MockRepository_GetAll_Call {
+func (_c *MockRepository_GetAll_Call) RunAndReturn(run func(ctx context.Context, filters models.TransactionFilter, sort []models.Sort, limit int64, offset int64) ([]models.Transaction, error)) *MockRepository_GetAll_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByID provides a mock function for the type MockRepository
-func (_mock *MockRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Transaction, error) {
+// GetByIDs provides a mock function for the type MockRepository
+func (_mock *MockRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]models.Transaction, error) {
+	ret := _mock.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDs")
+	}
+
+	var r0 []models.Transaction
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []uuid.UUID) ([]models.Transaction, error)); ok {
+		return returnFunc(ctx, ids)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []uuid.UUID) []models.Transaction); ok {
+		r0 = returnFunc(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Transaction)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_GetByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByIDs'
+type MockRepository_GetByIDs_Call struct {
+	*mock.Call
+}
+
+// GetByIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []uuid.UUID
+func (_e *MockRepository_Expecter) GetByIDs(ctx interface{}, ids interface{}) *MockRepository_GetByIDs_Call {
+	return &MockRepository_GetByIDs_Call{Call: _e.mock.On("GetByIDs", ctx, ids)}
+}
+
+func (_c *MockRepository_GetByIDs_Call) Run(run func(ctx context.Context, ids []uuid.UUID)) *MockRepository_GetByIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].([]uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetByIDs_Call) Return(transactions []models.Transaction, err error) *MockRepository_GetByIDs_Call {
+	_c.Call.Return(transactions, err)
+	return _c
+}
+
+func (_c *MockRepository_GetByIDs_Call) RunAndReturn(run func(ctx context.Context, ids []uuid.UUID) ([]models.Transaction, error)) *MockRepository_GetByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChain provides a mock function for the type MockRepository
+func (_mock *MockRepository) GetChain(ctx context.Context, id uuid.UUID) ([]models.Transaction, error) {
 	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByID")
+		panic("no return value specified for GetChain")
 	}
 
-	var r0 *models.Transaction
+	var r0 []models.Transaction
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*models.Transaction, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]models.Transaction, error)); ok {
 		return returnFunc(ctx, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.Transaction); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []models.Transaction); ok {
 		r0 = returnFunc(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Transaction)
+			r0 = ret.Get(0).([]models.Transaction)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
@@ -219,19 +425,19 @@ func (_mock *MockRepository) GetByID(ctx context.Context, id uuid.UUID) (*models
 	return r0, r1
 }
 
-// MockRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
-type MockRepository_GetByID_Call struct {
+// MockRepository_GetChain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChain'
+type MockRepository_GetChain_Call struct {
 	*mock.Call
 }
 
-// GetByID is a helper method to define mock.On call
+// GetChain is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id uuid.UUID
-func (_e *MockRepository_Expecter) GetByID(ctx interface{}, id interface{}) *MockRepository_GetByID_Call {
-	return &MockRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+func (_e *MockRepository_Expecter) GetChain(ctx interface{}, id interface{}) *MockRepository_GetChain_Call {
+	return &MockRepository_GetChain_Call{Call: _e.mock.On("GetChain", ctx, id)}
 }
 
-func (_c *MockRepository_GetByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockRepository_GetByID_Call {
+func (_c *MockRepository_GetChain_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockRepository_GetChain_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -249,12 +455,211 @@ func (_c *MockRepository_GetByID_Call) Run(run func(ctx context.Context, id uuid
 	return _c
 }
 
-func (_c *MockRepository_GetByID_Call) Return(transaction *models.Transaction, err error) *MockRepository_GetByID_Call {
-	_c.Call.Return(transaction, err)
+func (_c *MockRepository_GetChain_Call) Return(transactions []models.Transaction, err error) *MockRepository_GetChain_Call {
+	_c.Call.Return(transactions, err)
+	return _c
+}
+
+func (_c *MockRepository_GetChain_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) ([]models.Transaction, error)) *MockRepository_GetChain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOffsets provides a mock function for the type MockRepository
+func (_mock *MockRepository) GetOffsets(ctx context.Context, group string) ([]models.Offset, error) {
+	ret := _mock.Called(ctx, group)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOffsets")
+	}
+
+	var r0 []models.Offset
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]models.Offset, error)); ok {
+		return returnFunc(ctx, group)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []models.Offset); ok {
+		r0 = returnFunc(ctx, group)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Offset)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, group)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_GetOffsets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOffsets'
+type MockRepository_GetOffsets_Call struct {
+	*mock.Call
+}
+
+// GetOffsets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - group string
+func (_e *MockRepository_Expecter) GetOffsets(ctx interface{}, group interface{}) *MockRepository_GetOffsets_Call {
+	return &MockRepository_GetOffsets_Call{Call: _e.mock.On("GetOffsets", ctx, group)}
+}
+
+func (_c *MockRepository_GetOffsets_Call) Run(run func(ctx context.Context, group string)) *MockRepository_GetOffsets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetOffsets_Call) Return(offsets []models.Offset, err error) *MockRepository_GetOffsets_Call {
+	_c.Call.Return(offsets, err)
+	return _c
+}
+
+func (_c *MockRepository_GetOffsets_Call) RunAndReturn(run func(ctx context.Context, group string) ([]models.Offset, error)) *MockRepository_GetOffsets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPage provides a mock function for the type MockRepository
+func (_mock *MockRepository) GetPage(ctx context.Context, filters models.TransactionFilter, after *models.Cursor, limit int64) ([]models.Transaction, error) {
+	ret := _mock.Called(ctx, filters, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPage")
+	}
+
+	var r0 []models.Transaction
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.TransactionFilter, *models.Cursor, int64) ([]models.Transaction, error)); ok {
+		return returnFunc(ctx, filters, after, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.TransactionFilter, *models.Cursor, int64) []models.Transaction); ok {
+		r0 = returnFunc(ctx, filters, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Transaction)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.TransactionFilter, *models.Cursor, int64) error); ok {
+		r1 = returnFunc(ctx, filters, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_GetPage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPage'
+type MockRepository_GetPage_Call struct {
+	*mock.Call
+}
+
+// GetPage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filters models.TransactionFilter
+//   - after *models.Cursor
+//   - limit int64
+func (_e *MockRepository_Expecter) GetPage(ctx interface{}, filters interface{}, after interface{}, limit interface{}) *MockRepository_GetPage_Call {
+	return &MockRepository_GetPage_Call{Call: _e.mock.On("GetPage", ctx, filters, after, limit)}
+}
+
+func (_c *MockRepository_GetPage_Call) Run(run func(ctx context.Context, filters models.TransactionFilter, after *models.Cursor, limit int64)) *MockRepository_GetPage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.TransactionFilter
+		if args[1] != nil {
+			arg1 = args[1].(models.TransactionFilter)
+		}
+		var arg2 *models.Cursor
+		if args[2] != nil {
+			arg2 = args[2].(*models.Cursor)
+		}
+		var arg3 int64
+		if args[3] != nil {
+			arg3 = args[3].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetPage_Call) Return(transactions []models.Transaction, err error) *MockRepository_GetPage_Call {
+	_c.Call.Return(transactions, err)
+	return _c
+}
+
+func (_c *MockRepository_GetPage_Call) RunAndReturn(run func(ctx context.Context, filters models.TransactionFilter, after *models.Cursor, limit int64) ([]models.Transaction, error)) *MockRepository_GetPage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Ping provides a mock function for the type MockRepository
+func (_mock *MockRepository) Ping(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ping")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepository_Ping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Ping'
+type MockRepository_Ping_Call struct {
+	*mock.Call
+}
+
+// Ping is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockRepository_Expecter) Ping(ctx interface{}) *MockRepository_Ping_Call {
+	return &MockRepository_Ping_Call{Call: _e.mock.On("Ping", ctx)}
+}
+
+func (_c *MockRepository_Ping_Call) Run(run func(ctx context.Context)) *MockRepository_Ping_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_Ping_Call) Return(err error) *MockRepository_Ping_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepository_GetByID_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (*models.Transaction, error)) *MockRepository_GetByID_Call {
+func (_c *MockRepository_Ping_Call) RunAndReturn(run func(ctx context.Context) error) *MockRepository_Ping_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -3,6 +3,7 @@ package transaction
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/models"
 	"github.com/e1esm/casino-transaction-system/tx-manager/src/internal/svcerr"
@@ -10,44 +11,184 @@ import (
 )
 
 type Repository interface {
-	GetByID(ctx context.Context, id uuid.UUID) (*models.Transaction, error)
-	GetAll(ctx context.Context, filters models.TransactionFilter, orderBy string, limit, offset int64) ([]models.Transaction, error)
-	Add(ctx context.Context, transactions ...models.Transaction) error
+	GetChain(ctx context.Context, id uuid.UUID) ([]models.Transaction, error)
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]models.Transaction, error)
+	GetAll(ctx context.Context, filters models.TransactionFilter, sort []models.Sort, limit, offset int64) ([]models.Transaction, error)
+	GetPage(ctx context.Context, filters models.TransactionFilter, after *models.Cursor, limit int64) ([]models.Transaction, error)
+	Count(ctx context.Context, filters models.TransactionFilter) (int64, error)
+	EstimateCount(ctx context.Context, filters models.TransactionFilter) (int64, error)
+	Add(ctx context.Context, offsets []models.Offset, transactions ...models.Transaction) error
+	GetOffsets(ctx context.Context, group string) ([]models.Offset, error)
+	Ping(ctx context.Context) error
+}
+
+type FXService interface {
+	Convert(amount int64, from, to string, at time.Time) (*models.Conversion, error)
 }
 
 type Service struct {
-	repo Repository
+	repo         Repository
+	fx           FXService
+	maxBatchSize int
 }
 
-func New(repo Repository) *Service {
-	return &Service{repo: repo}
+func New(repo Repository, fx FXService, maxBatchSize int) *Service {
+	return &Service{
+		repo:         repo,
+		fx:           fx,
+		maxBatchSize: maxBatchSize,
+	}
 }
 
-func (s *Service) GetByID(ctx context.Context, id uuid.UUID) (*models.Transaction, error) {
-	resp, err := s.repo.GetByID(ctx, id)
+func (s *Service) GetChain(ctx context.Context, id uuid.UUID) ([]models.Transaction, error) {
+	resp, err := s.repo.GetChain(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get transaction by ID: %w", err)
+		return nil, fmt.Errorf("failed to get related transactions: %w", err)
 	}
 
-	if resp == nil {
+	if len(resp) == 0 {
 		return nil, fmt.Errorf("transaction with such id was not found: %w", svcerr.ErrNotFound)
 	}
 
 	return resp, nil
 }
 
-func (s *Service) GetAll(ctx context.Context, filters models.TransactionFilter, orderBy string, limit, offset int64) ([]models.Transaction, int64, error) {
-	resp, err := s.repo.GetAll(ctx, filters, orderBy, limit, offset)
+// BatchGet returns the transactions with the given ids in the order they were asked for, and the ids that weren't found.
+func (s *Service) BatchGet(ctx context.Context, ids []uuid.UUID, reportingCurrency string) ([]models.Transaction, []uuid.UUID, error) {
+	if len(ids) == 0 {
+		return nil, nil, fmt.Errorf("%w: no ids given", svcerr.ErrBadField)
+	}
+
+	if len(ids) > s.maxBatchSize {
+		return nil, nil, fmt.Errorf("%w: batch is limited to %d ids", svcerr.ErrBadField, s.maxBatchSize)
+	}
+
+	unique := make([]uuid.UUID, 0, len(ids))
+	seen := make(map[uuid.UUID]struct{}, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; !ok {
+			seen[id] = struct{}{}
+			unique = append(unique, id)
+		}
+	}
+
+	resp, err := s.repo.GetByIDs(ctx, unique)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get transactions by IDs: %w", err)
+	}
+
+	byID := make(map[uuid.UUID]models.Transaction, len(resp))
+	for _, tr := range resp {
+		byID[tr.ID] = tr
+	}
+
+	found := make([]models.Transaction, 0, len(resp))
+	var missing []uuid.UUID
+
+	for _, id := range unique {
+		if tr, ok := byID[id]; ok {
+			found = append(found, tr)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+
+	if err := s.report(found, reportingCurrency); err != nil {
+		return nil, nil, err
+	}
+
+	return found, missing, nil
+}
+
+func (s *Service) GetAll(ctx context.Context, filters models.TransactionFilter, sort []models.Sort, limit, offset int64, reportingCurrency string) ([]models.Transaction, error) {
+	resp, err := s.repo.GetAll(ctx, filters, sort, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions by filters: %w", err)
+	}
+
+	if err := s.report(resp, reportingCurrency); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// Count returns the number of transactions matching filters, or the planner's estimate of it.
+func (s *Service) Count(ctx context.Context, filters models.TransactionFilter, mode models.CountMode) (models.Total, error) {
+	if mode == models.CountEstimated {
+		n, err := s.repo.EstimateCount(ctx, filters)
+		if err != nil {
+			return models.Total{}, fmt.Errorf("failed to estimate transactions count: %w", err)
+		}
+
+		return models.Total{Count: n}, nil
+	}
+
+	n, err := s.repo.Count(ctx, filters)
 	if err != nil {
-		return nil, -1, fmt.Errorf("failed to get transactions by filters: %w", err)
+		return models.Total{}, fmt.Errorf("failed to count transactions: %w", err)
 	}
 
-	return resp, int64(len(resp)), nil
+	return models.Total{Count: n, Exact: true}, nil
 }
 
-func (s *Service) Create(ctx context.Context, transactions ...models.Transaction) error {
-	if len(transactions) == 0 {
+// GetPage returns up to limit transactions after the cursor, newest first, and the cursor of the next page if there is one.
+func (s *Service) GetPage(ctx context.Context, filters models.TransactionFilter, after *models.Cursor, limit int64, reportingCurrency string) ([]models.Transaction, *models.Cursor, error) {
+	resp, err := s.repo.GetPage(ctx, filters, after, limit+1)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get page of transactions: %w", err)
+	}
+
+	var next *models.Cursor
+	if int64(len(resp)) > limit {
+		resp = resp[:limit]
+		cursor := models.CursorOf(resp[len(resp)-1])
+		next = &cursor
+	}
+
+	if err := s.report(resp, reportingCurrency); err != nil {
+		return nil, nil, err
+	}
+
+	return resp, next, nil
+}
+
+// report converts the amounts of transactions with the rates valid at their time,
+// nothing is converted without a reporting currency.
+func (s *Service) report(transactions []models.Transaction, reportingCurrency string) error {
+	if len(reportingCurrency) == 0 {
 		return nil
 	}
-	return s.repo.Add(ctx, transactions...)
+
+	for i, tr := range transactions {
+		conversion, err := s.fx.Convert(tr.Amount, tr.Currency, reportingCurrency, tr.TransactionTime)
+		if err != nil {
+			return fmt.Errorf("failed to convert transaction %s: %w", tr.ID, err)
+		}
+
+		transactions[i].Reporting = conversion
+	}
+
+	return nil
+}
+
+func (s *Service) Create(ctx context.Context, offsets []models.Offset, transactions ...models.Transaction) error {
+	if len(transactions) == 0 && len(offsets) == 0 {
+		return nil
+	}
+
+	return s.repo.Add(ctx, offsets, transactions...)
+}
+
+func (s *Service) Offsets(ctx context.Context, group string) ([]models.Offset, error) {
+	resp, err := s.repo.GetOffsets(ctx, group)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stored offsets: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (s *Service) Ping(ctx context.Context) error {
+	return s.repo.Ping(ctx)
 }
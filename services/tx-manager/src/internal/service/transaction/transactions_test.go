@@ -3,6 +3,8 @@ package transaction
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math/big"
 	"testing"
 	"time"
 
@@ -16,10 +18,11 @@ import (
 
 func TestServiceCreate(t *testing.T) {
 	cliMock := mocks.NewMockRepository(t)
-	svc := New(cliMock)
+	svc := New(cliMock, nil, 100)
 
 	tests := []struct {
 		name         string
+		offsets      []models.Offset
 		transactions []models.Transaction
 		expectedErr  error
 	}{
@@ -28,6 +31,13 @@ func TestServiceCreate(t *testing.T) {
 			transactions: []models.Transaction{},
 			expectedErr:  nil,
 		},
+		{
+			name: "only offsets are stored",
+			offsets: []models.Offset{
+				{Group: "g1", Topic: "t1", Partition: 0, Offset: 10},
+			},
+			expectedErr: nil,
+		},
 		{
 			name: "single transaction",
 			transactions: []models.Transaction{
@@ -72,100 +82,137 @@ func TestServiceCreate(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		if len(tt.transactions) != 0 {
-			cliMock.On("Add", mock.Anything, mock.Anything).Return(tt.expectedErr)
+		switch {
+		case len(tt.transactions) != 0:
+			cliMock.On("Add", mock.Anything, tt.offsets, mock.Anything).Return(tt.expectedErr).Once()
+		case len(tt.offsets) != 0:
+			cliMock.On("Add", mock.Anything, tt.offsets).Return(tt.expectedErr).Once()
 		}
 
-		err := svc.Create(context.Background(), tt.transactions...)
+		err := svc.Create(context.Background(), tt.offsets, tt.transactions...)
 
 		assert.Equal(t, tt.expectedErr, err, tt.name)
 		cliMock.AssertExpectations(t)
 	}
 }
 
-func TestServiceGetByID(t *testing.T) {
-	cliMock := mocks.NewMockRepository(t)
-	svc := New(cliMock)
+func TestServiceGetChain(t *testing.T) {
+	repoMock := mocks.NewMockRepository(t)
+	svc := New(repoMock, nil, 100)
 	id := uuid.New()
+	chain := []models.Transaction{{ID: uuid.New(), Type: models.Bet}, {ID: id, Type: models.Rollback}}
+
+	repoMock.On("GetChain", mock.Anything, id).Return(chain, nil).Once()
+
+	resp, err := svc.GetChain(context.Background(), id)
+	assert.NoError(t, err)
+	assert.Equal(t, chain, resp)
+
+	dbErr := errors.New("some error")
+	repoMock.On("GetChain", mock.Anything, id).Return(nil, dbErr).Once()
+
+	_, err = svc.GetChain(context.Background(), id)
+	assert.ErrorIs(t, err, dbErr)
+
+	repoMock.On("GetChain", mock.Anything, id).Return(nil, nil).Once()
+
+	_, err = svc.GetChain(context.Background(), id)
+	assert.ErrorIs(t, err, svcerr.ErrNotFound)
+}
+
+func TestServiceBatchGet(t *testing.T) {
+	id1, id2, id3 := uuid.New(), uuid.New(), uuid.New()
+	tx1 := models.Transaction{ID: id1, Type: models.Bet}
+	tx3 := models.Transaction{ID: id3, Type: models.Win}
 
 	tests := []struct {
-		name        string
-		requestedID uuid.UUID
-		expectedTx  *models.Transaction
-		expectedErr error
+		name            string
+		ids             []uuid.UUID
+		maxBatchSize    int
+		mockSetup       func(repo *mocks.MockRepository)
+		expectedTxs     []models.Transaction
+		expectedMissing []uuid.UUID
+		expectedErr     error
 	}{
 		{
-			name:        "transaction not found",
-			requestedID: uuid.New(),
-			expectedTx:  nil,
-			expectedErr: svcerr.ErrNotFound,
+			name:         "found in the asked order and missing ids",
+			ids:          []uuid.UUID{id3, id2, id1, id3},
+			maxBatchSize: 4,
+			mockSetup: func(repo *mocks.MockRepository) {
+				repo.On("GetByIDs", mock.Anything, []uuid.UUID{id3, id2, id1}).Return([]models.Transaction{tx1, tx3}, nil)
+			},
+			expectedTxs:     []models.Transaction{tx3, tx1},
+			expectedMissing: []uuid.UUID{id2},
 		},
 		{
-			name:        "transaction was found",
-			requestedID: id,
-			expectedTx: &models.Transaction{
-				ID:              id,
-				UserID:          uuid.Nil,
-				Amount:          100,
-				Type:            models.Bet,
-				TransactionTime: time.Now(),
-			},
-			expectedErr: nil,
+			name:         "no ids",
+			maxBatchSize: 4,
+			mockSetup:    func(repo *mocks.MockRepository) {},
+			expectedErr:  svcerr.ErrBadField,
+		},
+		{
+			name:         "over the batch size",
+			ids:          []uuid.UUID{id1, id2, id3},
+			maxBatchSize: 2,
+			mockSetup:    func(repo *mocks.MockRepository) {},
+			expectedErr:  svcerr.ErrBadField,
 		},
 		{
-			name:        "some internal error",
-			requestedID: id,
-			expectedTx:  nil,
-			expectedErr: errors.New("some error"),
+			name:         "repository fails",
+			ids:          []uuid.UUID{id1},
+			maxBatchSize: 4,
+			mockSetup: func(repo *mocks.MockRepository) {
+				repo.On("GetByIDs", mock.Anything, []uuid.UUID{id1}).Return(nil, errors.New("connection refused"))
+			},
+			expectedErr: errors.New("connection refused"),
 		},
 	}
 
 	for _, tt := range tests {
-		switch {
-		case errors.Is(tt.expectedErr, svcerr.ErrNotFound):
-			cliMock.On("GetByID", mock.Anything, tt.requestedID).Return(tt.expectedTx, nil)
-		case tt.expectedErr == nil:
-			cliMock.On("GetByID", mock.Anything, tt.requestedID).Return(tt.expectedTx, nil)
-		default:
-			cliMock.On("GetByID", mock.Anything, tt.requestedID).Return(nil, tt.expectedErr)
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockRepository(t)
+			tt.mockSetup(repo)
 
-		}
+			found, missing, err := New(repo, nil, tt.maxBatchSize).BatchGet(context.Background(), tt.ids, "")
+			if tt.expectedErr != nil {
+				assert.Error(t, err)
+				if errors.Is(tt.expectedErr, svcerr.ErrBadField) {
+					assert.ErrorIs(t, err, svcerr.ErrBadField)
+				}
+				return
+			}
 
-		resp, err := svc.GetByID(context.Background(), tt.requestedID)
-		assert.Equal(t, tt.expectedTx, resp, tt.name)
-		assert.ErrorIs(t, err, tt.expectedErr, tt.name)
-		cliMock.AssertExpectations(t)
-		cliMock.ExpectedCalls = nil
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedTxs, found)
+			assert.Equal(t, tt.expectedMissing, missing)
+		})
 	}
 }
 
 func TestServiceGetAll(t *testing.T) {
 	cliMock := mocks.NewMockRepository(t)
-	svc := New(cliMock)
+	svc := New(cliMock, nil, 100)
 
 	tests := []struct {
 		name          string
 		filters       models.TransactionFilter
-		orderBy       string
+		sort          []models.Sort
 		offset, limit int64
-		expectedTotal int64
 		expectedTxs   []models.Transaction
 		expectedErr   error
 	}{
 		{
-			name:          "invalid order by",
-			filters:       models.TransactionFilter{},
-			orderBy:       "date desc",
-			offset:        0,
-			limit:         1,
-			expectedTxs:   nil,
-			expectedTotal: -1,
-			expectedErr:   svcerr.ErrBadField,
+			name:        "invalid order by",
+			filters:     models.TransactionFilter{},
+			sort:        []models.Sort{{Field: "date", Desc: true}},
+			offset:      0,
+			limit:       1,
+			expectedTxs: nil,
+			expectedErr: svcerr.ErrBadField,
 		},
 		{
 			name:        "limit 0",
 			filters:     models.TransactionFilter{},
-			orderBy:     "",
 			offset:      0,
 			limit:       0,
 			expectedTxs: nil,
@@ -174,11 +221,10 @@ func TestServiceGetAll(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		cliMock.On("GetAll", mock.Anything, tt.filters, tt.orderBy, tt.limit, tt.offset).Return(tt.expectedTxs, tt.expectedErr)
+		cliMock.On("GetAll", mock.Anything, tt.filters, tt.sort, tt.limit, tt.offset).Return(tt.expectedTxs, tt.expectedErr)
 
-		resp, total, err := svc.GetAll(context.Background(), tt.filters, tt.orderBy, tt.limit, tt.offset)
+		resp, err := svc.GetAll(context.Background(), tt.filters, tt.sort, tt.limit, tt.offset, "")
 
-		assert.Equal(t, tt.expectedTotal, total, tt.name)
 		assert.Equal(t, tt.expectedTxs, resp, tt.name)
 		assert.ErrorIs(t, err, tt.expectedErr, tt.name)
 
@@ -186,3 +232,172 @@ func TestServiceGetAll(t *testing.T) {
 		cliMock.ExpectedCalls = nil
 	}
 }
+
+func TestServiceCount(t *testing.T) {
+	userID := uuid.New()
+	filters := models.TransactionFilter{UserID: &userID}
+
+	tests := []struct {
+		name          string
+		mode          models.CountMode
+		mockSetup     func(repo *mocks.MockRepository)
+		expectedTotal models.Total
+		expectedErr   bool
+	}{
+		{
+			name: "exact",
+			mode: models.CountExact,
+			mockSetup: func(repo *mocks.MockRepository) {
+				repo.On("Count", mock.Anything, filters).Return(int64(42), nil)
+			},
+			expectedTotal: models.Total{Count: 42, Exact: true},
+		},
+		{
+			name: "estimated",
+			mode: models.CountEstimated,
+			mockSetup: func(repo *mocks.MockRepository) {
+				repo.On("EstimateCount", mock.Anything, filters).Return(int64(40), nil)
+			},
+			expectedTotal: models.Total{Count: 40},
+		},
+		{
+			name: "count fails",
+			mode: models.CountExact,
+			mockSetup: func(repo *mocks.MockRepository) {
+				repo.On("Count", mock.Anything, filters).Return(int64(0), errors.New("connection refused"))
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockRepository(t)
+			tt.mockSetup(repo)
+
+			resp, err := New(repo, nil, 100).Count(context.Background(), filters, tt.mode)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedTotal, resp)
+		})
+	}
+}
+
+func TestServiceGetPageReportingCurrency(t *testing.T) {
+	at := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+	tx1 := models.Transaction{ID: uuid.New(), Amount: 10400, Currency: "USD", TransactionTime: at}
+	tx2 := models.Transaction{ID: uuid.New(), Amount: 5200, Currency: "USD", TransactionTime: at.Add(-time.Minute)}
+
+	repo := mocks.NewMockRepository(t)
+	repo.On("GetPage", mock.Anything, models.TransactionFilter{}, (*models.Cursor)(nil), int64(2)).Return([]models.Transaction{tx1, tx2}, nil)
+
+	fxMock := mocks.NewMockFXService(t)
+	fxMock.On("Convert", int64(10400), "USD", "EUR", at).Return(&models.Conversion{Currency: "EUR", Amount: 10000, Rate: big.NewRat(100, 104)}, nil).Once()
+
+	resp, next, err := New(repo, fxMock, 100).GetPage(context.Background(), models.TransactionFilter{}, nil, 1, "EUR")
+	assert.NoError(t, err)
+	assert.Len(t, resp, 1)
+	assert.Equal(t, int64(10000), resp[0].Reporting.Amount)
+	assert.Equal(t, &models.Cursor{Time: at, ID: tx1.ID}, next)
+
+	fxMock.On("Convert", int64(10400), "USD", "EUR", at).Return(nil, fmt.Errorf("%w: no USD rate", svcerr.ErrNotFound)).Once()
+
+	_, _, err = New(repo, fxMock, 100).GetPage(context.Background(), models.TransactionFilter{}, nil, 1, "EUR")
+	assert.ErrorIs(t, err, svcerr.ErrNotFound)
+}
+
+func TestServiceGetPage(t *testing.T) {
+	now := time.Now()
+	tx1 := models.Transaction{ID: uuid.New(), TransactionTime: now}
+	tx2 := models.Transaction{ID: uuid.New(), TransactionTime: now.Add(-time.Minute)}
+	tx3 := models.Transaction{ID: uuid.New(), TransactionTime: now.Add(-2 * time.Minute)}
+	after := models.CursorOf(tx1)
+
+	tests := []struct {
+		name         string
+		after        *models.Cursor
+		limit        int64
+		repoResp     []models.Transaction
+		repoErr      error
+		expectedTxs  []models.Transaction
+		expectedNext *models.Cursor
+		expectedErr  bool
+	}{
+		{
+			name:         "more rows than the limit",
+			limit:        2,
+			repoResp:     []models.Transaction{tx1, tx2, tx3},
+			expectedTxs:  []models.Transaction{tx1, tx2},
+			expectedNext: &models.Cursor{Time: tx2.TransactionTime, ID: tx2.ID},
+		},
+		{
+			name:        "last page",
+			after:       &after,
+			limit:       2,
+			repoResp:    []models.Transaction{tx2, tx3},
+			expectedTxs: []models.Transaction{tx2, tx3},
+		},
+		{
+			name:        "repository error",
+			limit:       2,
+			repoErr:     errors.New("connection refused"),
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockRepository(t)
+			repo.On("GetPage", mock.Anything, models.TransactionFilter{}, tt.after, tt.limit+1).Return(tt.repoResp, tt.repoErr)
+
+			resp, next, err := New(repo, nil, 100).GetPage(context.Background(), models.TransactionFilter{}, tt.after, tt.limit, "")
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedTxs, resp)
+			assert.Equal(t, tt.expectedNext, next)
+		})
+	}
+}
+
+func TestServiceOffsets(t *testing.T) {
+	cliMock := mocks.NewMockRepository(t)
+	svc := New(cliMock, nil, 100)
+
+	tests := []struct {
+		name            string
+		group           string
+		expectedOffsets []models.Offset
+		expectedErr     error
+	}{
+		{
+			name:  "stored offsets are returned",
+			group: "g1",
+			expectedOffsets: []models.Offset{
+				{Group: "g1", Topic: "t1", Partition: 0, Offset: 10},
+			},
+		},
+		{
+			name:        "repository error is wrapped",
+			group:       "g2",
+			expectedErr: errors.New("connection refused"),
+		},
+	}
+
+	for _, tt := range tests {
+		cliMock.On("GetOffsets", mock.Anything, tt.group).Return(tt.expectedOffsets, tt.expectedErr).Once()
+
+		resp, err := svc.Offsets(context.Background(), tt.group)
+
+		assert.Equal(t, tt.expectedOffsets, resp, tt.name)
+		assert.ErrorIs(t, err, tt.expectedErr, tt.name)
+		cliMock.AssertExpectations(t)
+	}
+}
@@ -5,6 +5,9 @@ import "errors"
 var (
 	ErrNotFound = errors.New("not found")
 	ErrBadField = errors.New("bad field")
+	ErrRejected = errors.New("rejected")
+	// ErrTransient marks a rejection that may not repeat on a later attempt.
+	ErrTransient = errors.New("transient")
 )
 
 func IsNotFound(err error) bool {
@@ -14,3 +17,11 @@ func IsNotFound(err error) bool {
 func IsBadRequest(err error) bool {
 	return errors.Is(err, ErrBadField)
 }
+
+func IsRejected(err error) bool {
+	return errors.Is(err, ErrRejected)
+}
+
+func IsTransient(err error) bool {
+	return errors.Is(err, ErrTransient)
+}
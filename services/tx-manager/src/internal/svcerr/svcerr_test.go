@@ -62,3 +62,59 @@ func TestIsNotFound(t *testing.T) {
 		assert.Equal(t, IsNotFound(tt.err), tt.expectedResp, tt.name)
 	}
 }
+
+func TestIsRejected(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedResp bool
+	}{
+		{
+			name:         "no error was passed",
+			err:          nil,
+			expectedResp: false,
+		},
+		{
+			name:         "bad request error was passed",
+			err:          fmt.Errorf("%w: missing id", ErrBadField),
+			expectedResp: false,
+		},
+		{
+			name:         "rejected error was passed",
+			err:          fmt.Errorf("%w: numeric value out of range", ErrRejected),
+			expectedResp: true,
+		},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, IsRejected(tt.err), tt.expectedResp, tt.name)
+	}
+}
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedResp bool
+	}{
+		{
+			name:         "no error was passed",
+			err:          nil,
+			expectedResp: false,
+		},
+		{
+			name:         "rejected error was passed",
+			err:          fmt.Errorf("%w: numeric value out of range", ErrRejected),
+			expectedResp: false,
+		},
+		{
+			name:         "transient rejected error was passed",
+			err:          fmt.Errorf("%w: %w: violates foreign key constraint", ErrRejected, ErrTransient),
+			expectedResp: true,
+		},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, IsTransient(tt.err), tt.expectedResp, tt.name)
+	}
+}